@@ -7,6 +7,8 @@ import (
 	"strings"
 )
 
+// adminRequest calls the undocumented users.admin.* endpoints, which reject enterprise
+// tokens. The supported admin.users.* methods are in admin_users.go.
 func (api *Client) adminRequest(ctx context.Context, method string, teamName string, values url.Values) error {
 	resp := &SlackResponse{}
 	err := parseAdminResponse(ctx, api.httpclient, method, teamName, values, resp, api)
@@ -18,11 +20,17 @@ func (api *Client) adminRequest(ctx context.Context, method string, teamName str
 }
 
 // DisableUser disabled a user account, given a user ID
+//
+// Deprecated: The Web API has no admin.users method deactivating an account: use the
+// SCIM API instead, see DeleteUser of github.com/slack-go/slack/scim.
 func (api *Client) DisableUser(teamName string, uid string) error {
 	return api.DisableUserContext(context.Background(), teamName, uid)
 }
 
 // DisableUserContext disabled a user account, given a user ID with a custom context
+//
+// Deprecated: The Web API has no admin.users method deactivating an account: use the
+// SCIM API instead, see DeleteUser of github.com/slack-go/slack/scim.
 func (api *Client) DisableUserContext(ctx context.Context, teamName string, uid string) error {
 	values := url.Values{
 		"user":       {uid},
@@ -39,11 +47,15 @@ func (api *Client) DisableUserContext(ctx context.Context, teamName string, uid
 }
 
 // InviteGuest invites a user to Slack as a single-channel guest
+//
+// Deprecated: Use [Client.AdminUsersInvite] instead.
 func (api *Client) InviteGuest(teamName, channel, firstName, lastName, emailAddress string) error {
 	return api.InviteGuestContext(context.Background(), teamName, channel, firstName, lastName, emailAddress)
 }
 
 // InviteGuestContext invites a user to Slack as a single-channel guest with a custom context
+//
+// Deprecated: Use [Client.AdminUsersInvite] instead.
 func (api *Client) InviteGuestContext(ctx context.Context, teamName, channel, firstName, lastName, emailAddress string) error {
 	values := url.Values{
 		"email":            {emailAddress},
@@ -66,11 +78,15 @@ func (api *Client) InviteGuestContext(ctx context.Context, teamName, channel, fi
 }
 
 // InviteRestricted invites a user to Slack as a restricted account
+//
+// Deprecated: Use [Client.AdminUsersInvite] instead.
 func (api *Client) InviteRestricted(teamName, channel, firstName, lastName, emailAddress string) error {
 	return api.InviteRestrictedContext(context.Background(), teamName, channel, firstName, lastName, emailAddress)
 }
 
 // InviteRestrictedContext invites a user to Slack as a restricted account with a custom context
+//
+// Deprecated: Use [Client.AdminUsersInvite] instead.
 func (api *Client) InviteRestrictedContext(ctx context.Context, teamName, channel, firstName, lastName, emailAddress string) error {
 	values := url.Values{
 		"email":      {emailAddress},
@@ -93,11 +109,15 @@ func (api *Client) InviteRestrictedContext(ctx context.Context, teamName, channe
 }
 
 // InviteToTeam invites a user to a Slack team
+//
+// Deprecated: Use [Client.AdminUsersInvite] instead.
 func (api *Client) InviteToTeam(teamName, firstName, lastName, emailAddress string) error {
 	return api.InviteToTeamContext(context.Background(), teamName, firstName, lastName, emailAddress)
 }
 
 // InviteToTeamContext invites a user to a Slack team with a custom context
+//
+// Deprecated: Use [Client.AdminUsersInvite] instead.
 func (api *Client) InviteToTeamContext(ctx context.Context, teamName, firstName, lastName, emailAddress string) error {
 	values := url.Values{
 		"email":      {emailAddress},
@@ -117,11 +137,15 @@ func (api *Client) InviteToTeamContext(ctx context.Context, teamName, firstName,
 }
 
 // SetRegular enables the specified user
+//
+// Deprecated: Use [Client.AdminUsersSetRegular] instead.
 func (api *Client) SetRegular(teamName, user string) error {
 	return api.SetRegularContext(context.Background(), teamName, user)
 }
 
 // SetRegularContext enables the specified user with a custom context
+//
+// Deprecated: Use [Client.AdminUsersSetRegular] instead.
 func (api *Client) SetRegularContext(ctx context.Context, teamName, user string) error {
 	values := url.Values{
 		"user":       {user},
@@ -161,11 +185,15 @@ func (api *Client) SendSSOBindingEmailContext(ctx context.Context, teamName, use
 }
 
 // SetUltraRestricted converts a user into a single-channel guest
+//
+// Deprecated: Use [Client.AdminUsersAssign] instead.
 func (api *Client) SetUltraRestricted(teamName, uid, channel string) error {
 	return api.SetUltraRestrictedContext(context.Background(), teamName, uid, channel)
 }
 
 // SetUltraRestrictedContext converts a user into a single-channel guest with a custom context
+//
+// Deprecated: Use [Client.AdminUsersAssign] instead.
 func (api *Client) SetUltraRestrictedContext(ctx context.Context, teamName, uid, channel string) error {
 	values := url.Values{
 		"user":       {uid},
@@ -184,11 +212,15 @@ func (api *Client) SetUltraRestrictedContext(ctx context.Context, teamName, uid,
 }
 
 // SetRestricted converts a user into a restricted account
+//
+// Deprecated: Use [Client.AdminUsersAssign] instead.
 func (api *Client) SetRestricted(teamName, uid string, channelIds ...string) error {
 	return api.SetRestrictedContext(context.Background(), teamName, uid, channelIds...)
 }
 
 // SetRestrictedContext converts a user into a restricted account with a custom context
+//
+// Deprecated: Use [Client.AdminUsersAssign] instead.
 func (api *Client) SetRestrictedContext(ctx context.Context, teamName, uid string, channelIds ...string) error {
 	values := url.Values{
 		"user":       {uid},
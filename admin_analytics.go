@@ -0,0 +1,200 @@
+package slack
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// AnalyticsType is the kind of analytics of a file returned by admin.analytics.getFile.
+type AnalyticsType string
+
+const (
+	AnalyticsTypeMember        AnalyticsType = "member"
+	AnalyticsTypePublicChannel AnalyticsType = "public_channel"
+)
+
+// AnalyticsTeam is the workspace an analytics entry originates from.
+type AnalyticsTeam struct {
+	TeamID string `json:"team_id"`
+	Name   string `json:"name"`
+}
+
+// AnalyticsMemberEntry is the activity of a member on a day.
+type AnalyticsMemberEntry struct {
+	EnterpriseID               string        `json:"enterprise_id"`
+	TeamID                     string        `json:"team_id"`
+	OriginatingTeam            AnalyticsTeam `json:"originating_team"`
+	Date                       string        `json:"date"`
+	DateClaimed                JSONTime      `json:"date_claimed"`
+	UserID                     string        `json:"user_id"`
+	EmailAddress               string        `json:"email_address"`
+	EnterpriseEmployeeNumber   string        `json:"enterprise_employee_number"`
+	IsGuest                    bool          `json:"is_guest"`
+	IsBillableSeat             bool          `json:"is_billable_seat"`
+	IsActive                   bool          `json:"is_active"`
+	IsActiveIOS                bool          `json:"is_active_ios"`
+	IsActiveAndroid            bool          `json:"is_active_android"`
+	IsActiveDesktop            bool          `json:"is_active_desktop"`
+	IsActiveApps               bool          `json:"is_active_apps"`
+	IsActiveWorkflows          bool          `json:"is_active_workflows"`
+	IsActiveSlackConnect       bool          `json:"is_active_slack_connect"`
+	ReactionsAddedCount        int           `json:"reactions_added_count"`
+	MessagesPostedCount        int           `json:"messages_posted_count"`
+	ChannelMessagesPostedCount int           `json:"channel_messages_posted_count"`
+	FilesAddedCount            int           `json:"files_added_count"`
+	TotalCallsCount            int           `json:"total_calls_count"`
+	SlackCallsCount            int           `json:"slack_calls_count"`
+	SlackHuddlesCount          int           `json:"slack_huddles_count"`
+	SearchCount                int           `json:"search_count"`
+}
+
+// AnalyticsPublicChannelEntry is the activity of a public channel on a day.
+type AnalyticsPublicChannelEntry struct {
+	EnterpriseID                      string        `json:"enterprise_id"`
+	OriginatingTeam                   AnalyticsTeam `json:"originating_team"`
+	ChannelID                         string        `json:"channel_id"`
+	Date                              string        `json:"date"`
+	DateCreated                       JSONTime      `json:"date_created"`
+	DateLastActive                    JSONTime      `json:"date_last_active"`
+	TotalMembersCount                 int           `json:"total_members_count"`
+	FullMembersCount                  int           `json:"full_members_count"`
+	GuestMemberCount                  int           `json:"guest_member_count"`
+	MessagesPostedCount               int           `json:"messages_posted_count"`
+	MessagesPostedByMembersCount      int           `json:"messages_posted_by_members_count"`
+	MembersWhoViewedCount             int           `json:"members_who_viewed_count"`
+	MembersWhoPostedCount             int           `json:"members_who_posted_count"`
+	ReactionsAddedCount               int           `json:"reactions_added_count"`
+	Visibility                        string        `json:"visibility"`
+	ChannelType                       string        `json:"channel_type"`
+	IsSharedExternally                bool          `json:"is_shared_externally"`
+	SharedWith                        []string      `json:"shared_with"`
+	ExternallySharedWithOrganizations []string      `json:"externally_shared_with_organizations"`
+}
+
+// AnalyticsPublicChannelMetadata is the name, topic and description of a public channel, as
+// returned when only the metadata of the public channels is requested.
+type AnalyticsPublicChannelMetadata struct {
+	ChannelID   string `json:"channel_id"`
+	Name        string `json:"name"`
+	Topic       string `json:"topic"`
+	Description string `json:"description"`
+	Date        string `json:"date"`
+}
+
+// AdminAnalyticsGetFileParams contains arguments for AdminAnalyticsGetFile method calls.
+type AdminAnalyticsGetFileParams struct {
+	Type AnalyticsType
+	// Date is the day of the analytics, formatted as YYYY-MM-DD. It is ignored when
+	// MetadataOnly is set.
+	Date string
+	// MetadataOnly requests the metadata of the public channels instead of their activity.
+	MetadataOnly bool
+}
+
+// AdminAnalyticsGetFile downloads an analytics file, and calls f with each of its entries,
+// undecoded. The iteration stops at the first error of f, which is returned.
+// See: https://api.slack.com/methods/admin.analytics.getFile
+func (api *Client) AdminAnalyticsGetFile(ctx context.Context, params AdminAnalyticsGetFileParams, f func(json.RawMessage) error) error {
+	values := url.Values{
+		"type": {string(params.Type)},
+	}
+	if params.Date != "" {
+		values.Add("date", params.Date)
+	}
+	if params.MetadataOnly {
+		values.Add("metadata_only", strconv.FormatBool(params.MetadataOnly))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, api.endpoint+"admin.analytics.getFile", strings.NewReader(values.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := api.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// The errors are returned as JSON, e.g. when no file is available for the date.
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == "application/json" {
+		response := &SlackResponse{}
+		if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+			return err
+		}
+		return response.Err()
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return StatusCodeError{Code: resp.StatusCode, Status: resp.Status}
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	decoder := json.NewDecoder(gz)
+	for {
+		var entry json.RawMessage
+		if err := decoder.Decode(&entry); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if err := f(entry); err != nil {
+			return err
+		}
+	}
+}
+
+// AdminAnalyticsMembers calls f with the activity of every member on date, formatted as
+// YYYY-MM-DD.
+// See: https://api.slack.com/methods/admin.analytics.getFile
+func (api *Client) AdminAnalyticsMembers(ctx context.Context, date string, f func(AnalyticsMemberEntry) error) error {
+	params := AdminAnalyticsGetFileParams{Type: AnalyticsTypeMember, Date: date}
+	return api.AdminAnalyticsGetFile(ctx, params, func(raw json.RawMessage) error {
+		var entry AnalyticsMemberEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return err
+		}
+		return f(entry)
+	})
+}
+
+// AdminAnalyticsPublicChannels calls f with the activity of every public channel on date,
+// formatted as YYYY-MM-DD.
+// See: https://api.slack.com/methods/admin.analytics.getFile
+func (api *Client) AdminAnalyticsPublicChannels(ctx context.Context, date string, f func(AnalyticsPublicChannelEntry) error) error {
+	params := AdminAnalyticsGetFileParams{Type: AnalyticsTypePublicChannel, Date: date}
+	return api.AdminAnalyticsGetFile(ctx, params, func(raw json.RawMessage) error {
+		var entry AnalyticsPublicChannelEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return err
+		}
+		return f(entry)
+	})
+}
+
+// AdminAnalyticsPublicChannelsMetadata calls f with the metadata of every public channel.
+// See: https://api.slack.com/methods/admin.analytics.getFile
+func (api *Client) AdminAnalyticsPublicChannelsMetadata(ctx context.Context, f func(AnalyticsPublicChannelMetadata) error) error {
+	params := AdminAnalyticsGetFileParams{Type: AnalyticsTypePublicChannel, MetadataOnly: true}
+	return api.AdminAnalyticsGetFile(ctx, params, func(raw json.RawMessage) error {
+		var metadata AnalyticsPublicChannelMetadata
+		if err := json.Unmarshal(raw, &metadata); err != nil {
+			return err
+		}
+		return f(metadata)
+	})
+}
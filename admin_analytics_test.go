@@ -0,0 +1,66 @@
+package slack
+
+import (
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestAdminAnalyticsMembers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.analytics.getFile", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("type") != "member" || r.FormValue("date") != "2024-01-02" {
+			t.Errorf("unexpected form %v", r.Form)
+		}
+		w.Header().Set("Content-Type", "application/gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(`{"enterprise_id":"E1","team_id":"T1","date":"2024-01-02","user_id":"W1","is_active":true,"messages_posted_count":3}
+{"enterprise_id":"E1","team_id":"T1","date":"2024-01-02","user_id":"W2","is_billable_seat":true}
+`))
+		gz.Close()
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	var entries []AnalyticsMemberEntry
+	err := api.AdminAnalyticsMembers(context.Background(), "2024-01-02", func(entry AnalyticsMemberEntry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []AnalyticsMemberEntry{
+		{EnterpriseID: "E1", TeamID: "T1", Date: "2024-01-02", UserID: "W1", IsActive: true, MessagesPostedCount: 3},
+		{EnterpriseID: "E1", TeamID: "T1", Date: "2024-01-02", UserID: "W2", IsBillableSeat: true},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("expected %+v, got %+v", expected, entries)
+	}
+}
+
+func TestAdminAnalyticsGetFileError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.analytics.getFile", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(`{"ok":false,"error":"file_not_yet_available"}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	err := api.AdminAnalyticsPublicChannelsMetadata(context.Background(), func(AnalyticsPublicChannelMetadata) error {
+		t.Error("unexpected entry")
+		return nil
+	})
+	if err == nil || err.Error() != "file_not_yet_available" {
+		t.Errorf("expected file_not_yet_available, got %v", err)
+	}
+}
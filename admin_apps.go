@@ -0,0 +1,351 @@
+package slack
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// AdminApp describes an app requested, approved or restricted in a workspace or in the
+// organisation.
+type AdminApp struct {
+	ID                     string `json:"id"`
+	Name                   string `json:"name"`
+	Description            string `json:"description"`
+	HelpURL                string `json:"help_url"`
+	PrivacyPolicyURL       string `json:"privacy_policy_url"`
+	AppHomepageURL         string `json:"app_homepage_url"`
+	AppDirectoryURL        string `json:"app_directory_url"`
+	IsAppDirectoryApproved bool   `json:"is_app_directory_approved"`
+	IsInternal             bool   `json:"is_internal"`
+	AdditionalInfo         string `json:"additional_info"`
+}
+
+// AdminAppScope is a scope requested by an app.
+type AdminAppScope struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsSensitive bool   `json:"is_sensitive"`
+	// TokenType is either "bot" or "user".
+	TokenType string `json:"token_type"`
+}
+
+// AdminAppRequestResolution is the resolution of the previous request of an app.
+type AdminAppRequestResolution struct {
+	// Status is either "approved" or "restricted".
+	Status string          `json:"status"`
+	Scopes []AdminAppScope `json:"scopes"`
+}
+
+// AdminAppRequestUser is the user requesting an app.
+type AdminAppRequestUser struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// AdminAppRequestTeam is the workspace or the organisation an app is requested for.
+type AdminAppRequestTeam struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Domain string `json:"domain"`
+}
+
+// AdminAppRequest is a request to install an app, as returned by AdminAppsRequestsList
+// and sent with the app_requested event.
+type AdminAppRequest struct {
+	ID                 string                    `json:"id"`
+	App                AdminApp                  `json:"app"`
+	PreviousResolution AdminAppRequestResolution `json:"previous_resolution"`
+	User               AdminAppRequestUser       `json:"user"`
+	Team               AdminAppRequestTeam       `json:"team"`
+	Enterprise         *AdminAppRequestTeam      `json:"enterprise"`
+	Scopes             []AdminAppScope           `json:"scopes"`
+	Message            string                    `json:"message"`
+	DateCreated        JSONTime                  `json:"date_created"`
+}
+
+// SensitiveScopes returns the sensitive scopes requested by the app.
+func (r AdminAppRequest) SensitiveScopes() []AdminAppScope {
+	var scopes []AdminAppScope
+	for _, scope := range r.Scopes {
+		if scope.IsSensitive {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// AdminAppResolutionActor is the admin who approved or restricted an app.
+type AdminAppResolutionActor struct {
+	ActorID   string `json:"actor_id"`
+	ActorType string `json:"actor_type"`
+}
+
+// AdminAppResolution is an app approved or restricted in a workspace or in the organisation.
+type AdminAppResolution struct {
+	App            AdminApp                `json:"app"`
+	Scopes         []AdminAppScope         `json:"scopes"`
+	DateUpdated    JSONTime                `json:"date_updated"`
+	LastResolvedBy AdminAppResolutionActor `json:"last_resolved_by"`
+}
+
+// AdminAppsListParams contains arguments for AdminAppsRequestsList, AdminAppsApprovedList
+// and AdminAppsRestrictedList method calls.
+type AdminAppsListParams struct {
+	TeamID       string
+	EnterpriseID string
+	Cursor       string
+	Limit        int
+}
+
+func (params AdminAppsListParams) values(token string) url.Values {
+	values := url.Values{
+		"token": {token},
+	}
+
+	if params.TeamID != "" {
+		values.Add("team_id", params.TeamID)
+	}
+
+	if params.EnterpriseID != "" {
+		values.Add("enterprise_id", params.EnterpriseID)
+	}
+
+	if params.Cursor != "" {
+		values.Add("cursor", params.Cursor)
+	}
+
+	if params.Limit != 0 {
+		values.Add("limit", strconv.Itoa(params.Limit))
+	}
+
+	return values
+}
+
+// AdminAppsRequestsList lists the pending app installation requests of a workspace. It
+// returns the cursor of the next page, if any.
+// See: https://api.slack.com/methods/admin.apps.requests.list
+func (api *Client) AdminAppsRequestsList(ctx context.Context, params AdminAppsListParams) ([]AdminAppRequest, string, error) {
+	response := struct {
+		AppRequests []AdminAppRequest `json:"app_requests"`
+		SlackResponse
+	}{}
+
+	err := api.postMethod(ctx, "admin.apps.requests.list", params.values(api.token), &response)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.AppRequests, response.ResponseMetadata.Cursor, response.Err()
+}
+
+// AdminAppsApprovedList lists the apps approved in a workspace or in the organisation. It
+// returns the cursor of the next page, if any.
+// See: https://api.slack.com/methods/admin.apps.approved.list
+func (api *Client) AdminAppsApprovedList(ctx context.Context, params AdminAppsListParams) ([]AdminAppResolution, string, error) {
+	response := struct {
+		ApprovedApps []AdminAppResolution `json:"approved_apps"`
+		SlackResponse
+	}{}
+
+	err := api.postMethod(ctx, "admin.apps.approved.list", params.values(api.token), &response)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.ApprovedApps, response.ResponseMetadata.Cursor, response.Err()
+}
+
+// AdminAppsRestrictedList lists the apps restricted in a workspace or in the organisation.
+// It returns the cursor of the next page, if any.
+// See: https://api.slack.com/methods/admin.apps.restricted.list
+func (api *Client) AdminAppsRestrictedList(ctx context.Context, params AdminAppsListParams) ([]AdminAppResolution, string, error) {
+	response := struct {
+		RestrictedApps []AdminAppResolution `json:"restricted_apps"`
+		SlackResponse
+	}{}
+
+	err := api.postMethod(ctx, "admin.apps.restricted.list", params.values(api.token), &response)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.RestrictedApps, response.ResponseMetadata.Cursor, response.Err()
+}
+
+// AdminAppsResolutionParams contains arguments for AdminAppsApprove, AdminAppsRestrict
+// and AdminAppsClearResolution method calls.
+type AdminAppsResolutionParams struct {
+	// AppID or RequestID identifies the app. Only AppID is supported by
+	// AdminAppsClearResolution.
+	AppID     string
+	RequestID string
+	// TeamID or EnterpriseID is where the app is approved or restricted.
+	TeamID       string
+	EnterpriseID string
+}
+
+func (params AdminAppsResolutionParams) values(token string) url.Values {
+	values := url.Values{
+		"token": {token},
+	}
+
+	if params.AppID != "" {
+		values.Add("app_id", params.AppID)
+	}
+
+	if params.RequestID != "" {
+		values.Add("request_id", params.RequestID)
+	}
+
+	if params.TeamID != "" {
+		values.Add("team_id", params.TeamID)
+	}
+
+	if params.EnterpriseID != "" {
+		values.Add("enterprise_id", params.EnterpriseID)
+	}
+
+	return values
+}
+
+// AdminAppsApprove approves an app installation request, or an app.
+// See: https://api.slack.com/methods/admin.apps.approve
+func (api *Client) AdminAppsApprove(ctx context.Context, params AdminAppsResolutionParams) error {
+	response := &SlackResponse{}
+	err := api.postMethod(ctx, "admin.apps.approve", params.values(api.token), response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// AdminAppsRestrict restricts an app installation request, or an app.
+// See: https://api.slack.com/methods/admin.apps.restrict
+func (api *Client) AdminAppsRestrict(ctx context.Context, params AdminAppsResolutionParams) error {
+	response := &SlackResponse{}
+	err := api.postMethod(ctx, "admin.apps.restrict", params.values(api.token), response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// AdminAppsClearResolution clears the approval or the restriction of an app, which can
+// then be requested again.
+// See: https://api.slack.com/methods/admin.apps.clearResolution
+func (api *Client) AdminAppsClearResolution(ctx context.Context, params AdminAppsResolutionParams) error {
+	response := &SlackResponse{}
+	err := api.postMethod(ctx, "admin.apps.clearResolution", params.values(api.token), response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// AdminAppsUninstall uninstalls an app from the given workspaces, or from the whole
+// organisation when enterpriseID is set.
+// See: https://api.slack.com/methods/admin.apps.uninstall
+func (api *Client) AdminAppsUninstall(ctx context.Context, appID string, enterpriseID string, teamIDs ...string) error {
+	values := url.Values{
+		"token":  {api.token},
+		"app_id": {appID},
+	}
+
+	if enterpriseID != "" {
+		values.Add("enterprise_id", enterpriseID)
+	}
+
+	if len(teamIDs) > 0 {
+		values.Add("team_ids", strings.Join(teamIDs, ","))
+	}
+
+	response := &SlackResponse{}
+	err := api.postMethod(ctx, "admin.apps.uninstall", values, response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// AdminAppRequestAction is the action an AdminAppRequestPolicy decides on.
+type AdminAppRequestAction string
+
+const (
+	// AdminAppRequestSkip leaves the request pending, for an admin to resolve it.
+	AdminAppRequestSkip AdminAppRequestAction = ""
+	// AdminAppRequestApprove approves the request.
+	AdminAppRequestApprove AdminAppRequestAction = "approve"
+	// AdminAppRequestRestrict restricts the request.
+	AdminAppRequestRestrict AdminAppRequestAction = "restrict"
+)
+
+// AdminAppRequestPolicy decides what to do with a pending app installation request.
+type AdminAppRequestPolicy func(ctx context.Context, request AdminAppRequest) (AdminAppRequestAction, error)
+
+// AdminAppRequestResult is the outcome of the decision of an AdminAppRequestPolicy.
+type AdminAppRequestResult struct {
+	Request AdminAppRequest
+	Action  AdminAppRequestAction
+	// Err is the error of the policy or of the API call carrying out the decision.
+	Err error
+}
+
+// ProcessAdminAppRequest asks the policy what to do with an app installation request, and
+// does it. It returns false when the request is skipped.
+func (api *Client) ProcessAdminAppRequest(ctx context.Context, request AdminAppRequest, policy AdminAppRequestPolicy) (AdminAppRequestResult, bool) {
+	result := AdminAppRequestResult{Request: request}
+	result.Action, result.Err = policy(ctx, request)
+	if result.Err != nil {
+		return result, true
+	}
+
+	params := AdminAppsResolutionParams{
+		RequestID: request.ID,
+		TeamID:    request.Team.ID,
+	}
+
+	switch result.Action {
+	case AdminAppRequestSkip:
+		return result, false
+	case AdminAppRequestApprove:
+		result.Err = api.AdminAppsApprove(ctx, params)
+	case AdminAppRequestRestrict:
+		result.Err = api.AdminAppsRestrict(ctx, params)
+	default:
+		result.Err = ErrInvalidConfiguration
+	}
+
+	return result, true
+}
+
+// ProcessAdminAppRequests lists the pending app installation requests from params.Cursor,
+// and processes them with ProcessAdminAppRequest. It returns the results of the decisions
+// other than AdminAppRequestSkip, and the errors of the listing: the errors of the policy
+// and of the decisions are reported in the results.
+func (api *Client) ProcessAdminAppRequests(ctx context.Context, params AdminAppsListParams, policy AdminAppRequestPolicy) ([]AdminAppRequestResult, error) {
+	var results []AdminAppRequestResult
+	for {
+		requests, cursor, err := api.AdminAppsRequestsList(ctx, params)
+		if err != nil {
+			return results, err
+		}
+
+		for _, request := range requests {
+			if result, ok := api.ProcessAdminAppRequest(ctx, request, policy); ok {
+				results = append(results, result)
+			}
+		}
+
+		if cursor == "" {
+			return results, nil
+		}
+		params.Cursor = cursor
+	}
+}
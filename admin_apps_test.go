@@ -0,0 +1,95 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestProcessAdminAppRequests(t *testing.T) {
+	var calls []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.apps.requests.list", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"app_requests":[
+			{"id":"R1","app":{"id":"A1","name":"Polls","is_app_directory_approved":true},"team":{"id":"T1"},"scopes":[{"name":"chat:write","token_type":"bot"}]},
+			{"id":"R2","app":{"id":"A2","name":"Exporter"},"team":{"id":"T1"},"scopes":[{"name":"channels:history","is_sensitive":true,"token_type":"user"}]},
+			{"id":"R3","app":{"id":"A3","name":"Unknown"},"team":{"id":"T1"}}
+		]}`))
+	})
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.URL.Path+" "+r.FormValue("request_id")+" "+r.FormValue("team_id"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	}
+	mux.HandleFunc("/admin.apps.approve", handler)
+	mux.HandleFunc("/admin.apps.restrict", handler)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	errUnknown := errors.New("unknown app")
+	results, err := api.ProcessAdminAppRequests(context.Background(), AdminAppsListParams{TeamID: "T1"}, func(ctx context.Context, request AdminAppRequest) (AdminAppRequestAction, error) {
+		switch {
+		case request.App.ID == "A3":
+			return AdminAppRequestSkip, errUnknown
+		case len(request.SensitiveScopes()) > 0:
+			return AdminAppRequestRestrict, nil
+		case request.App.IsAppDirectoryApproved:
+			return AdminAppRequestApprove, nil
+		}
+		return AdminAppRequestSkip, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedCalls := []string{"/admin.apps.approve R1 T1", "/admin.apps.restrict R2 T1"}
+	if !reflect.DeepEqual(calls, expectedCalls) {
+		t.Errorf("expected calls %v, got %v", expectedCalls, calls)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if results[0].Action != AdminAppRequestApprove || results[1].Action != AdminAppRequestRestrict || results[2].Err != errUnknown {
+		t.Errorf("unexpected results %+v", results)
+	}
+}
+
+func TestAdminAppsApprovedList(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.apps.approved.list", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("enterprise_id") != "E1" || r.FormValue("limit") != "10" {
+			t.Errorf("unexpected form %v", r.Form)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"approved_apps":[{"app":{"id":"A1","name":"Polls"},"scopes":[{"name":"chat:write","token_type":"bot"}],"date_updated":1574296707,"last_resolved_by":{"actor_id":"W1","actor_type":"user"}}],"response_metadata":{"next_cursor":"next"}}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	apps, cursor, err := api.AdminAppsApprovedList(context.Background(), AdminAppsListParams{EnterpriseID: "E1", Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cursor != "next" {
+		t.Errorf("expected cursor next, got %q", cursor)
+	}
+
+	expected := []AdminAppResolution{{
+		App:            AdminApp{ID: "A1", Name: "Polls"},
+		Scopes:         []AdminAppScope{{Name: "chat:write", TokenType: "bot"}},
+		DateUpdated:    1574296707,
+		LastResolvedBy: AdminAppResolutionActor{ActorID: "W1", ActorType: "user"},
+	}}
+	if !reflect.DeepEqual(apps, expected) {
+		t.Errorf("expected %+v, got %+v", expected, apps)
+	}
+}
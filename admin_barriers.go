@@ -0,0 +1,184 @@
+package slack
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// InformationBarrierSubject is a kind of communication an information barrier restricts.
+type InformationBarrierSubject string
+
+const (
+	InformationBarrierSubjectIM   InformationBarrierSubject = "im"
+	InformationBarrierSubjectMPIM InformationBarrierSubject = "mpim"
+	InformationBarrierSubjectCall InformationBarrierSubject = "call"
+)
+
+// AllInformationBarrierSubjects are the subjects every barrier restricts: Slack does not
+// support restricting only some of them yet.
+var AllInformationBarrierSubjects = []InformationBarrierSubject{
+	InformationBarrierSubjectIM,
+	InformationBarrierSubjectMPIM,
+	InformationBarrierSubjectCall,
+}
+
+// InformationBarrier prevents the members of a user group from communicating with the members
+// of other user groups. Only the ID and the name of the user groups are set.
+type InformationBarrier struct {
+	ID                      string                      `json:"id"`
+	EnterpriseID            string                      `json:"enterprise_id"`
+	PrimaryUserGroup        UserGroup                   `json:"primary_usergroup"`
+	BarrieredFromUserGroups []UserGroup                 `json:"barriered_from_usergroups"`
+	RestrictedSubjects      []InformationBarrierSubject `json:"restricted_subjects"`
+	DateUpdate              JSONTime                    `json:"date_update"`
+}
+
+// BarrieredFromUserGroupIDs returns the IDs of the user groups the primary user group is
+// barriered from.
+func (b InformationBarrier) BarrieredFromUserGroupIDs() []string {
+	ids := make([]string, 0, len(b.BarrieredFromUserGroups))
+	for _, g := range b.BarrieredFromUserGroups {
+		ids = append(ids, g.ID)
+	}
+	return ids
+}
+
+// AdminBarriersParams contains arguments for AdminBarriersCreate and AdminBarriersUpdate
+// method calls.
+type AdminBarriersParams struct {
+	PrimaryUserGroupID        string
+	BarrieredFromUserGroupIDs []string
+	// RestrictedSubjects defaults to AllInformationBarrierSubjects.
+	RestrictedSubjects []InformationBarrierSubject
+}
+
+func (params AdminBarriersParams) values(token string) url.Values {
+	subjects := params.RestrictedSubjects
+	if len(subjects) == 0 {
+		subjects = AllInformationBarrierSubjects
+	}
+
+	names := make([]string, 0, len(subjects))
+	for _, s := range subjects {
+		names = append(names, string(s))
+	}
+
+	return url.Values{
+		"token":                        {token},
+		"primary_usergroup_id":         {params.PrimaryUserGroupID},
+		"barriered_from_usergroup_ids": {strings.Join(params.BarrieredFromUserGroupIDs, ",")},
+		"restricted_subjects":          {strings.Join(names, ",")},
+	}
+}
+
+type adminBarrierResponse struct {
+	Barrier InformationBarrier `json:"barrier"`
+	SlackResponse
+}
+
+// AdminBarriersCreate creates an information barrier, and returns it.
+// See: https://api.slack.com/methods/admin.barriers.create
+func (api *Client) AdminBarriersCreate(ctx context.Context, params AdminBarriersParams) (*InformationBarrier, error) {
+	response := &adminBarrierResponse{}
+	err := api.postMethod(ctx, "admin.barriers.create", params.values(api.token), response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Err() != nil {
+		return nil, response.Err()
+	}
+
+	return &response.Barrier, nil
+}
+
+// AdminBarriersUpdate updates an information barrier, and returns it.
+// See: https://api.slack.com/methods/admin.barriers.update
+func (api *Client) AdminBarriersUpdate(ctx context.Context, barrierID string, params AdminBarriersParams) (*InformationBarrier, error) {
+	values := params.values(api.token)
+	values.Add("barrier_id", barrierID)
+
+	response := &adminBarrierResponse{}
+	err := api.postMethod(ctx, "admin.barriers.update", values, response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Err() != nil {
+		return nil, response.Err()
+	}
+
+	return &response.Barrier, nil
+}
+
+// AdminBarriersDelete deletes an information barrier.
+// See: https://api.slack.com/methods/admin.barriers.delete
+func (api *Client) AdminBarriersDelete(ctx context.Context, barrierID string) error {
+	values := url.Values{
+		"token":      {api.token},
+		"barrier_id": {barrierID},
+	}
+
+	response := &SlackResponse{}
+	err := api.postMethod(ctx, "admin.barriers.delete", values, response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// AdminBarriersListParams contains arguments for AdminBarriersList method calls.
+type AdminBarriersListParams struct {
+	Cursor string
+	Limit  int
+}
+
+// AdminBarriersList lists the information barriers of the organisation. It returns the
+// cursor of the next page, if any.
+// See: https://api.slack.com/methods/admin.barriers.list
+func (api *Client) AdminBarriersList(ctx context.Context, params AdminBarriersListParams) ([]InformationBarrier, string, error) {
+	values := url.Values{
+		"token": {api.token},
+	}
+
+	if params.Cursor != "" {
+		values.Add("cursor", params.Cursor)
+	}
+
+	if params.Limit != 0 {
+		values.Add("limit", strconv.Itoa(params.Limit))
+	}
+
+	response := struct {
+		Barriers []InformationBarrier `json:"barriers"`
+		SlackResponse
+	}{}
+
+	err := api.postMethod(ctx, "admin.barriers.list", values, &response)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.Barriers, response.ResponseMetadata.Cursor, response.Err()
+}
+
+// AdminBarriersListAll lists the information barriers like AdminBarriersList does,
+// following the cursors from params.Cursor until the last page.
+func (api *Client) AdminBarriersListAll(ctx context.Context, params AdminBarriersListParams) ([]InformationBarrier, error) {
+	var barriers []InformationBarrier
+	for {
+		page, cursor, err := api.AdminBarriersList(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		barriers = append(barriers, page...)
+		if cursor == "" {
+			return barriers, nil
+		}
+		params.Cursor = cursor
+	}
+}
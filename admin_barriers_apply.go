@@ -0,0 +1,177 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// InformationBarrierConfig is the desired barrier of a primary user group.
+type InformationBarrierConfig struct {
+	BarrieredFromUserGroupIDs []string
+	// RestrictedSubjects defaults to AllInformationBarrierSubjects.
+	RestrictedSubjects []InformationBarrierSubject
+}
+
+// InformationBarrierUpdate is an update of an existing barrier planned by DiffInformationBarriers.
+type InformationBarrierUpdate struct {
+	Barrier InformationBarrier
+	Params  AdminBarriersParams
+}
+
+// InformationBarrierPlan is the list of the changes reconciling the existing information
+// barriers with a desired configuration.
+type InformationBarrierPlan struct {
+	Create []AdminBarriersParams
+	Update []InformationBarrierUpdate
+	// Delete holds the existing barriers whose primary user group is not configured, and the
+	// duplicate barriers of the configured ones.
+	Delete []InformationBarrier
+}
+
+// Empty reports whether the plan has no change.
+func (p *InformationBarrierPlan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Update) == 0 && len(p.Delete) == 0
+}
+
+// String returns the changes of the plan, one per line.
+func (p *InformationBarrierPlan) String() string {
+	var lines []string
+	for _, params := range p.Create {
+		lines = append(lines, fmt.Sprintf("+ %s: %s", params.PrimaryUserGroupID, strings.Join(params.BarrieredFromUserGroupIDs, ",")))
+	}
+	for _, u := range p.Update {
+		lines = append(lines, fmt.Sprintf("~ %s (%s): %s -> %s", u.Params.PrimaryUserGroupID, u.Barrier.ID,
+			strings.Join(u.Barrier.BarrieredFromUserGroupIDs(), ","), strings.Join(u.Params.BarrieredFromUserGroupIDs, ",")))
+	}
+	for _, b := range p.Delete {
+		lines = append(lines, fmt.Sprintf("- %s (%s)", b.PrimaryUserGroup.ID, b.ID))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DiffInformationBarriers plans the changes turning the existing barriers into the desired ones,
+// keyed by the ID of their primary user group. A barrier is updated when the user groups it
+// separates its primary user group from, or its restricted subjects, differ, regardless of their
+// order. When several barriers have the same primary user group, the first one is kept and the
+// others are deleted.
+func DiffInformationBarriers(desired map[string]InformationBarrierConfig, existing []InformationBarrier) *InformationBarrierPlan {
+	plan := &InformationBarrierPlan{}
+
+	byPrimary := make(map[string]InformationBarrier, len(existing))
+	for _, b := range existing {
+		id := b.PrimaryUserGroup.ID
+		if _, dup := byPrimary[id]; dup {
+			plan.Delete = append(plan.Delete, b)
+			continue
+		}
+		if _, ok := desired[id]; !ok {
+			plan.Delete = append(plan.Delete, b)
+			continue
+		}
+		byPrimary[id] = b
+	}
+
+	primaries := make([]string, 0, len(desired))
+	for id := range desired {
+		primaries = append(primaries, id)
+	}
+	sort.Strings(primaries)
+
+	for _, id := range primaries {
+		config := desired[id]
+		params := AdminBarriersParams{
+			PrimaryUserGroupID:        id,
+			BarrieredFromUserGroupIDs: config.BarrieredFromUserGroupIDs,
+			RestrictedSubjects:        config.RestrictedSubjects,
+		}
+		if len(params.RestrictedSubjects) == 0 {
+			params.RestrictedSubjects = AllInformationBarrierSubjects
+		}
+
+		b, ok := byPrimary[id]
+		if !ok {
+			plan.Create = append(plan.Create, params)
+			continue
+		}
+
+		subjects := make([]string, 0, len(params.RestrictedSubjects))
+		for _, s := range params.RestrictedSubjects {
+			subjects = append(subjects, string(s))
+		}
+		existingSubjects := make([]string, 0, len(b.RestrictedSubjects))
+		for _, s := range b.RestrictedSubjects {
+			existingSubjects = append(existingSubjects, string(s))
+		}
+
+		if !sameStringSet(params.BarrieredFromUserGroupIDs, b.BarrieredFromUserGroupIDs()) || !sameStringSet(subjects, existingSubjects) {
+			plan.Update = append(plan.Update, InformationBarrierUpdate{Barrier: b, Params: params})
+		}
+	}
+
+	return plan
+}
+
+func sameStringSet(a, b []string) bool {
+	set := make(map[string]bool, len(a))
+	for _, s := range a {
+		set[s] = true
+	}
+	for _, s := range b {
+		if !set[s] {
+			return false
+		}
+	}
+
+	other := make(map[string]bool, len(b))
+	for _, s := range b {
+		other[s] = true
+	}
+	return len(set) == len(other)
+}
+
+// ApplyInformationBarriers reconciles the information barriers of the organisation with the
+// desired ones, see DiffInformationBarriers, and returns the plan it carried out. The barriers
+// whose primary user group is not configured are only deleted when prune is true, while the
+// duplicate barriers of the configured ones are always deleted.
+//
+// Information barriers are often set up along with retention policies: the retention of the
+// conversations is managed with AdminConversationsGetCustomRetention,
+// AdminConversationsSetCustomRetention and AdminConversationsRemoveCustomRetention. The Web API
+// has no method managing the retention policy of a workspace.
+func (api *Client) ApplyInformationBarriers(ctx context.Context, desired map[string]InformationBarrierConfig, prune bool) (*InformationBarrierPlan, error) {
+	existing, err := api.AdminBarriersListAll(ctx, AdminBarriersListParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	plan := DiffInformationBarriers(desired, existing)
+	if !prune {
+		var duplicates []InformationBarrier
+		for _, b := range plan.Delete {
+			if _, ok := desired[b.PrimaryUserGroup.ID]; ok {
+				duplicates = append(duplicates, b)
+			}
+		}
+		plan.Delete = duplicates
+	}
+
+	for _, params := range plan.Create {
+		if _, err := api.AdminBarriersCreate(ctx, params); err != nil {
+			return plan, fmt.Errorf("failed to create the barrier of %s: %w", params.PrimaryUserGroupID, err)
+		}
+	}
+	for _, u := range plan.Update {
+		if _, err := api.AdminBarriersUpdate(ctx, u.Barrier.ID, u.Params); err != nil {
+			return plan, fmt.Errorf("failed to update the barrier of %s: %w", u.Params.PrimaryUserGroupID, err)
+		}
+	}
+	for _, b := range plan.Delete {
+		if err := api.AdminBarriersDelete(ctx, b.ID); err != nil {
+			return plan, fmt.Errorf("failed to delete the barrier of %s: %w", b.PrimaryUserGroup.ID, err)
+		}
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,173 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestAdminBarriersCreate(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.barriers.create", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("primary_usergroup_id") != "S1" {
+			t.Errorf("unexpected primary_usergroup_id %q", r.FormValue("primary_usergroup_id"))
+		}
+		if r.FormValue("barriered_from_usergroup_ids") != "S2,S3" {
+			t.Errorf("unexpected barriered_from_usergroup_ids %q", r.FormValue("barriered_from_usergroup_ids"))
+		}
+		if r.FormValue("restricted_subjects") != "im,mpim,call" {
+			t.Errorf("unexpected restricted_subjects %q", r.FormValue("restricted_subjects"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"barrier":{"id":"Ba1","enterprise_id":"E1","primary_usergroup":{"id":"S1","name":"Research"},"barriered_from_usergroups":[{"id":"S2","name":"Sales"},{"id":"S3","name":"Trading"}],"restricted_subjects":["im","mpim","call"],"date_update":1700000000}}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	barrier, err := api.AdminBarriersCreate(context.Background(), AdminBarriersParams{
+		PrimaryUserGroupID:        "S1",
+		BarrieredFromUserGroupIDs: []string{"S2", "S3"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &InformationBarrier{
+		ID:                      "Ba1",
+		EnterpriseID:            "E1",
+		PrimaryUserGroup:        UserGroup{ID: "S1", Name: "Research"},
+		BarrieredFromUserGroups: []UserGroup{{ID: "S2", Name: "Sales"}, {ID: "S3", Name: "Trading"}},
+		RestrictedSubjects:      AllInformationBarrierSubjects,
+		DateUpdate:              JSONTime(1700000000),
+	}
+	if !reflect.DeepEqual(barrier, expected) {
+		t.Errorf("expected %+v, got %+v", expected, barrier)
+	}
+}
+
+func TestAdminBarriersListAll(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.barriers.list", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.FormValue("cursor") {
+		case "":
+			w.Write([]byte(`{"ok":true,"barriers":[{"id":"Ba1","primary_usergroup":{"id":"S1"}}],"response_metadata":{"next_cursor":"page2"}}`))
+		case "page2":
+			w.Write([]byte(`{"ok":true,"barriers":[{"id":"Ba2","primary_usergroup":{"id":"S2"}}],"response_metadata":{"next_cursor":""}}`))
+		default:
+			t.Errorf("unexpected cursor %q", r.FormValue("cursor"))
+		}
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	barriers, err := api.AdminBarriersListAll(context.Background(), AdminBarriersListParams{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(barriers) != 2 || barriers[0].ID != "Ba1" || barriers[1].ID != "Ba2" {
+		t.Errorf("unexpected barriers %+v", barriers)
+	}
+}
+
+func TestDiffInformationBarriers(t *testing.T) {
+	existing := []InformationBarrier{
+		{
+			ID:                      "Ba1",
+			PrimaryUserGroup:        UserGroup{ID: "S1"},
+			BarrieredFromUserGroups: []UserGroup{{ID: "S3"}, {ID: "S2"}},
+			RestrictedSubjects:      AllInformationBarrierSubjects,
+		},
+		{
+			ID:                      "Ba2",
+			PrimaryUserGroup:        UserGroup{ID: "S2"},
+			BarrieredFromUserGroups: []UserGroup{{ID: "S1"}},
+			RestrictedSubjects:      AllInformationBarrierSubjects,
+		},
+		{
+			ID:                      "Ba3",
+			PrimaryUserGroup:        UserGroup{ID: "S9"},
+			BarrieredFromUserGroups: []UserGroup{{ID: "S1"}},
+			RestrictedSubjects:      AllInformationBarrierSubjects,
+		},
+	}
+	desired := map[string]InformationBarrierConfig{
+		"S1": {BarrieredFromUserGroupIDs: []string{"S2", "S3"}},
+		"S2": {BarrieredFromUserGroupIDs: []string{"S1", "S3"}},
+		"S3": {BarrieredFromUserGroupIDs: []string{"S1"}},
+	}
+
+	plan := DiffInformationBarriers(desired, existing)
+
+	expected := `+ S3: S1
+~ S2 (Ba2): S1 -> S1,S3
+- S9 (Ba3)`
+	if plan.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, plan)
+	}
+
+	desired = map[string]InformationBarrierConfig{
+		"S1": {BarrieredFromUserGroupIDs: []string{"S2", "S3"}},
+		"S2": {BarrieredFromUserGroupIDs: []string{"S1"}},
+		"S9": {BarrieredFromUserGroupIDs: []string{"S1"}, RestrictedSubjects: []InformationBarrierSubject{"call", "im", "mpim"}},
+	}
+	if plan := DiffInformationBarriers(desired, existing); !plan.Empty() {
+		t.Errorf("expected an empty plan, got:\n%s", plan)
+	}
+}
+
+func TestApplyInformationBarriers(t *testing.T) {
+	var calls []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.barriers.list", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"barriers":[{"id":"Ba1","primary_usergroup":{"id":"S1"},"barriered_from_usergroups":[{"id":"S2"}],"restricted_subjects":["im","mpim","call"]},{"id":"Ba3","primary_usergroup":{"id":"S1"},"barriered_from_usergroups":[{"id":"S2"}],"restricted_subjects":["im","mpim","call"]},{"id":"Ba9","primary_usergroup":{"id":"S9"},"barriered_from_usergroups":[{"id":"S1"}],"restricted_subjects":["im","mpim","call"]}]}`))
+	})
+	mux.HandleFunc("/admin.barriers.create", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "create "+r.FormValue("primary_usergroup_id"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"barrier":{"id":"Ba2"}}`))
+	})
+	mux.HandleFunc("/admin.barriers.update", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "update "+r.FormValue("barrier_id")+" "+r.FormValue("barriered_from_usergroup_ids"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"barrier":{"id":"Ba1"}}`))
+	})
+	mux.HandleFunc("/admin.barriers.delete", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "delete "+r.FormValue("barrier_id"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+	desired := map[string]InformationBarrierConfig{
+		"S1": {BarrieredFromUserGroupIDs: []string{"S2", "S3"}},
+		"S2": {BarrieredFromUserGroupIDs: []string{"S1"}},
+	}
+
+	if _, err := api.ApplyInformationBarriers(context.Background(), desired, false); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// The duplicate barrier of S1 is deleted even without pruning.
+	expected := []string{"create S2", "update Ba1 S2,S3", "delete Ba3"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected %v, got %v", expected, calls)
+	}
+
+	calls = nil
+	if _, err := api.ApplyInformationBarriers(context.Background(), desired, true); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected = []string{"create S2", "update Ba1 S2,S3", "delete Ba3", "delete Ba9"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected %v, got %v", expected, calls)
+	}
+}
@@ -2,6 +2,7 @@ package slack
 
 import (
 	"context"
+	"encoding/json"
 	"net/url"
 	"strconv"
 	"strings"
@@ -83,3 +84,462 @@ func (api *Client) AdminConversationsConvertToPublic(ctx context.Context, channe
 
 	return response.Err()
 }
+
+// AdminConversation is a conversation of an Enterprise Grid organisation, as returned by
+// AdminConversationsSearch.
+type AdminConversation struct {
+	ID                         string   `json:"id"`
+	Name                       string   `json:"name"`
+	Purpose                    string   `json:"purpose"`
+	MemberCount                int      `json:"member_count"`
+	Created                    JSONTime `json:"created"`
+	CreatorID                  string   `json:"creator_id"`
+	LastActivityTs             int64    `json:"last_activity_ts"`
+	IsPrivate                  bool     `json:"is_private"`
+	IsArchived                 bool     `json:"is_archived"`
+	IsGeneral                  bool     `json:"is_general"`
+	IsFrozen                   bool     `json:"is_frozen"`
+	IsExtShared                bool     `json:"is_ext_shared"`
+	IsPendingExtShared         bool     `json:"is_pending_ext_shared"`
+	IsGlobalShared             bool     `json:"is_global_shared"`
+	IsOrgShared                bool     `json:"is_org_shared"`
+	IsOrgDefault               bool     `json:"is_org_default"`
+	IsOrgMandatory             bool     `json:"is_org_mandatory"`
+	ContextTeamID              string   `json:"context_team_id"`
+	ConversationHostID         string   `json:"conversation_host_id"`
+	ConnectedTeamIDs           []string `json:"connected_team_ids"`
+	ConnectedLimitedTeamIDs    []string `json:"connected_limited_team_ids"`
+	PendingConnectedTeamIDs    []string `json:"pending_connected_team_ids"`
+	InternalTeamIDsCount       int      `json:"internal_team_ids_count"`
+	InternalTeamIDsSampleTeam  string   `json:"internal_team_ids_sample_team"`
+	ExternalUserCount          int      `json:"external_user_count"`
+	ChannelManagerCount        int      `json:"channel_manager_count"`
+	ChannelEmailAddressesCount int      `json:"channel_email_addresses_count"`
+}
+
+// AdminConversationsSearchChannelType filters the conversations searched by
+// AdminConversationsSearch.
+type AdminConversationsSearchChannelType string
+
+const (
+	AdminConversationsSearchPrivate                AdminConversationsSearchChannelType = "private"
+	AdminConversationsSearchPrivateExclude         AdminConversationsSearchChannelType = "private_exclude"
+	AdminConversationsSearchArchived               AdminConversationsSearchChannelType = "archived"
+	AdminConversationsSearchExcludeArchived        AdminConversationsSearchChannelType = "exclude_archived"
+	AdminConversationsSearchMultiWorkspace         AdminConversationsSearchChannelType = "multi_workspace"
+	AdminConversationsSearchOrgWide                AdminConversationsSearchChannelType = "org_wide"
+	AdminConversationsSearchExcludeOrgShared       AdminConversationsSearchChannelType = "exclude_org_shared"
+	AdminConversationsSearchExternalShared         AdminConversationsSearchChannelType = "external_shared"
+	AdminConversationsSearchExternalSharedExclude  AdminConversationsSearchChannelType = "external_shared_exclude"
+	AdminConversationsSearchExternalSharedPrivate  AdminConversationsSearchChannelType = "external_shared_private"
+	AdminConversationsSearchExternalSharedArchived AdminConversationsSearchChannelType = "external_shared_archived"
+)
+
+// AdminConversationsSearchSort is the order of the results of AdminConversationsSearch.
+type AdminConversationsSearchSort string
+
+const (
+	AdminConversationsSearchSortRelevant    AdminConversationsSearchSort = "relevant"
+	AdminConversationsSearchSortName        AdminConversationsSearchSort = "name"
+	AdminConversationsSearchSortMemberCount AdminConversationsSearchSort = "member_count"
+	AdminConversationsSearchSortCreated     AdminConversationsSearchSort = "created"
+)
+
+// AdminConversationsSearchParams contains arguments for AdminConversationsSearch
+// method calls.
+type AdminConversationsSearchParams struct {
+	Query            string
+	ChannelTypes     []AdminConversationsSearchChannelType
+	TeamIDs          []string
+	ConnectedTeamIDs []string
+	Sort             AdminConversationsSearchSort
+	// SortDir is either "asc" or "desc".
+	SortDir string
+	Cursor  string
+	Limit   int
+}
+
+// AdminConversationsSearch searches the public and private conversations of the
+// organisation. It returns the cursor of the next page, if any.
+// See: https://api.slack.com/methods/admin.conversations.search
+func (api *Client) AdminConversationsSearch(ctx context.Context, params AdminConversationsSearchParams) ([]AdminConversation, string, error) {
+	values := url.Values{
+		"token": {api.token},
+	}
+
+	if params.Query != "" {
+		values.Add("query", params.Query)
+	}
+
+	if len(params.ChannelTypes) > 0 {
+		types := make([]string, 0, len(params.ChannelTypes))
+		for _, t := range params.ChannelTypes {
+			types = append(types, string(t))
+		}
+		values.Add("search_channel_types", strings.Join(types, ","))
+	}
+
+	if len(params.TeamIDs) > 0 {
+		values.Add("team_ids", strings.Join(params.TeamIDs, ","))
+	}
+
+	if len(params.ConnectedTeamIDs) > 0 {
+		values.Add("connected_team_ids", strings.Join(params.ConnectedTeamIDs, ","))
+	}
+
+	if params.Sort != "" {
+		values.Add("sort", string(params.Sort))
+	}
+
+	if params.SortDir != "" {
+		values.Add("sort_dir", params.SortDir)
+	}
+
+	if params.Cursor != "" {
+		values.Add("cursor", params.Cursor)
+	}
+
+	if params.Limit != 0 {
+		values.Add("limit", strconv.Itoa(params.Limit))
+	}
+
+	response := struct {
+		Conversations []AdminConversation `json:"conversations"`
+		NextCursor    string              `json:"next_cursor"`
+		SlackResponse
+	}{}
+
+	err := api.postMethod(ctx, "admin.conversations.search", values, &response)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.Conversations, response.NextCursor, response.Err()
+}
+
+// AdminConversationsSearchAll searches the conversations like AdminConversationsSearch
+// does, following the cursors from params.Cursor until the last page.
+func (api *Client) AdminConversationsSearchAll(ctx context.Context, params AdminConversationsSearchParams) ([]AdminConversation, error) {
+	var conversations []AdminConversation
+	for {
+		page, cursor, err := api.AdminConversationsSearch(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		conversations = append(conversations, page...)
+		if cursor == "" {
+			return conversations, nil
+		}
+		params.Cursor = cursor
+	}
+}
+
+// adminConversationsRequest calls an admin.conversations.* method taking a channel, and
+// decodes its response into response.
+func (api *Client) adminConversationsRequest(ctx context.Context, method string, channelID string, values url.Values, response interface{ Err() error }) error {
+	if values == nil {
+		values = url.Values{}
+	}
+	values.Set("token", api.token)
+	values.Set("channel_id", channelID)
+
+	if response == nil {
+		response = &SlackResponse{}
+	}
+	err := api.postMethod(ctx, method, values, response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// AdminConversationsArchive archives a public or private channel.
+// See: https://api.slack.com/methods/admin.conversations.archive
+func (api *Client) AdminConversationsArchive(ctx context.Context, channelID string) error {
+	return api.adminConversationsRequest(ctx, "admin.conversations.archive", channelID, nil, nil)
+}
+
+// AdminConversationsUnarchive unarchives a public or private channel.
+// See: https://api.slack.com/methods/admin.conversations.unarchive
+func (api *Client) AdminConversationsUnarchive(ctx context.Context, channelID string) error {
+	return api.adminConversationsRequest(ctx, "admin.conversations.unarchive", channelID, nil, nil)
+}
+
+// AdminConversationsDelete deletes a public or private channel.
+// See: https://api.slack.com/methods/admin.conversations.delete
+func (api *Client) AdminConversationsDelete(ctx context.Context, channelID string) error {
+	return api.adminConversationsRequest(ctx, "admin.conversations.delete", channelID, nil, nil)
+}
+
+// AdminConversationsRename renames a public or private channel.
+// See: https://api.slack.com/methods/admin.conversations.rename
+func (api *Client) AdminConversationsRename(ctx context.Context, channelID string, name string) error {
+	values := url.Values{
+		"name": {name},
+	}
+
+	return api.adminConversationsRequest(ctx, "admin.conversations.rename", channelID, values, nil)
+}
+
+// AdminConversationsInvite invites users to a public or private channel.
+// See: https://api.slack.com/methods/admin.conversations.invite
+func (api *Client) AdminConversationsInvite(ctx context.Context, channelID string, userIDs ...string) error {
+	values := url.Values{
+		"user_ids": {strings.Join(userIDs, ",")},
+	}
+
+	return api.adminConversationsRequest(ctx, "admin.conversations.invite", channelID, values, nil)
+}
+
+// AdminConversationsDisconnectShared disconnects a shared channel from the given teams,
+// or from all the organisations when no team is given.
+// See: https://api.slack.com/methods/admin.conversations.disconnectShared
+func (api *Client) AdminConversationsDisconnectShared(ctx context.Context, channelID string, leavingTeamIDs ...string) error {
+	values := url.Values{}
+	if len(leavingTeamIDs) > 0 {
+		values.Add("leaving_team_ids", strings.Join(leavingTeamIDs, ","))
+	}
+
+	return api.adminConversationsRequest(ctx, "admin.conversations.disconnectShared", channelID, values, nil)
+}
+
+// AdminConversationsCreateParams contains arguments for AdminConversationsCreate
+// method calls.
+type AdminConversationsCreateParams struct {
+	Name        string
+	Description string
+	IsPrivate   bool
+	// OrgWide creates a channel available to all the workspaces of the organisation.
+	// TeamID is required otherwise.
+	OrgWide bool
+	TeamID  string
+}
+
+// AdminConversationsCreate creates a public or private channel, and returns its ID.
+// See: https://api.slack.com/methods/admin.conversations.create
+func (api *Client) AdminConversationsCreate(ctx context.Context, params AdminConversationsCreateParams) (string, error) {
+	values := url.Values{
+		"token":      {api.token},
+		"name":       {params.Name},
+		"is_private": {strconv.FormatBool(params.IsPrivate)},
+	}
+
+	if params.Description != "" {
+		values.Add("description", params.Description)
+	}
+
+	if params.OrgWide {
+		values.Add("org_wide", "true")
+	}
+
+	if params.TeamID != "" {
+		values.Add("team_id", params.TeamID)
+	}
+
+	response := struct {
+		ChannelID string `json:"channel_id"`
+		SlackResponse
+	}{}
+
+	err := api.postMethod(ctx, "admin.conversations.create", values, &response)
+	if err != nil {
+		return "", err
+	}
+
+	return response.ChannelID, response.Err()
+}
+
+// AdminConversationPref restricts an action in a conversation to the users of the given
+// types, e.g. "admin" or "ra", and to the given users.
+type AdminConversationPref struct {
+	Types []string `json:"type"`
+	Users []string `json:"user"`
+}
+
+func (p AdminConversationPref) String() string {
+	parts := make([]string, 0, len(p.Types)+len(p.Users))
+	for _, t := range p.Types {
+		parts = append(parts, "type:"+t)
+	}
+	for _, u := range p.Users {
+		parts = append(parts, "user:"+u)
+	}
+	return strings.Join(parts, ",")
+}
+
+// AdminConversationPrefs are the preferences of a conversation. The preferences left nil
+// are not changed by AdminConversationsSetConversationPrefs.
+type AdminConversationPrefs struct {
+	WhoCanPost *AdminConversationPref `json:"who_can_post,omitempty"`
+	CanThread  *AdminConversationPref `json:"can_thread,omitempty"`
+}
+
+// AdminConversationsGetConversationPrefs returns the posting permissions of a public or
+// private channel.
+// See: https://api.slack.com/methods/admin.conversations.getConversationPrefs
+func (api *Client) AdminConversationsGetConversationPrefs(ctx context.Context, channelID string) (*AdminConversationPrefs, error) {
+	response := struct {
+		Prefs AdminConversationPrefs `json:"prefs"`
+		SlackResponse
+	}{}
+
+	err := api.adminConversationsRequest(ctx, "admin.conversations.getConversationPrefs", channelID, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Prefs, nil
+}
+
+// AdminConversationsSetConversationPrefs sets the posting permissions of a public or
+// private channel.
+// See: https://api.slack.com/methods/admin.conversations.setConversationPrefs
+func (api *Client) AdminConversationsSetConversationPrefs(ctx context.Context, channelID string, prefs AdminConversationPrefs) error {
+	// The preferences are sent as a JSON object of strings, e.g. {"who_can_post":"type:admin,user:U1"}.
+	encoded := map[string]string{}
+	if prefs.WhoCanPost != nil {
+		encoded["who_can_post"] = prefs.WhoCanPost.String()
+	}
+	if prefs.CanThread != nil {
+		encoded["can_thread"] = prefs.CanThread.String()
+	}
+
+	b, err := json.Marshal(encoded)
+	if err != nil {
+		return err
+	}
+
+	values := url.Values{
+		"prefs": {string(b)},
+	}
+
+	return api.adminConversationsRequest(ctx, "admin.conversations.setConversationPrefs", channelID, values, nil)
+}
+
+// AdminConversationsGetTeamsParams contains arguments for AdminConversationsGetTeams
+// method calls.
+type AdminConversationsGetTeamsParams struct {
+	ChannelID string
+	Cursor    string
+	Limit     int
+}
+
+// AdminConversationsGetTeams returns the workspaces a channel is connected to within the
+// organisation. It returns the cursor of the next page, if any.
+// See: https://api.slack.com/methods/admin.conversations.getTeams
+func (api *Client) AdminConversationsGetTeams(ctx context.Context, params AdminConversationsGetTeamsParams) ([]string, string, error) {
+	values := url.Values{}
+
+	if params.Cursor != "" {
+		values.Add("cursor", params.Cursor)
+	}
+
+	if params.Limit != 0 {
+		values.Add("limit", strconv.Itoa(params.Limit))
+	}
+
+	response := struct {
+		TeamIDs []string `json:"team_ids"`
+		SlackResponse
+	}{}
+
+	err := api.adminConversationsRequest(ctx, "admin.conversations.getTeams", params.ChannelID, values, &response)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.TeamIDs, response.ResponseMetadata.Cursor, nil
+}
+
+// AdminConversationRetention is the retention policy of a conversation. The retention policy
+// of a workspace can only be changed in its settings: the Web API has no method managing it.
+type AdminConversationRetention struct {
+	// IsPolicyEnabled is false when the conversation follows the retention policy of
+	// the workspace or of the organisation.
+	IsPolicyEnabled bool `json:"is_policy_enabled"`
+	DurationDays    int  `json:"duration_days"`
+}
+
+// AdminConversationsGetCustomRetention returns the custom retention policy of a conversation.
+// See: https://api.slack.com/methods/admin.conversations.getCustomRetention
+func (api *Client) AdminConversationsGetCustomRetention(ctx context.Context, channelID string) (*AdminConversationRetention, error) {
+	response := struct {
+		AdminConversationRetention
+		SlackResponse
+	}{}
+
+	err := api.adminConversationsRequest(ctx, "admin.conversations.getCustomRetention", channelID, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.AdminConversationRetention, nil
+}
+
+// AdminConversationsSetCustomRetention sets a custom retention policy for a conversation.
+// See: https://api.slack.com/methods/admin.conversations.setCustomRetention
+func (api *Client) AdminConversationsSetCustomRetention(ctx context.Context, channelID string, durationDays int) error {
+	values := url.Values{
+		"duration_days": {strconv.Itoa(durationDays)},
+	}
+
+	return api.adminConversationsRequest(ctx, "admin.conversations.setCustomRetention", channelID, values, nil)
+}
+
+// AdminConversationsRemoveCustomRetention removes the custom retention policy of a
+// conversation, which then follows the policy of the workspace or of the organisation.
+// See: https://api.slack.com/methods/admin.conversations.removeCustomRetention
+func (api *Client) AdminConversationsRemoveCustomRetention(ctx context.Context, channelID string) error {
+	return api.adminConversationsRequest(ctx, "admin.conversations.removeCustomRetention", channelID, nil, nil)
+}
+
+// AdminConversationsRestrictAccessAddGroup restricts access to a private channel to the
+// members of an IDP group. teamID is required for the channels of a workspace.
+// See: https://api.slack.com/methods/admin.conversations.restrictAccess.addGroup
+func (api *Client) AdminConversationsRestrictAccessAddGroup(ctx context.Context, channelID string, groupID string, teamID string) error {
+	values := url.Values{
+		"group_id": {groupID},
+	}
+
+	if teamID != "" {
+		values.Add("team_id", teamID)
+	}
+
+	return api.adminConversationsRequest(ctx, "admin.conversations.restrictAccess.addGroup", channelID, values, nil)
+}
+
+// AdminConversationsRestrictAccessRemoveGroup removes an IDP group from the groups
+// restricting access to a private channel.
+// See: https://api.slack.com/methods/admin.conversations.restrictAccess.removeGroup
+func (api *Client) AdminConversationsRestrictAccessRemoveGroup(ctx context.Context, channelID string, groupID string, teamID string) error {
+	values := url.Values{
+		"group_id": {groupID},
+		"team_id":  {teamID},
+	}
+
+	return api.adminConversationsRequest(ctx, "admin.conversations.restrictAccess.removeGroup", channelID, values, nil)
+}
+
+// AdminConversationsRestrictAccessListGroups returns the IDP groups restricting access
+// to a private channel.
+// See: https://api.slack.com/methods/admin.conversations.restrictAccess.listGroups
+func (api *Client) AdminConversationsRestrictAccessListGroups(ctx context.Context, channelID string, teamID string) ([]string, error) {
+	values := url.Values{}
+	if teamID != "" {
+		values.Add("team_id", teamID)
+	}
+
+	response := struct {
+		GroupIDs []string `json:"group_ids"`
+		SlackResponse
+	}{}
+
+	err := api.adminConversationsRequest(ctx, "admin.conversations.restrictAccess.listGroups", channelID, values, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.GroupIDs, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 )
 
@@ -80,3 +82,118 @@ func mockAdminChannelIDHandler(t *testing.T) func(rw http.ResponseWriter, r *htt
 		rw.Write(response)
 	}
 }
+
+func TestAdminConversationsSearchAll(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.conversations.search", func(rw http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unexpected error: %s", err)
+			return
+		}
+		if r.Form.Get("search_channel_types") != "private,exclude_archived" || r.Form.Get("sort") != "member_count" {
+			t.Errorf("unexpected form %v", r.Form)
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		switch r.Form.Get("cursor") {
+		case "":
+			rw.Write([]byte(`{"ok":true,"conversations":[{"id":"C1","name":"general","member_count":3,"is_private":true,"connected_team_ids":["T1"]}],"next_cursor":"page2"}`))
+		case "page2":
+			rw.Write([]byte(`{"ok":true,"conversations":[{"id":"C2","name":"random","is_ext_shared":true}],"next_cursor":""}`))
+		default:
+			t.Errorf("unexpected cursor %q", r.Form.Get("cursor"))
+		}
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	conversations, err := api.AdminConversationsSearchAll(context.Background(), AdminConversationsSearchParams{
+		ChannelTypes: []AdminConversationsSearchChannelType{AdminConversationsSearchPrivate, AdminConversationsSearchExcludeArchived},
+		Sort:         AdminConversationsSearchSortMemberCount,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []AdminConversation{
+		{ID: "C1", Name: "general", MemberCount: 3, IsPrivate: true, ConnectedTeamIDs: []string{"T1"}},
+		{ID: "C2", Name: "random", IsExtShared: true},
+	}
+	if !reflect.DeepEqual(conversations, expected) {
+		t.Errorf("expected %+v, got %+v", expected, conversations)
+	}
+}
+
+func TestAdminConversationsConversationPrefs(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.conversations.getConversationPrefs", func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"ok":true,"prefs":{"who_can_post":{"type":["admin"],"user":["U1"]},"can_thread":{"type":["ra"],"user":[]}}}`))
+	})
+	mux.HandleFunc("/admin.conversations.setConversationPrefs", func(rw http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unexpected error: %s", err)
+			return
+		}
+		if r.Form.Get("channel_id") != "C1" {
+			t.Errorf("unexpected channel_id %q", r.Form.Get("channel_id"))
+		}
+		expected := `{"who_can_post":"type:admin,user:U1"}`
+		if r.Form.Get("prefs") != expected {
+			t.Errorf("expected prefs %s, got %s", expected, r.Form.Get("prefs"))
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"ok":true}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	prefs, err := api.AdminConversationsGetConversationPrefs(context.Background(), "C1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &AdminConversationPrefs{
+		WhoCanPost: &AdminConversationPref{Types: []string{"admin"}, Users: []string{"U1"}},
+		CanThread:  &AdminConversationPref{Types: []string{"ra"}, Users: []string{}},
+	}
+	if !reflect.DeepEqual(prefs, expected) {
+		t.Errorf("expected %+v, got %+v", expected, prefs)
+	}
+
+	err = api.AdminConversationsSetConversationPrefs(context.Background(), "C1", AdminConversationPrefs{WhoCanPost: prefs.WhoCanPost})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestAdminConversationsGetCustomRetention(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.conversations.getCustomRetention", func(rw http.ResponseWriter, r *http.Request) {
+		if r.FormValue("channel_id") != "C1" {
+			t.Errorf("unexpected channel_id %q", r.FormValue("channel_id"))
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"ok":true,"is_policy_enabled":true,"duration_days":30}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	retention, err := api.AdminConversationsGetCustomRetention(context.Background(), "C1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &AdminConversationRetention{IsPolicyEnabled: true, DurationDays: 30}
+	if !reflect.DeepEqual(retention, expected) {
+		t.Errorf("expected %+v, got %+v", expected, retention)
+	}
+}
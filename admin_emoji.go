@@ -0,0 +1,160 @@
+package slack
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// AdminEmoji is a custom emoji of an Enterprise Grid organisation.
+type AdminEmoji struct {
+	URL         string   `json:"url"`
+	DateCreated JSONTime `json:"date_created"`
+	UploadedBy  string   `json:"uploaded_by"`
+}
+
+func (api *Client) adminEmojiRequest(ctx context.Context, path string, values url.Values) error {
+	response := &SlackResponse{}
+	err := api.postMethod(ctx, path, values, response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// AdminEmojiAdd adds a custom emoji to the organisation.
+// For more information see the AdminEmojiAddContext documentation.
+func (api *Client) AdminEmojiAdd(name string, imageURL string) error {
+	return api.AdminEmojiAddContext(context.Background(), name, imageURL)
+}
+
+// AdminEmojiAddContext adds a custom emoji to the organisation from an image URL with a custom context.
+// Slack API docs: https://api.slack.com/methods/admin.emoji.add
+func (api *Client) AdminEmojiAddContext(ctx context.Context, name string, imageURL string) error {
+	values := url.Values{
+		"token": {api.token},
+		"name":  {name},
+		"url":   {imageURL},
+	}
+
+	return api.adminEmojiRequest(ctx, "admin.emoji.add", values)
+}
+
+// AdminEmojiAddAlias adds an alias of a custom emoji.
+// For more information see the AdminEmojiAddAliasContext documentation.
+func (api *Client) AdminEmojiAddAlias(name string, aliasFor string) error {
+	return api.AdminEmojiAddAliasContext(context.Background(), name, aliasFor)
+}
+
+// AdminEmojiAddAliasContext adds the alias name of the custom emoji aliasFor with a custom context.
+// Slack API docs: https://api.slack.com/methods/admin.emoji.addAlias
+func (api *Client) AdminEmojiAddAliasContext(ctx context.Context, name string, aliasFor string) error {
+	values := url.Values{
+		"token":     {api.token},
+		"name":      {name},
+		"alias_for": {aliasFor},
+	}
+
+	return api.adminEmojiRequest(ctx, "admin.emoji.addAlias", values)
+}
+
+// AdminEmojiListParams contains arguments for AdminEmojiList method calls.
+type AdminEmojiListParams struct {
+	Cursor string
+	Limit  int
+}
+
+// AdminEmojiList lists a page of the custom emojis of the organisation.
+// For more information see the AdminEmojiListContext documentation.
+func (api *Client) AdminEmojiList(params AdminEmojiListParams) (map[string]AdminEmoji, string, error) {
+	return api.AdminEmojiListContext(context.Background(), params)
+}
+
+// AdminEmojiListContext lists a page of the custom emojis of the organisation, keyed by name,
+// with a custom context. It returns the cursor of the next page, if any.
+// Slack API docs: https://api.slack.com/methods/admin.emoji.list
+func (api *Client) AdminEmojiListContext(ctx context.Context, params AdminEmojiListParams) (map[string]AdminEmoji, string, error) {
+	values := url.Values{
+		"token": {api.token},
+	}
+	if params.Cursor != "" {
+		values.Add("cursor", params.Cursor)
+	}
+	if params.Limit != 0 {
+		values.Add("limit", strconv.Itoa(params.Limit))
+	}
+
+	response := struct {
+		Emoji map[string]AdminEmoji `json:"emoji"`
+		SlackResponse
+	}{}
+
+	err := api.postMethod(ctx, "admin.emoji.list", values, &response)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.Emoji, response.ResponseMetadata.Cursor, response.Err()
+}
+
+// AdminEmojiListAll lists all the custom emojis of the organisation.
+// For more information see the AdminEmojiListAllContext documentation.
+func (api *Client) AdminEmojiListAll(params AdminEmojiListParams) (map[string]AdminEmoji, error) {
+	return api.AdminEmojiListAllContext(context.Background(), params)
+}
+
+// AdminEmojiListAllContext lists the custom emojis of the organisation from params.Cursor until
+// the last page with a custom context.
+func (api *Client) AdminEmojiListAllContext(ctx context.Context, params AdminEmojiListParams) (map[string]AdminEmoji, error) {
+	emoji := map[string]AdminEmoji{}
+	for {
+		page, cursor, err := api.AdminEmojiListContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for name, e := range page {
+			emoji[name] = e
+		}
+		if cursor == "" {
+			return emoji, nil
+		}
+		params.Cursor = cursor
+	}
+}
+
+// AdminEmojiRemove removes a custom emoji from the organisation.
+// For more information see the AdminEmojiRemoveContext documentation.
+func (api *Client) AdminEmojiRemove(name string) error {
+	return api.AdminEmojiRemoveContext(context.Background(), name)
+}
+
+// AdminEmojiRemoveContext removes a custom emoji from the organisation with a custom context.
+// Slack API docs: https://api.slack.com/methods/admin.emoji.remove
+func (api *Client) AdminEmojiRemoveContext(ctx context.Context, name string) error {
+	values := url.Values{
+		"token": {api.token},
+		"name":  {name},
+	}
+
+	return api.adminEmojiRequest(ctx, "admin.emoji.remove", values)
+}
+
+// AdminEmojiRename renames a custom emoji.
+// For more information see the AdminEmojiRenameContext documentation.
+func (api *Client) AdminEmojiRename(name string, newName string) error {
+	return api.AdminEmojiRenameContext(context.Background(), name, newName)
+}
+
+// AdminEmojiRenameContext renames a custom emoji with a custom context.
+// Slack API docs: https://api.slack.com/methods/admin.emoji.rename
+func (api *Client) AdminEmojiRenameContext(ctx context.Context, name string, newName string) error {
+	values := url.Values{
+		"token":    {api.token},
+		"name":     {name},
+		"new_name": {newName},
+	}
+
+	return api.adminEmojiRequest(ctx, "admin.emoji.rename", values)
+}
@@ -0,0 +1,60 @@
+package slack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestAdminEmojiListAll(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.emoji.list", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.FormValue("cursor") {
+		case "":
+			w.Write([]byte(`{"ok":true,"emoji":{"party":{"url":"https://emoji.slack-edge.com/party.gif","date_created":1574830800,"uploaded_by":"W1"}},"response_metadata":{"next_cursor":"page2"}}`))
+		case "page2":
+			w.Write([]byte(`{"ok":true,"emoji":{"shipit":{"url":"https://emoji.slack-edge.com/shipit.png","uploaded_by":"W2"}},"response_metadata":{"next_cursor":""}}`))
+		default:
+			t.Errorf("unexpected cursor %q", r.FormValue("cursor"))
+		}
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	emoji, err := api.AdminEmojiListAll(AdminEmojiListParams{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]AdminEmoji{
+		"party":  {URL: "https://emoji.slack-edge.com/party.gif", DateCreated: 1574830800, UploadedBy: "W1"},
+		"shipit": {URL: "https://emoji.slack-edge.com/shipit.png", UploadedBy: "W2"},
+	}
+	if !reflect.DeepEqual(emoji, expected) {
+		t.Errorf("expected %+v, got %+v", expected, emoji)
+	}
+}
+
+func TestAdminEmojiRename(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.emoji.rename", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("name") != "party" || r.FormValue("new_name") != "partyparrot" {
+			t.Errorf("unexpected form %v", r.Form)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":false,"error":"emoji_not_found"}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	err := api.AdminEmojiRename("party", "partyparrot")
+	if err == nil || err.Error() != "emoji_not_found" {
+		t.Errorf("expected emoji_not_found, got %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+package slack
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// AdminInviteRequest is a request to invite a user to a workspace, made by a member of the
+// workspace who cannot invite users.
+type AdminInviteRequest struct {
+	ID            string   `json:"id"`
+	Email         string   `json:"email"`
+	RealName      string   `json:"real_name"`
+	RequesterIDs  []string `json:"requester_ids"`
+	ChannelIDs    []string `json:"channel_ids"`
+	InviteType    string   `json:"invite_type"`
+	RequestReason string   `json:"request_reason"`
+	DateCreated   JSONTime `json:"date_created"`
+	DateExpire    JSONTime `json:"date_expire"`
+	Team          TeamInfo `json:"team"`
+}
+
+// AdminInviteRequestActor is the admin who approved or denied an invite request.
+type AdminInviteRequestActor struct {
+	ActorType string `json:"actor_type"`
+	ActorID   string `json:"actor_id"`
+}
+
+// AdminInviteRequestResolution is an approved or denied invite request. ApprovedBy is only set
+// for the approved requests, and DeniedBy for the denied ones.
+type AdminInviteRequestResolution struct {
+	InviteRequest AdminInviteRequest       `json:"invite_request"`
+	ApprovedBy    *AdminInviteRequestActor `json:"approved_by,omitempty"`
+	DeniedBy      *AdminInviteRequestActor `json:"denied_by,omitempty"`
+}
+
+// AdminInviteRequestsListParams contains arguments for AdminInviteRequestsList,
+// AdminInviteRequestsApprovedList and AdminInviteRequestsDeniedList method calls.
+type AdminInviteRequestsListParams struct {
+	TeamID string
+	Cursor string
+	Limit  int
+}
+
+func (params AdminInviteRequestsListParams) values(token string) url.Values {
+	values := url.Values{
+		"token": {token},
+	}
+	if params.TeamID != "" {
+		values.Add("team_id", params.TeamID)
+	}
+	if params.Cursor != "" {
+		values.Add("cursor", params.Cursor)
+	}
+	if params.Limit != 0 {
+		values.Add("limit", strconv.Itoa(params.Limit))
+	}
+	return values
+}
+
+func (api *Client) adminInviteRequestsResolveRequest(ctx context.Context, path string, teamID string, inviteRequestID string) error {
+	values := url.Values{
+		"token":             {api.token},
+		"invite_request_id": {inviteRequestID},
+	}
+	if teamID != "" {
+		values.Add("team_id", teamID)
+	}
+
+	response := &SlackResponse{}
+	err := api.postMethod(ctx, path, values, response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// AdminInviteRequestsApprove approves an invite request.
+// For more information see the AdminInviteRequestsApproveContext documentation.
+func (api *Client) AdminInviteRequestsApprove(teamID string, inviteRequestID string) error {
+	return api.AdminInviteRequestsApproveContext(context.Background(), teamID, inviteRequestID)
+}
+
+// AdminInviteRequestsApproveContext approves an invite request with a custom context.
+// Slack API docs: https://api.slack.com/methods/admin.inviteRequests.approve
+func (api *Client) AdminInviteRequestsApproveContext(ctx context.Context, teamID string, inviteRequestID string) error {
+	return api.adminInviteRequestsResolveRequest(ctx, "admin.inviteRequests.approve", teamID, inviteRequestID)
+}
+
+// AdminInviteRequestsDeny denies an invite request.
+// For more information see the AdminInviteRequestsDenyContext documentation.
+func (api *Client) AdminInviteRequestsDeny(teamID string, inviteRequestID string) error {
+	return api.AdminInviteRequestsDenyContext(context.Background(), teamID, inviteRequestID)
+}
+
+// AdminInviteRequestsDenyContext denies an invite request with a custom context.
+// Slack API docs: https://api.slack.com/methods/admin.inviteRequests.deny
+func (api *Client) AdminInviteRequestsDenyContext(ctx context.Context, teamID string, inviteRequestID string) error {
+	return api.adminInviteRequestsResolveRequest(ctx, "admin.inviteRequests.deny", teamID, inviteRequestID)
+}
+
+// AdminInviteRequestsList lists a page of the pending invite requests of a workspace.
+// For more information see the AdminInviteRequestsListContext documentation.
+func (api *Client) AdminInviteRequestsList(params AdminInviteRequestsListParams) ([]AdminInviteRequest, string, error) {
+	return api.AdminInviteRequestsListContext(context.Background(), params)
+}
+
+// AdminInviteRequestsListContext lists a page of the pending invite requests of a workspace
+// with a custom context. It returns the cursor of the next page, if any.
+// Slack API docs: https://api.slack.com/methods/admin.inviteRequests.list
+func (api *Client) AdminInviteRequestsListContext(ctx context.Context, params AdminInviteRequestsListParams) ([]AdminInviteRequest, string, error) {
+	response := struct {
+		InviteRequests []AdminInviteRequest `json:"invite_requests"`
+		SlackResponse
+	}{}
+
+	err := api.postMethod(ctx, "admin.inviteRequests.list", params.values(api.token), &response)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.InviteRequests, response.ResponseMetadata.Cursor, response.Err()
+}
+
+// AdminInviteRequestsListAll lists all the pending invite requests of a workspace.
+// For more information see the AdminInviteRequestsListAllContext documentation.
+func (api *Client) AdminInviteRequestsListAll(params AdminInviteRequestsListParams) ([]AdminInviteRequest, error) {
+	return api.AdminInviteRequestsListAllContext(context.Background(), params)
+}
+
+// AdminInviteRequestsListAllContext lists the pending invite requests of a workspace from
+// params.Cursor until the last page with a custom context.
+func (api *Client) AdminInviteRequestsListAllContext(ctx context.Context, params AdminInviteRequestsListParams) ([]AdminInviteRequest, error) {
+	var requests []AdminInviteRequest
+	for {
+		page, cursor, err := api.AdminInviteRequestsListContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		requests = append(requests, page...)
+		if cursor == "" {
+			return requests, nil
+		}
+		params.Cursor = cursor
+	}
+}
+
+// AdminInviteRequestsApprovedList lists a page of the approved invite requests of a workspace.
+// For more information see the AdminInviteRequestsApprovedListContext documentation.
+func (api *Client) AdminInviteRequestsApprovedList(params AdminInviteRequestsListParams) ([]AdminInviteRequestResolution, string, error) {
+	return api.AdminInviteRequestsApprovedListContext(context.Background(), params)
+}
+
+// AdminInviteRequestsApprovedListContext lists a page of the approved invite requests of a
+// workspace with a custom context. It returns the cursor of the next page, if any.
+// Slack API docs: https://api.slack.com/methods/admin.inviteRequests.approved.list
+func (api *Client) AdminInviteRequestsApprovedListContext(ctx context.Context, params AdminInviteRequestsListParams) ([]AdminInviteRequestResolution, string, error) {
+	response := struct {
+		ApprovedRequests []AdminInviteRequestResolution `json:"approved_requests"`
+		SlackResponse
+	}{}
+
+	err := api.postMethod(ctx, "admin.inviteRequests.approved.list", params.values(api.token), &response)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.ApprovedRequests, response.ResponseMetadata.Cursor, response.Err()
+}
+
+// AdminInviteRequestsDeniedList lists a page of the denied invite requests of a workspace.
+// For more information see the AdminInviteRequestsDeniedListContext documentation.
+func (api *Client) AdminInviteRequestsDeniedList(params AdminInviteRequestsListParams) ([]AdminInviteRequestResolution, string, error) {
+	return api.AdminInviteRequestsDeniedListContext(context.Background(), params)
+}
+
+// AdminInviteRequestsDeniedListContext lists a page of the denied invite requests of a
+// workspace with a custom context. It returns the cursor of the next page, if any.
+// Slack API docs: https://api.slack.com/methods/admin.inviteRequests.denied.list
+func (api *Client) AdminInviteRequestsDeniedListContext(ctx context.Context, params AdminInviteRequestsListParams) ([]AdminInviteRequestResolution, string, error) {
+	response := struct {
+		DeniedRequests []AdminInviteRequestResolution `json:"denied_requests"`
+		SlackResponse
+	}{}
+
+	err := api.postMethod(ctx, "admin.inviteRequests.denied.list", params.values(api.token), &response)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.DeniedRequests, response.ResponseMetadata.Cursor, response.Err()
+}
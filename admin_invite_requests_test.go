@@ -0,0 +1,68 @@
+package slack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestAdminInviteRequestsListAll(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.inviteRequests.list", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("team_id") != "T1" {
+			t.Errorf("unexpected team_id %q", r.FormValue("team_id"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.FormValue("cursor") {
+		case "":
+			w.Write([]byte(`{"ok":true,"invite_requests":[{"id":"I1","email":"a@example.com","requester_ids":["W1"],"channel_ids":["C1"],"invite_type":"full_member","date_created":1574830800,"team":{"id":"T1","name":"Sales"}}],"response_metadata":{"next_cursor":"page2"}}`))
+		case "page2":
+			w.Write([]byte(`{"ok":true,"invite_requests":[{"id":"I2","email":"b@example.com","invite_type":"restricted"}],"response_metadata":{"next_cursor":""}}`))
+		default:
+			t.Errorf("unexpected cursor %q", r.FormValue("cursor"))
+		}
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	requests, err := api.AdminInviteRequestsListAll(AdminInviteRequestsListParams{TeamID: "T1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []AdminInviteRequest{
+		{ID: "I1", Email: "a@example.com", RequesterIDs: []string{"W1"}, ChannelIDs: []string{"C1"}, InviteType: "full_member", DateCreated: 1574830800, Team: TeamInfo{ID: "T1", Name: "Sales"}},
+		{ID: "I2", Email: "b@example.com", InviteType: "restricted"},
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("expected %+v, got %+v", expected, requests)
+	}
+}
+
+func TestAdminInviteRequestsDeniedList(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.inviteRequests.denied.list", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"denied_requests":[{"invite_request":{"id":"I1","email":"a@example.com"},"denied_by":{"actor_type":"user","actor_id":"W1"}}]}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	denied, cursor, err := api.AdminInviteRequestsDeniedList(AdminInviteRequestsListParams{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []AdminInviteRequestResolution{{
+		InviteRequest: AdminInviteRequest{ID: "I1", Email: "a@example.com"},
+		DeniedBy:      &AdminInviteRequestActor{ActorType: "user", ActorID: "W1"},
+	}}
+	if !reflect.DeepEqual(denied, expected) || cursor != "" {
+		t.Errorf("expected %+v, got %+v and cursor %q", expected, denied, cursor)
+	}
+}
@@ -0,0 +1,348 @@
+package slack
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// TeamDiscoverability is who can find and join a workspace of an Enterprise Grid organisation.
+type TeamDiscoverability string
+
+const (
+	TeamDiscoverabilityOpen       TeamDiscoverability = "open"
+	TeamDiscoverabilityInviteOnly TeamDiscoverability = "invite_only"
+	TeamDiscoverabilityClosed     TeamDiscoverability = "closed"
+	TeamDiscoverabilityUnlisted   TeamDiscoverability = "unlisted"
+)
+
+// AdminTeamOwner is the primary owner of a workspace.
+type AdminTeamOwner struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+// AdminTeam is a workspace of an Enterprise Grid organisation, as returned by AdminTeamsList.
+type AdminTeam struct {
+	ID              string              `json:"id"`
+	Name            string              `json:"name"`
+	Discoverability TeamDiscoverability `json:"discoverability"`
+	PrimaryOwner    AdminTeamOwner      `json:"primary_owner"`
+	TeamURL         string              `json:"team_url"`
+}
+
+// AdminTeamSettings are the settings of a workspace, as returned by AdminTeamsSettingsInfo.
+type AdminTeamSettings struct {
+	ID              string                 `json:"id"`
+	Name            string                 `json:"name"`
+	Domain          string                 `json:"domain"`
+	EmailDomain     string                 `json:"email_domain"`
+	Icon            map[string]interface{} `json:"icon"`
+	EnterpriseID    string                 `json:"enterprise_id"`
+	EnterpriseName  string                 `json:"enterprise_name"`
+	Description     string                 `json:"description"`
+	Discoverability TeamDiscoverability    `json:"discoverability"`
+	DefaultChannels []string               `json:"default_channels"`
+}
+
+type adminTeamsListResponse struct {
+	Teams []AdminTeam `json:"teams"`
+	SlackResponse
+}
+
+type adminTeamsUsersResponse struct {
+	AdminIDs []string `json:"admin_ids"`
+	OwnerIDs []string `json:"owner_ids"`
+	SlackResponse
+}
+
+// AdminTeamsCreateParams contains arguments for AdminTeamsCreate method calls.
+type AdminTeamsCreateParams struct {
+	TeamDomain          string
+	TeamName            string
+	TeamDescription     string
+	TeamDiscoverability TeamDiscoverability
+}
+
+// AdminTeamsCreate creates a workspace in the organisation, and returns its ID.
+// For more information see the AdminTeamsCreateContext documentation.
+func (api *Client) AdminTeamsCreate(params AdminTeamsCreateParams) (string, error) {
+	return api.AdminTeamsCreateContext(context.Background(), params)
+}
+
+// AdminTeamsCreateContext creates a workspace in the organisation, and returns its ID with a custom context.
+// Slack API docs: https://api.slack.com/methods/admin.teams.create
+func (api *Client) AdminTeamsCreateContext(ctx context.Context, params AdminTeamsCreateParams) (string, error) {
+	values := url.Values{
+		"token":       {api.token},
+		"team_domain": {params.TeamDomain},
+		"team_name":   {params.TeamName},
+	}
+	if params.TeamDescription != "" {
+		values.Add("team_description", params.TeamDescription)
+	}
+	if params.TeamDiscoverability != "" {
+		values.Add("team_discoverability", string(params.TeamDiscoverability))
+	}
+
+	response := struct {
+		Team string `json:"team"`
+		SlackResponse
+	}{}
+
+	err := api.postMethod(ctx, "admin.teams.create", values, &response)
+	if err != nil {
+		return "", err
+	}
+
+	return response.Team, response.Err()
+}
+
+// AdminTeamsListParams contains arguments for AdminTeamsList method calls.
+type AdminTeamsListParams struct {
+	Cursor string
+	Limit  int
+}
+
+// AdminTeamsList lists a page of the workspaces of the organisation.
+// For more information see the AdminTeamsListContext documentation.
+func (api *Client) AdminTeamsList(params AdminTeamsListParams) ([]AdminTeam, string, error) {
+	return api.AdminTeamsListContext(context.Background(), params)
+}
+
+// AdminTeamsListContext lists a page of the workspaces of the organisation with a custom context.
+// It returns the cursor of the next page, if any.
+// Slack API docs: https://api.slack.com/methods/admin.teams.list
+func (api *Client) AdminTeamsListContext(ctx context.Context, params AdminTeamsListParams) ([]AdminTeam, string, error) {
+	values := url.Values{
+		"token": {api.token},
+	}
+	if params.Cursor != "" {
+		values.Add("cursor", params.Cursor)
+	}
+	if params.Limit != 0 {
+		values.Add("limit", strconv.Itoa(params.Limit))
+	}
+
+	response := &adminTeamsListResponse{}
+	err := api.postMethod(ctx, "admin.teams.list", values, response)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.Teams, response.ResponseMetadata.Cursor, response.Err()
+}
+
+// AdminTeamsListAll lists all the workspaces of the organisation.
+// For more information see the AdminTeamsListAllContext documentation.
+func (api *Client) AdminTeamsListAll(params AdminTeamsListParams) ([]AdminTeam, error) {
+	return api.AdminTeamsListAllContext(context.Background(), params)
+}
+
+// AdminTeamsListAllContext lists the workspaces of the organisation from params.Cursor until
+// the last page with a custom context.
+func (api *Client) AdminTeamsListAllContext(ctx context.Context, params AdminTeamsListParams) ([]AdminTeam, error) {
+	var teams []AdminTeam
+	for {
+		page, cursor, err := api.AdminTeamsListContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		teams = append(teams, page...)
+		if cursor == "" {
+			return teams, nil
+		}
+		params.Cursor = cursor
+	}
+}
+
+// AdminTeamsUsersListParams contains arguments for AdminTeamsAdminsList and AdminTeamsOwnersList
+// method calls.
+type AdminTeamsUsersListParams struct {
+	TeamID string
+	Cursor string
+	Limit  int
+}
+
+func (api *Client) adminTeamsUsersRequest(ctx context.Context, path string, params AdminTeamsUsersListParams) (*adminTeamsUsersResponse, error) {
+	values := url.Values{
+		"token":   {api.token},
+		"team_id": {params.TeamID},
+	}
+	if params.Cursor != "" {
+		values.Add("cursor", params.Cursor)
+	}
+	if params.Limit != 0 {
+		values.Add("limit", strconv.Itoa(params.Limit))
+	}
+
+	response := &adminTeamsUsersResponse{}
+	err := api.postMethod(ctx, path, values, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, response.Err()
+}
+
+// AdminTeamsAdminsList lists a page of the admins of a workspace.
+// For more information see the AdminTeamsAdminsListContext documentation.
+func (api *Client) AdminTeamsAdminsList(params AdminTeamsUsersListParams) ([]string, string, error) {
+	return api.AdminTeamsAdminsListContext(context.Background(), params)
+}
+
+// AdminTeamsAdminsListContext lists a page of the admins of a workspace with a custom context.
+// It returns the cursor of the next page, if any.
+// Slack API docs: https://api.slack.com/methods/admin.teams.admins.list
+func (api *Client) AdminTeamsAdminsListContext(ctx context.Context, params AdminTeamsUsersListParams) ([]string, string, error) {
+	response, err := api.adminTeamsUsersRequest(ctx, "admin.teams.admins.list", params)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.AdminIDs, response.ResponseMetadata.Cursor, nil
+}
+
+// AdminTeamsOwnersList lists a page of the owners of a workspace.
+// For more information see the AdminTeamsOwnersListContext documentation.
+func (api *Client) AdminTeamsOwnersList(params AdminTeamsUsersListParams) ([]string, string, error) {
+	return api.AdminTeamsOwnersListContext(context.Background(), params)
+}
+
+// AdminTeamsOwnersListContext lists a page of the owners of a workspace with a custom context.
+// It returns the cursor of the next page, if any.
+// Slack API docs: https://api.slack.com/methods/admin.teams.owners.list
+func (api *Client) AdminTeamsOwnersListContext(ctx context.Context, params AdminTeamsUsersListParams) ([]string, string, error) {
+	response, err := api.adminTeamsUsersRequest(ctx, "admin.teams.owners.list", params)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.OwnerIDs, response.ResponseMetadata.Cursor, nil
+}
+
+// AdminTeamsSettingsInfo gets the settings of a workspace.
+// For more information see the AdminTeamsSettingsInfoContext documentation.
+func (api *Client) AdminTeamsSettingsInfo(teamID string) (*AdminTeamSettings, error) {
+	return api.AdminTeamsSettingsInfoContext(context.Background(), teamID)
+}
+
+// AdminTeamsSettingsInfoContext gets the settings of a workspace with a custom context.
+// Slack API docs: https://api.slack.com/methods/admin.teams.settings.info
+func (api *Client) AdminTeamsSettingsInfoContext(ctx context.Context, teamID string) (*AdminTeamSettings, error) {
+	values := url.Values{
+		"token":   {api.token},
+		"team_id": {teamID},
+	}
+
+	response := struct {
+		Team AdminTeamSettings `json:"team"`
+		SlackResponse
+	}{}
+
+	err := api.postMethod(ctx, "admin.teams.settings.info", values, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Err() != nil {
+		return nil, response.Err()
+	}
+
+	return &response.Team, nil
+}
+
+func (api *Client) adminTeamsSettingsRequest(ctx context.Context, path string, teamID string, values url.Values) error {
+	values.Set("token", api.token)
+	values.Set("team_id", teamID)
+
+	response := &SlackResponse{}
+	err := api.postMethod(ctx, path, values, response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// AdminTeamsSettingsSetDefaultChannels sets the default channels of a workspace.
+// For more information see the AdminTeamsSettingsSetDefaultChannelsContext documentation.
+func (api *Client) AdminTeamsSettingsSetDefaultChannels(teamID string, channelIDs ...string) error {
+	return api.AdminTeamsSettingsSetDefaultChannelsContext(context.Background(), teamID, channelIDs...)
+}
+
+// AdminTeamsSettingsSetDefaultChannelsContext sets the default channels of a workspace with a custom context.
+// Slack API docs: https://api.slack.com/methods/admin.teams.settings.setDefaultChannels
+func (api *Client) AdminTeamsSettingsSetDefaultChannelsContext(ctx context.Context, teamID string, channelIDs ...string) error {
+	values := url.Values{
+		"channel_ids": {strings.Join(channelIDs, ",")},
+	}
+
+	return api.adminTeamsSettingsRequest(ctx, "admin.teams.settings.setDefaultChannels", teamID, values)
+}
+
+// AdminTeamsSettingsSetDescription sets the description of a workspace.
+// For more information see the AdminTeamsSettingsSetDescriptionContext documentation.
+func (api *Client) AdminTeamsSettingsSetDescription(teamID string, description string) error {
+	return api.AdminTeamsSettingsSetDescriptionContext(context.Background(), teamID, description)
+}
+
+// AdminTeamsSettingsSetDescriptionContext sets the description of a workspace with a custom context.
+// Slack API docs: https://api.slack.com/methods/admin.teams.settings.setDescription
+func (api *Client) AdminTeamsSettingsSetDescriptionContext(ctx context.Context, teamID string, description string) error {
+	values := url.Values{
+		"description": {description},
+	}
+
+	return api.adminTeamsSettingsRequest(ctx, "admin.teams.settings.setDescription", teamID, values)
+}
+
+// AdminTeamsSettingsSetDiscoverability sets who can find and join a workspace.
+// For more information see the AdminTeamsSettingsSetDiscoverabilityContext documentation.
+func (api *Client) AdminTeamsSettingsSetDiscoverability(teamID string, discoverability TeamDiscoverability) error {
+	return api.AdminTeamsSettingsSetDiscoverabilityContext(context.Background(), teamID, discoverability)
+}
+
+// AdminTeamsSettingsSetDiscoverabilityContext sets who can find and join a workspace with a custom context.
+// Slack API docs: https://api.slack.com/methods/admin.teams.settings.setDiscoverability
+func (api *Client) AdminTeamsSettingsSetDiscoverabilityContext(ctx context.Context, teamID string, discoverability TeamDiscoverability) error {
+	values := url.Values{
+		"discoverability": {string(discoverability)},
+	}
+
+	return api.adminTeamsSettingsRequest(ctx, "admin.teams.settings.setDiscoverability", teamID, values)
+}
+
+// AdminTeamsSettingsSetIcon sets the icon of a workspace from an image URL.
+// For more information see the AdminTeamsSettingsSetIconContext documentation.
+func (api *Client) AdminTeamsSettingsSetIcon(teamID string, imageURL string) error {
+	return api.AdminTeamsSettingsSetIconContext(context.Background(), teamID, imageURL)
+}
+
+// AdminTeamsSettingsSetIconContext sets the icon of a workspace from an image URL with a custom context.
+// Slack API docs: https://api.slack.com/methods/admin.teams.settings.setIcon
+func (api *Client) AdminTeamsSettingsSetIconContext(ctx context.Context, teamID string, imageURL string) error {
+	values := url.Values{
+		"image_url": {imageURL},
+	}
+
+	return api.adminTeamsSettingsRequest(ctx, "admin.teams.settings.setIcon", teamID, values)
+}
+
+// AdminTeamsSettingsSetName sets the name of a workspace.
+// For more information see the AdminTeamsSettingsSetNameContext documentation.
+func (api *Client) AdminTeamsSettingsSetName(teamID string, name string) error {
+	return api.AdminTeamsSettingsSetNameContext(context.Background(), teamID, name)
+}
+
+// AdminTeamsSettingsSetNameContext sets the name of a workspace with a custom context.
+// Slack API docs: https://api.slack.com/methods/admin.teams.settings.setName
+func (api *Client) AdminTeamsSettingsSetNameContext(ctx context.Context, teamID string, name string) error {
+	values := url.Values{
+		"name": {name},
+	}
+
+	return api.adminTeamsSettingsRequest(ctx, "admin.teams.settings.setName", teamID, values)
+}
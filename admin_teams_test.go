@@ -0,0 +1,82 @@
+package slack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestAdminTeamsListAll(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.teams.list", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.FormValue("cursor") {
+		case "":
+			w.Write([]byte(`{"ok":true,"teams":[{"id":"T1","name":"Sales","discoverability":"open","primary_owner":{"user_id":"W1","email":"a@example.com"},"team_url":"https://sales.slack.com/"}],"response_metadata":{"next_cursor":"page2"}}`))
+		case "page2":
+			w.Write([]byte(`{"ok":true,"teams":[{"id":"T2","name":"Support","discoverability":"unlisted"}],"response_metadata":{"next_cursor":""}}`))
+		default:
+			t.Errorf("unexpected cursor %q", r.FormValue("cursor"))
+		}
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	teams, err := api.AdminTeamsListAll(AdminTeamsListParams{Limit: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []AdminTeam{
+		{ID: "T1", Name: "Sales", Discoverability: TeamDiscoverabilityOpen, PrimaryOwner: AdminTeamOwner{UserID: "W1", Email: "a@example.com"}, TeamURL: "https://sales.slack.com/"},
+		{ID: "T2", Name: "Support", Discoverability: TeamDiscoverabilityUnlisted},
+	}
+	if !reflect.DeepEqual(teams, expected) {
+		t.Errorf("expected %+v, got %+v", expected, teams)
+	}
+}
+
+func TestAdminTeamsAdminsList(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.teams.admins.list", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("team_id") != "T1" {
+			t.Errorf("unexpected team_id %q", r.FormValue("team_id"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"admin_ids":["W1","W2"],"response_metadata":{"next_cursor":"next"}}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	admins, cursor, err := api.AdminTeamsAdminsList(AdminTeamsUsersListParams{TeamID: "T1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(admins, []string{"W1", "W2"}) || cursor != "next" {
+		t.Errorf("unexpected admins %v and cursor %q", admins, cursor)
+	}
+}
+
+func TestAdminTeamsSettingsSetDiscoverability(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.teams.settings.setDiscoverability", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("team_id") != "T1" || r.FormValue("discoverability") != "invite_only" {
+			t.Errorf("unexpected form %v", r.Form)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	if err := api.AdminTeamsSettingsSetDiscoverability("T1", TeamDiscoverabilityInviteOnly); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,127 @@
+package slack
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// AdminUserGroupChannel is a default channel of an IDP group.
+type AdminUserGroupChannel struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	TeamID     string `json:"team_id"`
+	NumMembers int    `json:"num_members"`
+}
+
+func (api *Client) adminUserGroupsRequest(ctx context.Context, path string, values url.Values) error {
+	response := &SlackResponse{}
+	err := api.postMethod(ctx, path, values, response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// AdminUserGroupsAddChannels adds default channels to an IDP group.
+// For more information see the AdminUserGroupsAddChannelsContext documentation.
+func (api *Client) AdminUserGroupsAddChannels(userGroupID string, teamID string, channelIDs ...string) error {
+	return api.AdminUserGroupsAddChannelsContext(context.Background(), userGroupID, teamID, channelIDs...)
+}
+
+// AdminUserGroupsAddChannelsContext adds default channels to an IDP group with a custom context.
+// teamID is only required for the groups of a workspace.
+// Slack API docs: https://api.slack.com/methods/admin.usergroups.addChannels
+func (api *Client) AdminUserGroupsAddChannelsContext(ctx context.Context, userGroupID string, teamID string, channelIDs ...string) error {
+	values := url.Values{
+		"token":        {api.token},
+		"usergroup_id": {userGroupID},
+		"channel_ids":  {strings.Join(channelIDs, ",")},
+	}
+	if teamID != "" {
+		values.Add("team_id", teamID)
+	}
+
+	return api.adminUserGroupsRequest(ctx, "admin.usergroups.addChannels", values)
+}
+
+// AdminUserGroupsAddTeams associates workspaces with an IDP group.
+// For more information see the AdminUserGroupsAddTeamsContext documentation.
+func (api *Client) AdminUserGroupsAddTeams(userGroupID string, autoProvision bool, teamIDs ...string) error {
+	return api.AdminUserGroupsAddTeamsContext(context.Background(), userGroupID, autoProvision, teamIDs...)
+}
+
+// AdminUserGroupsAddTeamsContext associates workspaces with an IDP group with a custom context.
+// When autoProvision is true, the members of the group are added to the workspaces.
+// Slack API docs: https://api.slack.com/methods/admin.usergroups.addTeams
+func (api *Client) AdminUserGroupsAddTeamsContext(ctx context.Context, userGroupID string, autoProvision bool, teamIDs ...string) error {
+	values := url.Values{
+		"token":        {api.token},
+		"usergroup_id": {userGroupID},
+		"team_ids":     {strings.Join(teamIDs, ",")},
+	}
+	if autoProvision {
+		values.Add("auto_provision", "true")
+	}
+
+	return api.adminUserGroupsRequest(ctx, "admin.usergroups.addTeams", values)
+}
+
+// AdminUserGroupsListChannelsParams contains arguments for AdminUserGroupsListChannels method calls.
+type AdminUserGroupsListChannelsParams struct {
+	UserGroupID       string
+	TeamID            string
+	IncludeNumMembers bool
+}
+
+// AdminUserGroupsListChannels lists the default channels of an IDP group.
+// For more information see the AdminUserGroupsListChannelsContext documentation.
+func (api *Client) AdminUserGroupsListChannels(params AdminUserGroupsListChannelsParams) ([]AdminUserGroupChannel, error) {
+	return api.AdminUserGroupsListChannelsContext(context.Background(), params)
+}
+
+// AdminUserGroupsListChannelsContext lists the default channels of an IDP group with a custom context.
+// Slack API docs: https://api.slack.com/methods/admin.usergroups.listChannels
+func (api *Client) AdminUserGroupsListChannelsContext(ctx context.Context, params AdminUserGroupsListChannelsParams) ([]AdminUserGroupChannel, error) {
+	values := url.Values{
+		"token":        {api.token},
+		"usergroup_id": {params.UserGroupID},
+	}
+	if params.TeamID != "" {
+		values.Add("team_id", params.TeamID)
+	}
+	if params.IncludeNumMembers {
+		values.Add("include_num_members", "true")
+	}
+
+	response := struct {
+		Channels []AdminUserGroupChannel `json:"channels"`
+		SlackResponse
+	}{}
+
+	err := api.postMethod(ctx, "admin.usergroups.listChannels", values, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Channels, response.Err()
+}
+
+// AdminUserGroupsRemoveChannels removes default channels from an IDP group.
+// For more information see the AdminUserGroupsRemoveChannelsContext documentation.
+func (api *Client) AdminUserGroupsRemoveChannels(userGroupID string, channelIDs ...string) error {
+	return api.AdminUserGroupsRemoveChannelsContext(context.Background(), userGroupID, channelIDs...)
+}
+
+// AdminUserGroupsRemoveChannelsContext removes default channels from an IDP group with a custom context.
+// Slack API docs: https://api.slack.com/methods/admin.usergroups.removeChannels
+func (api *Client) AdminUserGroupsRemoveChannelsContext(ctx context.Context, userGroupID string, channelIDs ...string) error {
+	values := url.Values{
+		"token":        {api.token},
+		"usergroup_id": {userGroupID},
+		"channel_ids":  {strings.Join(channelIDs, ",")},
+	}
+
+	return api.adminUserGroupsRequest(ctx, "admin.usergroups.removeChannels", values)
+}
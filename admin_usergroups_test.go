@@ -0,0 +1,52 @@
+package slack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestAdminUserGroupsListChannels(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.usergroups.listChannels", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("usergroup_id") != "S1" || r.FormValue("include_num_members") != "true" {
+			t.Errorf("unexpected form %v", r.Form)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"channels":[{"id":"C1","name":"general","team_id":"T1","num_members":12}]}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	channels, err := api.AdminUserGroupsListChannels(AdminUserGroupsListChannelsParams{UserGroupID: "S1", IncludeNumMembers: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []AdminUserGroupChannel{{ID: "C1", Name: "general", TeamID: "T1", NumMembers: 12}}
+	if !reflect.DeepEqual(channels, expected) {
+		t.Errorf("expected %+v, got %+v", expected, channels)
+	}
+}
+
+func TestAdminUserGroupsAddTeams(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.usergroups.addTeams", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("team_ids") != "T1,T2" || r.FormValue("auto_provision") != "true" {
+			t.Errorf("unexpected form %v", r.Form)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	if err := api.AdminUserGroupsAddTeams("S1", true, "T1", "T2"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,434 @@
+package slack
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// AdminUser is a user of an Enterprise Grid organisation, as returned by AdminUsersList.
+type AdminUser struct {
+	ID                string   `json:"id"`
+	Email             string   `json:"email"`
+	Username          string   `json:"username"`
+	FullName          string   `json:"full_name"`
+	IsAdmin           bool     `json:"is_admin"`
+	IsOwner           bool     `json:"is_owner"`
+	IsPrimaryOwner    bool     `json:"is_primary_owner"`
+	IsRestricted      bool     `json:"is_restricted"`
+	IsUltraRestricted bool     `json:"is_ultra_restricted"`
+	IsBot             bool     `json:"is_bot"`
+	IsActive          bool     `json:"is_active"`
+	IsInvitedUser     bool     `json:"is_invited_user"`
+	IsEmailConfirmed  bool     `json:"is_email_confirmed"`
+	Has2FA            bool     `json:"has_2fa"`
+	HasSSO            bool     `json:"has_sso"`
+	DateCreated       JSONTime `json:"date_created"`
+	DeactivatedTs     JSONTime `json:"deactivated_ts"`
+	ExpirationTs      JSONTime `json:"expiration_ts"`
+	Workspaces        []string `json:"workspaces"`
+}
+
+// AdminUsersListParams contains arguments for AdminUsersList method calls.
+type AdminUsersListParams struct {
+	// TeamID lists the users of a workspace. The users of the whole organisation are
+	// listed when it is empty, which requires an org-level token.
+	TeamID                           string
+	Cursor                           string
+	Limit                            int
+	IsActive                         *bool
+	OnlyGuests                       bool
+	IncludeDeactivatedUserWorkspaces bool
+}
+
+// AdminUsersList lists the users of a workspace or of the organisation. It returns the
+// cursor of the next page, if any.
+// See: https://api.slack.com/methods/admin.users.list
+func (api *Client) AdminUsersList(ctx context.Context, params AdminUsersListParams) ([]AdminUser, string, error) {
+	values := url.Values{
+		"token": {api.token},
+	}
+
+	if params.TeamID != "" {
+		values.Add("team_id", params.TeamID)
+	}
+
+	if params.Cursor != "" {
+		values.Add("cursor", params.Cursor)
+	}
+
+	if params.Limit != 0 {
+		values.Add("limit", strconv.Itoa(params.Limit))
+	}
+
+	if params.IsActive != nil {
+		values.Add("is_active", strconv.FormatBool(*params.IsActive))
+	}
+
+	if params.OnlyGuests {
+		values.Add("only_guests", "true")
+	}
+
+	if params.IncludeDeactivatedUserWorkspaces {
+		values.Add("include_deactivated_user_workspaces", "true")
+	}
+
+	response := struct {
+		Users []AdminUser `json:"users"`
+		SlackResponse
+	}{}
+
+	err := api.postMethod(ctx, "admin.users.list", values, &response)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.Users, response.ResponseMetadata.Cursor, response.Err()
+}
+
+// AdminUsersListAll lists the users like AdminUsersList does, following the cursors
+// from params.Cursor until the last page.
+func (api *Client) AdminUsersListAll(ctx context.Context, params AdminUsersListParams) ([]AdminUser, error) {
+	var users []AdminUser
+	for {
+		page, cursor, err := api.AdminUsersList(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, page...)
+		if cursor == "" {
+			return users, nil
+		}
+		params.Cursor = cursor
+	}
+}
+
+// AdminUsersInviteParams contains arguments for AdminUsersInvite method calls.
+type AdminUsersInviteParams struct {
+	TeamID string
+	Email  string
+	// ChannelIDs are the channels the user joins. At least one is required.
+	ChannelIDs                 []string
+	CustomMessage              string
+	RealName                   string
+	IsRestricted               bool
+	IsUltraRestricted          bool
+	GuestExpirationTs          int64
+	Resend                     bool
+	EmailPasswordPolicyEnabled bool
+}
+
+// AdminUsersInvite invites a user to a workspace.
+// See: https://api.slack.com/methods/admin.users.invite
+func (api *Client) AdminUsersInvite(ctx context.Context, params AdminUsersInviteParams) error {
+	values := url.Values{
+		"token":       {api.token},
+		"team_id":     {params.TeamID},
+		"email":       {params.Email},
+		"channel_ids": {strings.Join(params.ChannelIDs, ",")},
+	}
+
+	if params.CustomMessage != "" {
+		values.Add("custom_message", params.CustomMessage)
+	}
+
+	if params.RealName != "" {
+		values.Add("real_name", params.RealName)
+	}
+
+	if params.IsRestricted {
+		values.Add("is_restricted", "true")
+	}
+
+	if params.IsUltraRestricted {
+		values.Add("is_ultra_restricted", "true")
+	}
+
+	if params.GuestExpirationTs != 0 {
+		values.Add("guest_expiration_ts", strconv.FormatInt(params.GuestExpirationTs, 10))
+	}
+
+	if params.Resend {
+		values.Add("resend", "true")
+	}
+
+	if params.EmailPasswordPolicyEnabled {
+		values.Add("email_password_policy_enabled", "true")
+	}
+
+	response := &SlackResponse{}
+	err := api.postMethod(ctx, "admin.users.invite", values, response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// AdminUsersAssignParams contains arguments for AdminUsersAssign method calls.
+type AdminUsersAssignParams struct {
+	TeamID            string
+	UserID            string
+	ChannelIDs        []string
+	IsRestricted      bool
+	IsUltraRestricted bool
+}
+
+// AdminUsersAssign adds an existing user of the organisation to a workspace.
+// See: https://api.slack.com/methods/admin.users.assign
+func (api *Client) AdminUsersAssign(ctx context.Context, params AdminUsersAssignParams) error {
+	values := url.Values{
+		"token":   {api.token},
+		"team_id": {params.TeamID},
+		"user_id": {params.UserID},
+	}
+
+	if len(params.ChannelIDs) > 0 {
+		values.Add("channel_ids", strings.Join(params.ChannelIDs, ","))
+	}
+
+	if params.IsRestricted {
+		values.Add("is_restricted", "true")
+	}
+
+	if params.IsUltraRestricted {
+		values.Add("is_ultra_restricted", "true")
+	}
+
+	response := &SlackResponse{}
+	err := api.postMethod(ctx, "admin.users.assign", values, response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// adminUsersRequest calls an admin.users.* method taking a team and a user.
+func (api *Client) adminUsersRequest(ctx context.Context, method string, teamID string, userID string) error {
+	values := url.Values{
+		"token":   {api.token},
+		"team_id": {teamID},
+		"user_id": {userID},
+	}
+
+	response := &SlackResponse{}
+	err := api.postMethod(ctx, method, values, response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// AdminUsersRemove removes a user from a workspace.
+// See: https://api.slack.com/methods/admin.users.remove
+func (api *Client) AdminUsersRemove(ctx context.Context, teamID string, userID string) error {
+	return api.adminUsersRequest(ctx, "admin.users.remove", teamID, userID)
+}
+
+// AdminUsersSetAdmin sets an existing regular user or owner to be a workspace admin.
+// See: https://api.slack.com/methods/admin.users.setAdmin
+func (api *Client) AdminUsersSetAdmin(ctx context.Context, teamID string, userID string) error {
+	return api.adminUsersRequest(ctx, "admin.users.setAdmin", teamID, userID)
+}
+
+// AdminUsersSetOwner sets an existing regular user or admin to be a workspace owner.
+// See: https://api.slack.com/methods/admin.users.setOwner
+func (api *Client) AdminUsersSetOwner(ctx context.Context, teamID string, userID string) error {
+	return api.adminUsersRequest(ctx, "admin.users.setOwner", teamID, userID)
+}
+
+// AdminUsersSetRegular sets an existing guest, admin or owner to be a regular user.
+// See: https://api.slack.com/methods/admin.users.setRegular
+func (api *Client) AdminUsersSetRegular(ctx context.Context, teamID string, userID string) error {
+	return api.adminUsersRequest(ctx, "admin.users.setRegular", teamID, userID)
+}
+
+// AdminUsersSetExpirationParams contains arguments for AdminUsersSetExpiration method calls.
+type AdminUsersSetExpirationParams struct {
+	UserID       string
+	ExpirationTs int64
+	// TeamID is required for workspace-level tokens.
+	TeamID string
+}
+
+// AdminUsersSetExpiration sets the expiration date of a guest user.
+// See: https://api.slack.com/methods/admin.users.setExpiration
+func (api *Client) AdminUsersSetExpiration(ctx context.Context, params AdminUsersSetExpirationParams) error {
+	values := url.Values{
+		"token":         {api.token},
+		"user_id":       {params.UserID},
+		"expiration_ts": {strconv.FormatInt(params.ExpirationTs, 10)},
+	}
+
+	if params.TeamID != "" {
+		values.Add("team_id", params.TeamID)
+	}
+
+	response := &SlackResponse{}
+	err := api.postMethod(ctx, "admin.users.setExpiration", values, response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// AdminUsersSessionResetParams contains arguments for AdminUsersSessionReset method calls.
+type AdminUsersSessionResetParams struct {
+	UserID string
+	// MobileOnly and WebOnly limit the reset to the sessions of the mobile or the web
+	// clients. All the sessions are reset when both are false.
+	MobileOnly bool
+	WebOnly    bool
+}
+
+// AdminUsersSessionReset wipes all the valid sessions of a user, signing them out.
+// See: https://api.slack.com/methods/admin.users.session.reset
+func (api *Client) AdminUsersSessionReset(ctx context.Context, params AdminUsersSessionResetParams) error {
+	values := url.Values{
+		"token":   {api.token},
+		"user_id": {params.UserID},
+	}
+
+	if params.MobileOnly {
+		values.Add("mobile_only", "true")
+	}
+
+	if params.WebOnly {
+		values.Add("web_only", "true")
+	}
+
+	response := &SlackResponse{}
+	err := api.postMethod(ctx, "admin.users.session.reset", values, response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// AdminUserSessionClient describes the client of a session.
+type AdminUserSessionClient struct {
+	DeviceHardware     string `json:"device_hardware"`
+	OS                 string `json:"os"`
+	OSVersion          string `json:"os_version"`
+	SlackClientVersion string `json:"slack_client_version"`
+	IP                 string `json:"ip"`
+}
+
+// AdminUserSession is an active session of a user.
+type AdminUserSession struct {
+	UserID    string `json:"user_id"`
+	TeamID    string `json:"team_id"`
+	SessionID int64  `json:"session_id"`
+	// Created describes the client the session was created with, and Recent the
+	// client it was most recently used from.
+	Created *AdminUserSessionClient `json:"created,omitempty"`
+	Recent  *AdminUserSessionClient `json:"recent,omitempty"`
+}
+
+// AdminUsersSessionListParams contains arguments for AdminUsersSessionList method calls.
+type AdminUsersSessionListParams struct {
+	// TeamID and UserID must be both set to list the sessions of a user, or both empty
+	// to list the active sessions of the organisation.
+	TeamID string
+	UserID string
+	Cursor string
+	Limit  int
+}
+
+// AdminUsersSessionList lists the active sessions of the organisation or of a user. It
+// returns the cursor of the next page, if any.
+// See: https://api.slack.com/methods/admin.users.session.list
+func (api *Client) AdminUsersSessionList(ctx context.Context, params AdminUsersSessionListParams) ([]AdminUserSession, string, error) {
+	values := url.Values{
+		"token": {api.token},
+	}
+
+	if params.TeamID != "" {
+		values.Add("team_id", params.TeamID)
+	}
+
+	if params.UserID != "" {
+		values.Add("user_id", params.UserID)
+	}
+
+	if params.Cursor != "" {
+		values.Add("cursor", params.Cursor)
+	}
+
+	if params.Limit != 0 {
+		values.Add("limit", strconv.Itoa(params.Limit))
+	}
+
+	response := struct {
+		ActiveSessions []AdminUserSession `json:"active_sessions"`
+		SlackResponse
+	}{}
+
+	err := api.postMethod(ctx, "admin.users.session.list", values, &response)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.ActiveSessions, response.ResponseMetadata.Cursor, response.Err()
+}
+
+// AdminUsersSessionInvalidate revokes a single session of a user, signing them out of
+// the client of the session.
+// See: https://api.slack.com/methods/admin.users.session.invalidate
+func (api *Client) AdminUsersSessionInvalidate(ctx context.Context, teamID string, sessionID int64) error {
+	values := url.Values{
+		"token":      {api.token},
+		"team_id":    {teamID},
+		"session_id": {strconv.FormatInt(sessionID, 10)},
+	}
+
+	response := &SlackResponse{}
+	err := api.postMethod(ctx, "admin.users.session.invalidate", values, response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// AdminUsersUnsupportedVersionsExportParams contains arguments for
+// AdminUsersUnsupportedVersionsExport method calls.
+type AdminUsersUnsupportedVersionsExportParams struct {
+	// DateEndOfSupport exports the users whose client versions are unsupported at this
+	// date, defaulting to the next upcoming end of support.
+	DateEndOfSupport int64
+	// DateSessionsStarted only exports the sessions started after this date, defaulting
+	// to 30 days before DateEndOfSupport.
+	DateSessionsStarted int64
+}
+
+// AdminUsersUnsupportedVersionsExport asks Slack to export the users and the clients
+// using unsupported versions of Slack. The export is sent to the caller by Slackbot.
+// See: https://api.slack.com/methods/admin.users.unsupportedVersions.export
+func (api *Client) AdminUsersUnsupportedVersionsExport(ctx context.Context, params AdminUsersUnsupportedVersionsExportParams) error {
+	values := url.Values{
+		"token": {api.token},
+	}
+
+	if params.DateEndOfSupport != 0 {
+		values.Add("date_end_of_support", strconv.FormatInt(params.DateEndOfSupport, 10))
+	}
+
+	if params.DateSessionsStarted != 0 {
+		values.Add("date_sessions_started", strconv.FormatInt(params.DateSessionsStarted, 10))
+	}
+
+	response := &SlackResponse{}
+	err := api.postMethod(ctx, "admin.users.unsupportedVersions.export", values, response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
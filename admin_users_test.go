@@ -0,0 +1,148 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestAdminUsersListAll(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.users.list", func(rw http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unexpected error: %s", err)
+			return
+		}
+		if r.Form.Get("team_id") != "T1" || r.Form.Get("is_active") != "true" {
+			t.Errorf("unexpected form %v", r.Form)
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		switch r.Form.Get("cursor") {
+		case "":
+			rw.Write([]byte(`{"ok":true,"users":[{"id":"W1","email":"a@example.com","is_admin":true,"date_created":1574830800,"workspaces":["T1"]}],"response_metadata":{"next_cursor":"page2"}}`))
+		case "page2":
+			rw.Write([]byte(`{"ok":true,"users":[{"id":"W2","is_restricted":true,"expiration_ts":1700000000}],"response_metadata":{"next_cursor":""}}`))
+		default:
+			t.Errorf("unexpected cursor %q", r.Form.Get("cursor"))
+		}
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	active := true
+	users, err := api.AdminUsersListAll(context.Background(), AdminUsersListParams{TeamID: "T1", IsActive: &active})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []AdminUser{
+		{ID: "W1", Email: "a@example.com", IsAdmin: true, DateCreated: 1574830800, Workspaces: []string{"T1"}},
+		{ID: "W2", IsRestricted: true, ExpirationTs: 1700000000},
+	}
+	if !reflect.DeepEqual(users, expected) {
+		t.Errorf("expected %+v, got %+v", expected, users)
+	}
+}
+
+func TestAdminUsersMethods(t *testing.T) {
+	var forms = map[string]map[string]string{}
+
+	mux := http.NewServeMux()
+	handler := func(rw http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unexpected error: %s", err)
+			return
+		}
+		form := map[string]string{}
+		for k := range r.Form {
+			if k != "token" {
+				form[k] = r.Form.Get(k)
+			}
+		}
+		forms[r.URL.Path] = form
+
+		rw.Header().Set("Content-Type", "application/json")
+		response, _ := json.Marshal(SlackResponse{Ok: true})
+		rw.Write(response)
+	}
+	for _, method := range []string{
+		"admin.users.invite",
+		"admin.users.assign",
+		"admin.users.remove",
+		"admin.users.setAdmin",
+		"admin.users.setExpiration",
+		"admin.users.session.reset",
+		"admin.users.session.invalidate",
+	} {
+		mux.HandleFunc("/"+method, handler)
+	}
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+	ctx := context.Background()
+
+	calls := []error{
+		api.AdminUsersInvite(ctx, AdminUsersInviteParams{TeamID: "T1", Email: "a@example.com", ChannelIDs: []string{"C1", "C2"}, IsUltraRestricted: true, GuestExpirationTs: 1700000000}),
+		api.AdminUsersAssign(ctx, AdminUsersAssignParams{TeamID: "T1", UserID: "W1", ChannelIDs: []string{"C1"}}),
+		api.AdminUsersRemove(ctx, "T1", "W1"),
+		api.AdminUsersSetAdmin(ctx, "T1", "W1"),
+		api.AdminUsersSetExpiration(ctx, AdminUsersSetExpirationParams{UserID: "W1", ExpirationTs: 1700000000}),
+		api.AdminUsersSessionReset(ctx, AdminUsersSessionResetParams{UserID: "W1", MobileOnly: true}),
+		api.AdminUsersSessionInvalidate(ctx, "T1", 1234),
+	}
+	for i, err := range calls {
+		if err != nil {
+			t.Errorf("call %d: unexpected error: %s", i, err)
+		}
+	}
+
+	expected := map[string]map[string]string{
+		"/admin.users.invite":             {"team_id": "T1", "email": "a@example.com", "channel_ids": "C1,C2", "is_ultra_restricted": "true", "guest_expiration_ts": "1700000000"},
+		"/admin.users.assign":             {"team_id": "T1", "user_id": "W1", "channel_ids": "C1"},
+		"/admin.users.remove":             {"team_id": "T1", "user_id": "W1"},
+		"/admin.users.setAdmin":           {"team_id": "T1", "user_id": "W1"},
+		"/admin.users.setExpiration":      {"user_id": "W1", "expiration_ts": "1700000000"},
+		"/admin.users.session.reset":      {"user_id": "W1", "mobile_only": "true"},
+		"/admin.users.session.invalidate": {"team_id": "T1", "session_id": "1234"},
+	}
+	if !reflect.DeepEqual(forms, expected) {
+		t.Errorf("expected %v, got %v", expected, forms)
+	}
+}
+
+func TestAdminUsersSessionList(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.users.session.list", func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"ok":true,"active_sessions":[{"user_id":"W1","team_id":"T1","session_id":1234,"created":{"device_hardware":"iPhone","os":"iOS","ip":"1.2.3.4"}}],"response_metadata":{"next_cursor":"next"}}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	sessions, cursor, err := api.AdminUsersSessionList(context.Background(), AdminUsersSessionListParams{TeamID: "T1", UserID: "W1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cursor != "next" {
+		t.Errorf("expected cursor next, got %q", cursor)
+	}
+
+	expected := []AdminUserSession{{
+		UserID:    "W1",
+		TeamID:    "T1",
+		SessionID: 1234,
+		Created:   &AdminUserSessionClient{DeviceHardware: "iPhone", OS: "iOS", IP: "1.2.3.4"},
+	}}
+	if !reflect.DeepEqual(sessions, expected) {
+		t.Errorf("expected %+v, got %+v", expected, sessions)
+	}
+}
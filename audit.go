@@ -2,6 +2,7 @@ package slack
 
 import (
 	"context"
+	"encoding/json"
 	"net/url"
 	"strconv"
 )
@@ -150,3 +151,113 @@ func (api *Client) GetAuditLogsContext(ctx context.Context, params AuditLogParam
 	}
 	return response.Entries, response.ResponseMetadata.Cursor, response.Err()
 }
+
+// IterateAuditLogs calls f with the audit entries matching params, page after page.
+// For more information see the IterateAuditLogsContext documentation.
+func (api *Client) IterateAuditLogs(params AuditLogParameters, f func(AuditEntry) error) error {
+	return api.IterateAuditLogsContext(context.Background(), params, f)
+}
+
+// IterateAuditLogsContext calls f with the audit entries matching params, following the cursors
+// from params.Cursor until the last page, with a custom context. The rate limited requests are
+// retried once allowed. The iteration stops at the first error of f, which is returned.
+func (api *Client) IterateAuditLogsContext(ctx context.Context, params AuditLogParameters, f func(AuditEntry) error) error {
+	for {
+		entries, cursor, err := api.GetAuditLogsContext(ctx, params)
+		if err != nil {
+			if waitRateLimit(ctx, err) {
+				continue
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		for _, entry := range entries {
+			if err := f(entry); err != nil {
+				return err
+			}
+		}
+
+		if cursor == "" {
+			return nil
+		}
+		params.Cursor = cursor
+	}
+}
+
+// AuditSchema describes the entities of a type, e.g. "user" or "channel", as returned by
+// GetAuditSchemas.
+type AuditSchema struct {
+	Type string
+	// Fields are the fields of the entities of the type, with example values.
+	Fields map[string]interface{}
+}
+
+// UnmarshalJSON decodes a schema, whose fields are keyed by its type.
+func (s *AuditSchema) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	s.Type, s.Fields = "", nil
+	if t, ok := raw["type"]; ok {
+		if err := json.Unmarshal(t, &s.Type); err != nil {
+			return err
+		}
+	}
+	if fields, ok := raw[s.Type]; ok {
+		if err := json.Unmarshal(fields, &s.Fields); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetAuditSchemas retrieves the types of the entities of the audit entries.
+// For more information see the GetAuditSchemasContext documentation.
+func (api *Client) GetAuditSchemas() ([]AuditSchema, error) {
+	return api.GetAuditSchemasContext(context.Background())
+}
+
+// GetAuditSchemasContext retrieves the types of the entities of the audit entries with a custom context.
+// Slack API docs: https://api.slack.com/admins/audit-logs-call#the-schemas-endpoint
+func (api *Client) GetAuditSchemasContext(ctx context.Context) ([]AuditSchema, error) {
+	response := struct {
+		Schemas []AuditSchema `json:"schemas"`
+		SlackResponse
+	}{}
+
+	err := api.getMethod(ctx, "audit/v1/schemas", api.token, url.Values{}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Schemas, response.Err()
+}
+
+// GetAuditActions retrieves the actions of the audit entries, keyed by the type of their entity.
+// For more information see the GetAuditActionsContext documentation.
+func (api *Client) GetAuditActions() (map[string][]string, error) {
+	return api.GetAuditActionsContext(context.Background())
+}
+
+// GetAuditActionsContext retrieves the actions of the audit entries, keyed by the type of their
+// entity, e.g. "user" or "channel", with a custom context.
+// Slack API docs: https://api.slack.com/admins/audit-logs-call#the-actions-endpoint
+func (api *Client) GetAuditActionsContext(ctx context.Context) (map[string][]string, error) {
+	response := struct {
+		Actions map[string][]string `json:"actions"`
+		SlackResponse
+	}{}
+
+	err := api.getMethod(ctx, "audit/v1/actions", api.token, url.Values{}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Actions, response.Err()
+}
@@ -0,0 +1,153 @@
+package slack
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+const (
+	defaultAuditLogTailerInterval = time.Minute
+	defaultAuditLogTailerOverlap  = 5 * time.Minute
+)
+
+// AuditLogCheckpoint is the position of an AuditLogTailer, to be persisted so that it can
+// resume where it stopped.
+type AuditLogCheckpoint struct {
+	// Oldest is the creation time of the most recent entry emitted, as a unix timestamp.
+	Oldest int `json:"oldest"`
+	// SeenIDs are the IDs of the entries emitted within the overlap before Oldest, which
+	// are not emitted again.
+	SeenIDs []string `json:"seen_ids,omitempty"`
+}
+
+// AuditLogTailer continuously polls the audit logs, and emits the new entries in their order of
+// creation. Every poll reads the entries created since the watermark of the previous one minus an
+// overlap, so that the entries indexed late by Slack are not missed, and the entries already
+// emitted are deduplicated by ID.
+type AuditLogTailer struct {
+	Client *Client
+	// Params filters the entries, e.g. by action. Its Oldest, Latest and Cursor are ignored.
+	Params AuditLogParameters
+	// Interval is the time between two polls, a minute by default.
+	Interval time.Duration
+	// Overlap is how far before the watermark every poll reads, five minutes by default.
+	Overlap time.Duration
+	// Checkpoint is the position to resume from. The tailer starts with the entries created
+	// from now on when it is zero.
+	Checkpoint AuditLogCheckpoint
+	// OnCheckpoint, when set, is called with the new checkpoint once the entries of a poll are
+	// emitted. An error stops the tailer.
+	OnCheckpoint func(ctx context.Context, checkpoint AuditLogCheckpoint) error
+}
+
+// NewAuditLogTailer returns an AuditLogTailer polling the audit logs with client from checkpoint.
+func NewAuditLogTailer(client *Client, checkpoint AuditLogCheckpoint) *AuditLogTailer {
+	return &AuditLogTailer{
+		Client:     client,
+		Interval:   defaultAuditLogTailerInterval,
+		Overlap:    defaultAuditLogTailerOverlap,
+		Checkpoint: checkpoint,
+	}
+}
+
+// Run polls the audit logs and sends the new entries to entries until ctx is done, or an error
+// other than a rate limit occurs. It returns the error, and the tailer can then be run again from
+// its Checkpoint. The entries of an interrupted poll are emitted again.
+func (t *AuditLogTailer) Run(ctx context.Context, entries chan<- AuditEntry) error {
+	interval := t.Interval
+	if interval <= 0 {
+		interval = defaultAuditLogTailerInterval
+	}
+
+	for {
+		if err := t.Poll(ctx, entries); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Poll reads the audit logs once, sends the new entries to entries, and moves the checkpoint.
+func (t *AuditLogTailer) Poll(ctx context.Context, entries chan<- AuditEntry) error {
+	if t.Checkpoint.Oldest == 0 {
+		t.Checkpoint.Oldest = int(time.Now().Unix())
+	}
+
+	overlap := int(t.Overlap / time.Second)
+	if t.Overlap == 0 {
+		overlap = int(defaultAuditLogTailerOverlap / time.Second)
+	}
+
+	seen := make(map[string]bool, len(t.Checkpoint.SeenIDs))
+	for _, id := range t.Checkpoint.SeenIDs {
+		seen[id] = true
+	}
+
+	params := t.Params
+	params.Cursor = ""
+	params.Latest = 0
+	params.Oldest = t.Checkpoint.Oldest - overlap
+
+	var fresh []AuditEntry
+	// read holds the creation time of all the entries read, emitted before or not.
+	read := make(map[string]int)
+	err := t.Client.IterateAuditLogsContext(ctx, params, func(entry AuditEntry) error {
+		read[entry.ID] = entry.DateCreate
+		if !seen[entry.ID] {
+			seen[entry.ID] = true
+			fresh = append(fresh, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(fresh) == 0 {
+		return nil
+	}
+
+	// The entries are returned from the most recent.
+	sort.SliceStable(fresh, func(i, j int) bool {
+		return fresh[i].DateCreate < fresh[j].DateCreate
+	})
+
+	for _, entry := range fresh {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case entries <- entry:
+		}
+	}
+
+	checkpoint := AuditLogCheckpoint{Oldest: t.Checkpoint.Oldest}
+	if last := fresh[len(fresh)-1].DateCreate; last > checkpoint.Oldest {
+		checkpoint.Oldest = last
+	}
+
+	// Only the entries within the next overlap can be read again.
+	for id, date := range read {
+		if date >= checkpoint.Oldest-overlap {
+			checkpoint.SeenIDs = append(checkpoint.SeenIDs, id)
+		}
+	}
+	sort.Strings(checkpoint.SeenIDs)
+
+	if t.OnCheckpoint != nil {
+		if err := t.OnCheckpoint(ctx, checkpoint); err != nil {
+			return err
+		}
+	}
+	t.Checkpoint = checkpoint
+
+	return nil
+}
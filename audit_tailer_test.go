@@ -0,0 +1,102 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAuditLogTailerPoll(t *testing.T) {
+	// The second poll reads E2 again, and E3 that Slack indexed late.
+	pages := []string{
+		`{"ok":true,"entries":[{"id":"E2","date_create":1000},{"id":"E1","date_create":900}]}`,
+		`{"ok":true,"entries":[{"id":"E4","date_create":1100},{"id":"E2","date_create":1000},{"id":"E3","date_create":990}]}`,
+	}
+	var oldests []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/audit/v1/logs", func(w http.ResponseWriter, r *http.Request) {
+		oldests = append(oldests, r.URL.Query().Get("oldest"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(pages[len(oldests)-1]))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	tailer := NewAuditLogTailer(api, AuditLogCheckpoint{Oldest: 800})
+	tailer.Overlap = 30 * time.Second
+
+	var checkpoints []AuditLogCheckpoint
+	tailer.OnCheckpoint = func(ctx context.Context, checkpoint AuditLogCheckpoint) error {
+		checkpoints = append(checkpoints, checkpoint)
+		return nil
+	}
+
+	entries := make(chan AuditEntry, 10)
+	for i := 0; i < 2; i++ {
+		if err := tailer.Poll(context.Background(), entries); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	close(entries)
+
+	var ids []string
+	for entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+
+	if expected := []string{"E1", "E2", "E3", "E4"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected entries %v, got %v", expected, ids)
+	}
+	if expected := []string{"770", "970"}; !reflect.DeepEqual(oldests, expected) {
+		t.Errorf("expected oldest %v, got %v", expected, oldests)
+	}
+
+	expected := []AuditLogCheckpoint{
+		{Oldest: 1000, SeenIDs: []string{"E2"}},
+		{Oldest: 1100, SeenIDs: []string{"E4"}},
+	}
+	if !reflect.DeepEqual(checkpoints, expected) {
+		t.Errorf("expected checkpoints %+v, got %+v", expected, checkpoints)
+	}
+}
+
+func TestAuditLogTailerRun(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/audit/v1/logs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"entries":[{"id":"E1","date_create":1000}]}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	tailer := NewAuditLogTailer(api, AuditLogCheckpoint{Oldest: 1000})
+	tailer.Interval = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	entries := make(chan AuditEntry)
+	done := make(chan error)
+	go func() { done <- tailer.Run(ctx, entries) }()
+
+	if entry := <-entries; entry.ID != "E1" {
+		t.Errorf("expected E1, got %s", entry.ID)
+	}
+
+	// E1 is deduplicated by the next polls.
+	select {
+	case entry := <-entries:
+		t.Errorf("unexpected entry %s", entry.ID)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -2,6 +2,8 @@ package slack
 
 import (
 	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 )
 
@@ -83,3 +85,67 @@ func TestGetAuditLogs(t *testing.T) {
 		t.Fatal(ErrIncorrectResponse)
 	}
 }
+
+func TestIterateAuditLogs(t *testing.T) {
+	var requests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/audit/v1/logs", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("oldest") != "1500000000" {
+			t.Errorf("unexpected oldest %q", r.URL.Query().Get("oldest"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case requests == 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case r.URL.Query().Get("cursor") == "":
+			w.Write([]byte(`{"ok":true,"entries":[{"id":"E2","date_create":1500000002}],"response_metadata":{"next_cursor":"page2"}}`))
+		case r.URL.Query().Get("cursor") == "page2":
+			w.Write([]byte(`{"ok":true,"entries":[{"id":"E1","date_create":1500000001}]}`))
+		}
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	var ids []string
+	err := api.IterateAuditLogs(AuditLogParameters{Oldest: 1500000000}, func(entry AuditEntry) error {
+		ids = append(ids, entry.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !reflect.DeepEqual(ids, []string{"E2", "E1"}) || requests != 3 {
+		t.Errorf("unexpected entries %v after %d requests", ids, requests)
+	}
+}
+
+func TestGetAuditSchemas(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/audit/v1/schemas", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"schemas":[{"type":"workspace","workspace":{"id":"","name":"","domain":""}},{"type":"user","user":{"id":"","email":""}}]}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	schemas, err := api.GetAuditSchemas()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []AuditSchema{
+		{Type: "workspace", Fields: map[string]interface{}{"id": "", "name": "", "domain": ""}},
+		{Type: "user", Fields: map[string]interface{}{"id": "", "email": ""}},
+	}
+	if !reflect.DeepEqual(schemas, expected) {
+		t.Errorf("expected %+v, got %+v", expected, schemas)
+	}
+}
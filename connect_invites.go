@@ -0,0 +1,401 @@
+package slack
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// ConnectInviteTeam is a team taking part in a Slack Connect invite.
+type ConnectInviteTeam struct {
+	ID                  string `json:"id"`
+	Name                string `json:"name"`
+	Domain              string `json:"domain"`
+	IsVerified          bool   `json:"is_verified"`
+	DateCreated         int64  `json:"date_created,omitempty"`
+	RequiresSponsorship bool   `json:"requires_sponsorship,omitempty"`
+}
+
+// ConnectInviteUser is a user taking part in a Slack Connect invite.
+type ConnectInviteUser struct {
+	ID      string       `json:"id"`
+	TeamID  string       `json:"team_id"`
+	Name    string       `json:"name"`
+	Updated int64        `json:"updated,omitempty"`
+	Profile *UserProfile `json:"profile,omitempty"`
+}
+
+// ConnectInviteChannel is the channel a Slack Connect invite is for.
+type ConnectInviteChannel struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	IsPrivate bool   `json:"is_private"`
+	IsIM      bool   `json:"is_im"`
+}
+
+// ConnectInviteDetails is the invitation of a Slack Connect invite.
+type ConnectInviteDetails struct {
+	ID              string             `json:"id"`
+	DateCreated     int64              `json:"date_created"`
+	DateInvalid     int64              `json:"date_invalid"`
+	InvitingTeam    *ConnectInviteTeam `json:"inviting_team,omitempty"`
+	InvitingUser    *ConnectInviteUser `json:"inviting_user,omitempty"`
+	RecipientEmail  string             `json:"recipient_email,omitempty"`
+	RecipientUserID string             `json:"recipient_user_id,omitempty"`
+	Link            string             `json:"link,omitempty"`
+}
+
+// ConnectInviteReview is the review of an acceptance by a team.
+type ConnectInviteReview struct {
+	Type          string             `json:"type"`
+	DateReview    int64              `json:"date_review"`
+	ReviewingTeam *ConnectInviteTeam `json:"reviewing_team,omitempty"`
+}
+
+// ConnectInviteAcceptance is the acceptance of a Slack Connect invite by a team.
+type ConnectInviteAcceptance struct {
+	// ApprovalStatus is e.g. "pending_approval", "approved" or "declined".
+	ApprovalStatus  string                `json:"approval_status"`
+	DateAccepted    int64                 `json:"date_accepted"`
+	DateInvalid     int64                 `json:"date_invalid"`
+	DateLastUpdated int64                 `json:"date_last_updated"`
+	AcceptingTeam   *ConnectInviteTeam    `json:"accepting_team,omitempty"`
+	AcceptingUser   *ConnectInviteUser    `json:"accepting_user,omitempty"`
+	Reviews         []ConnectInviteReview `json:"reviews,omitempty"`
+}
+
+// ConnectInvite is a Slack Connect invite, as returned by ListConnectInvites.
+type ConnectInvite struct {
+	// Direction is "incoming" for the invites received by the team, "outgoing" otherwise.
+	Direction       string                    `json:"direction"`
+	Status          string                    `json:"status"`
+	DateLastUpdated int64                     `json:"date_last_updated"`
+	InviteType      string                    `json:"invite_type"`
+	Channel         ConnectInviteChannel      `json:"channel"`
+	Invite          ConnectInviteDetails      `json:"invite"`
+	Acceptances     []ConnectInviteAcceptance `json:"acceptances,omitempty"`
+}
+
+// PendingAcceptance reports whether the invite was received by the team and not accepted yet.
+func (i ConnectInvite) PendingAcceptance() bool {
+	return i.Direction == "incoming" && len(i.Acceptances) == 0 && i.Status != "revoked"
+}
+
+// AcceptSharedInviteParams defines the parameters for the AcceptSharedInvite and AcceptSharedInviteContext functions.
+type AcceptSharedInviteParams struct {
+	// ChannelName is the name of the channel in the team, required.
+	ChannelName string
+	// Either InviteID or ChannelID identifies the invite.
+	InviteID          string
+	ChannelID         string
+	FreeTrialAccepted bool
+	IsPrivate         bool
+	TeamID            string
+}
+
+// AcceptSharedInviteResponse is the response of AcceptSharedInvite.
+type AcceptSharedInviteResponse struct {
+	// ImplicitApproval is true when the acceptance did not require an approval.
+	ImplicitApproval bool   `json:"implicit_approval"`
+	ChannelID        string `json:"channel_id"`
+	InviteID         string `json:"invite_id"`
+}
+
+// AcceptSharedInvite accepts an invitation to a Slack Connect channel.
+// For more details, see AcceptSharedInviteContext documentation.
+func (api *Client) AcceptSharedInvite(params AcceptSharedInviteParams) (*AcceptSharedInviteResponse, error) {
+	return api.AcceptSharedInviteContext(context.Background(), params)
+}
+
+// AcceptSharedInviteContext accepts an invitation to a Slack Connect channel with a custom context.
+// Slack API docs: https://api.slack.com/methods/conversations.acceptSharedInvite
+func (api *Client) AcceptSharedInviteContext(ctx context.Context, params AcceptSharedInviteParams) (*AcceptSharedInviteResponse, error) {
+	values := url.Values{
+		"token":        {api.token},
+		"channel_name": {params.ChannelName},
+	}
+	if params.InviteID != "" {
+		values.Add("invite_id", params.InviteID)
+	}
+	if params.ChannelID != "" {
+		values.Add("channel_id", params.ChannelID)
+	}
+	if params.FreeTrialAccepted {
+		values.Add("free_trial_accepted", "true")
+	}
+	if params.IsPrivate {
+		values.Add("is_private", "true")
+	}
+	if params.TeamID != "" {
+		values.Add("team_id", params.TeamID)
+	}
+
+	response := struct {
+		SlackResponse
+		AcceptSharedInviteResponse
+	}{}
+
+	err := api.postMethod(ctx, "conversations.acceptSharedInvite", values, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.AcceptSharedInviteResponse, response.Err()
+}
+
+// ApproveSharedInvite approves an invitation to a Slack Connect channel.
+// For more details, see ApproveSharedInviteContext documentation.
+func (api *Client) ApproveSharedInvite(inviteID string, targetTeam string) error {
+	return api.ApproveSharedInviteContext(context.Background(), inviteID, targetTeam)
+}
+
+// ApproveSharedInviteContext approves an invitation to a Slack Connect channel with a custom
+// context. targetTeam, when not empty, is the team whose acceptance is approved.
+// Slack API docs: https://api.slack.com/methods/conversations.approveSharedInvite
+func (api *Client) ApproveSharedInviteContext(ctx context.Context, inviteID string, targetTeam string) error {
+	return api.reviewSharedInvite(ctx, "conversations.approveSharedInvite", inviteID, targetTeam)
+}
+
+// DeclineSharedInvite declines a Slack Connect channel invite.
+// For more details, see DeclineSharedInviteContext documentation.
+func (api *Client) DeclineSharedInvite(inviteID string, targetTeam string) error {
+	return api.DeclineSharedInviteContext(context.Background(), inviteID, targetTeam)
+}
+
+// DeclineSharedInviteContext declines a Slack Connect channel invite with a custom context.
+// targetTeam, when not empty, is the team whose acceptance is declined.
+// Slack API docs: https://api.slack.com/methods/conversations.declineSharedInvite
+func (api *Client) DeclineSharedInviteContext(ctx context.Context, inviteID string, targetTeam string) error {
+	return api.reviewSharedInvite(ctx, "conversations.declineSharedInvite", inviteID, targetTeam)
+}
+
+func (api *Client) reviewSharedInvite(ctx context.Context, method string, inviteID string, targetTeam string) error {
+	values := url.Values{
+		"token":     {api.token},
+		"invite_id": {inviteID},
+	}
+	if targetTeam != "" {
+		values.Add("target_team", targetTeam)
+	}
+
+	response := SlackResponse{}
+	err := api.postMethod(ctx, method, values, &response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// ListConnectInvitesParams defines the parameters for the ListConnectInvites and ListConnectInvitesContext functions.
+type ListConnectInvitesParams struct {
+	Count  int
+	Cursor string
+	TeamID string
+}
+
+// ListConnectInvites lists the shared channel invites that have been generated or received but have not been approved by all parties.
+// For more details, see ListConnectInvitesContext documentation.
+func (api *Client) ListConnectInvites(params ListConnectInvitesParams) ([]ConnectInvite, string, error) {
+	return api.ListConnectInvitesContext(context.Background(), params)
+}
+
+// ListConnectInvitesContext lists the shared channel invites that have been generated or received
+// but have not been approved by all parties with a custom context. It returns the cursor of the
+// next page, if any.
+// Slack API docs: https://api.slack.com/methods/conversations.listConnectInvites
+func (api *Client) ListConnectInvitesContext(ctx context.Context, params ListConnectInvitesParams) ([]ConnectInvite, string, error) {
+	values := url.Values{
+		"token": {api.token},
+	}
+	if params.Count != 0 {
+		values.Add("count", strconv.Itoa(params.Count))
+	}
+	if params.Cursor != "" {
+		values.Add("cursor", params.Cursor)
+	}
+	if params.TeamID != "" {
+		values.Add("team_id", params.TeamID)
+	}
+
+	response := struct {
+		Invites []ConnectInvite `json:"invites"`
+		SlackResponse
+	}{}
+
+	err := api.postMethod(ctx, "conversations.listConnectInvites", values, &response)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response.Invites, response.ResponseMetadata.Cursor, response.Err()
+}
+
+// ExternalInvitePermissionsAction is the action of SetExternalInvitePermissions.
+type ExternalInvitePermissionsAction string
+
+const (
+	// ExternalInvitePermissionsUpgrade allows the members of the external team to invite others.
+	ExternalInvitePermissionsUpgrade ExternalInvitePermissionsAction = "upgrade"
+	// ExternalInvitePermissionsDowngrade limits the external team to posting messages.
+	ExternalInvitePermissionsDowngrade ExternalInvitePermissionsAction = "downgrade"
+)
+
+// SetExternalInvitePermissions upgrades or downgrades the permissions of an external team in a Slack Connect channel.
+// For more details, see SetExternalInvitePermissionsContext documentation.
+func (api *Client) SetExternalInvitePermissions(action ExternalInvitePermissionsAction, channelID string, targetTeam string) error {
+	return api.SetExternalInvitePermissionsContext(context.Background(), action, channelID, targetTeam)
+}
+
+// SetExternalInvitePermissionsContext upgrades or downgrades the permissions of an external team
+// in a Slack Connect channel with a custom context.
+// Slack API docs: https://api.slack.com/methods/conversations.externalInvitePermissions.set
+func (api *Client) SetExternalInvitePermissionsContext(ctx context.Context, action ExternalInvitePermissionsAction, channelID string, targetTeam string) error {
+	values := url.Values{
+		"token":       {api.token},
+		"action":      {string(action)},
+		"channel":     {channelID},
+		"target_team": {targetTeam},
+	}
+
+	response := SlackResponse{}
+	err := api.postMethod(ctx, "conversations.externalInvitePermissions.set", values, &response)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// ConnectInviteAction is the action a ConnectInvitePolicy decides on.
+type ConnectInviteAction string
+
+const (
+	// ConnectInviteSkip leaves the invite pending.
+	ConnectInviteSkip ConnectInviteAction = ""
+	// ConnectInviteAccept accepts an incoming invite.
+	ConnectInviteAccept ConnectInviteAction = "accept"
+	// ConnectInviteApprove approves an acceptance.
+	ConnectInviteApprove ConnectInviteAction = "approve"
+	// ConnectInviteDecline declines an incoming invite, or an acceptance.
+	ConnectInviteDecline ConnectInviteAction = "decline"
+)
+
+// ConnectInviteDecision is the decision of a ConnectInvitePolicy.
+type ConnectInviteDecision struct {
+	Action ConnectInviteAction
+	// ChannelName, IsPrivate and TeamID set the channel of the accepted invites. ChannelName
+	// defaults to the name of the invite's channel.
+	ChannelName string
+	IsPrivate   bool
+	TeamID      string
+}
+
+// ConnectInvitePolicy decides what to do with a pending Slack Connect invite: acceptance is
+// nil for an incoming invite waiting to be accepted, and is otherwise the acceptance of a team
+// waiting for an approval.
+type ConnectInvitePolicy func(ctx context.Context, invite ConnectInvite, acceptance *ConnectInviteAcceptance) (ConnectInviteDecision, error)
+
+// ConnectInviteResult is the outcome of the decision of a ConnectInvitePolicy.
+type ConnectInviteResult struct {
+	Invite     ConnectInvite
+	Acceptance *ConnectInviteAcceptance
+	Decision   ConnectInviteDecision
+	// Err is the error of the policy or of the API call carrying out the decision.
+	Err error
+}
+
+// ProcessConnectInvites processes the pending Slack Connect invites according to a policy.
+// For more details, see ProcessConnectInvitesContext documentation.
+func (api *Client) ProcessConnectInvites(params ListConnectInvitesParams, policy ConnectInvitePolicy) ([]ConnectInviteResult, error) {
+	return api.ProcessConnectInvitesContext(context.Background(), params, policy)
+}
+
+// ProcessConnectInvitesContext lists the Slack Connect invites from params.Cursor, and asks the
+// policy what to do with the incoming invites waiting to be accepted and with the acceptances
+// waiting for an approval. The decisions are carried out right away. It returns the results of
+// the decisions other than ConnectInviteSkip, and the errors of the listing: the errors of the
+// policy and of the decisions are reported in the results.
+func (api *Client) ProcessConnectInvitesContext(ctx context.Context, params ListConnectInvitesParams, policy ConnectInvitePolicy) ([]ConnectInviteResult, error) {
+	var results []ConnectInviteResult
+	for {
+		invites, cursor, err := api.ListConnectInvitesContext(ctx, params)
+		if err != nil {
+			return results, err
+		}
+
+		for _, invite := range invites {
+			if invite.PendingAcceptance() {
+				if result, ok := api.processConnectInvite(ctx, policy, invite, nil); ok {
+					results = append(results, result)
+				}
+				continue
+			}
+
+			for i := range invite.Acceptances {
+				if invite.Acceptances[i].ApprovalStatus != "pending_approval" {
+					continue
+				}
+				if result, ok := api.processConnectInvite(ctx, policy, invite, &invite.Acceptances[i]); ok {
+					results = append(results, result)
+				}
+			}
+		}
+
+		if cursor == "" {
+			return results, nil
+		}
+		params.Cursor = cursor
+	}
+}
+
+// processConnectInvite asks the policy what to do with an invite, and does it. It returns
+// false when the invite is skipped.
+func (api *Client) processConnectInvite(ctx context.Context, policy ConnectInvitePolicy, invite ConnectInvite, acceptance *ConnectInviteAcceptance) (ConnectInviteResult, bool) {
+	result := ConnectInviteResult{Invite: invite, Acceptance: acceptance}
+	result.Decision, result.Err = policy(ctx, invite, acceptance)
+	if result.Err != nil {
+		return result, true
+	}
+
+	var targetTeam string
+	if acceptance != nil && acceptance.AcceptingTeam != nil {
+		targetTeam = acceptance.AcceptingTeam.ID
+	}
+
+	switch result.Decision.Action {
+	case ConnectInviteSkip:
+		return result, false
+	case ConnectInviteAccept:
+		if acceptance != nil {
+			// Only the incoming invites are accepted.
+			result.Err = ErrInvalidConfiguration
+			break
+		}
+
+		name := result.Decision.ChannelName
+		if name == "" {
+			name = invite.Channel.Name
+		}
+		_, result.Err = api.AcceptSharedInviteContext(ctx, AcceptSharedInviteParams{
+			ChannelName: name,
+			InviteID:    invite.Invite.ID,
+			IsPrivate:   result.Decision.IsPrivate,
+			TeamID:      result.Decision.TeamID,
+		})
+	case ConnectInviteApprove:
+		if acceptance == nil {
+			// Only the acceptances are approved.
+			result.Err = ErrInvalidConfiguration
+			break
+		}
+
+		result.Err = api.ApproveSharedInviteContext(ctx, invite.Invite.ID, targetTeam)
+	case ConnectInviteDecline:
+		result.Err = api.DeclineSharedInviteContext(ctx, invite.Invite.ID, targetTeam)
+	default:
+		result.Err = ErrInvalidConfiguration
+	}
+
+	return result, true
+}
@@ -0,0 +1,121 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const testConnectInvites = `{
+	"ok": true,
+	"invites": [
+		{
+			"direction": "incoming",
+			"status": "pending",
+			"invite_type": "channel",
+			"channel": {"id": "C1", "name": "partner-ops", "is_private": false},
+			"invite": {"id": "I1", "inviting_team": {"id": "T9", "name": "Partner", "domain": "partner", "is_verified": true}}
+		},
+		{
+			"direction": "outgoing",
+			"status": "accepted",
+			"invite_type": "channel",
+			"channel": {"id": "C2", "name": "vendors"},
+			"invite": {"id": "I2"},
+			"acceptances": [
+				{"approval_status": "pending_approval", "accepting_team": {"id": "T7", "name": "Unknown", "is_verified": false}},
+				{"approval_status": "approved", "accepting_team": {"id": "T8"}}
+			]
+		}
+	],
+	"response_metadata": {"next_cursor": "next"}
+}`
+
+func TestProcessConnectInvites(t *testing.T) {
+	var calls []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/conversations.listConnectInvites", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.FormValue("cursor") == "" {
+			w.Write([]byte(testConnectInvites))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"invites":[{"direction":"incoming","status":"pending","channel":{"name":"spam"},"invite":{"id":"I3"}}]}`))
+	})
+	for _, method := range []string{"acceptSharedInvite", "approveSharedInvite", "declineSharedInvite"} {
+		method := method
+		mux.HandleFunc("/conversations."+method, func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			delete(r.Form, "token")
+			calls = append(calls, method+" "+r.Form.Encode())
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"ok":true,"implicit_approval":true,"channel_id":"C1","invite_id":"I1"}`))
+		})
+	}
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+	policyErr := errors.New("no decision")
+	policy := func(ctx context.Context, invite ConnectInvite, acceptance *ConnectInviteAcceptance) (ConnectInviteDecision, error) {
+		switch {
+		case invite.Invite.ID == "I3":
+			return ConnectInviteDecision{}, policyErr
+		case acceptance == nil && invite.Invite.InvitingTeam.IsVerified:
+			return ConnectInviteDecision{Action: ConnectInviteAccept, ChannelName: "ext-" + invite.Channel.Name}, nil
+		case acceptance != nil && !acceptance.AcceptingTeam.IsVerified:
+			return ConnectInviteDecision{Action: ConnectInviteDecline}, nil
+		}
+		return ConnectInviteDecision{Action: ConnectInviteSkip}, nil
+	}
+
+	results, err := api.ProcessConnectInvites(ListConnectInvitesParams{}, policy)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := []string{
+		"acceptSharedInvite channel_name=ext-partner-ops&invite_id=I1",
+		"declineSharedInvite invite_id=I2&target_team=T7",
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected %v, got %v", expected, calls)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	if results[1].Acceptance == nil || results[1].Acceptance.AcceptingTeam.ID != "T7" || results[1].Err != nil {
+		t.Errorf("Unexpected result %+v", results[1])
+	}
+	if results[2].Invite.Invite.ID != "I3" || results[2].Err != policyErr {
+		t.Errorf("Expected the error of the policy, got %+v", results[2])
+	}
+}
+
+func TestSetExternalInvitePermissions(t *testing.T) {
+	var form string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/conversations.externalInvitePermissions.set", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		delete(r.Form, "token")
+		form = r.Form.Encode()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+	if err := api.SetExternalInvitePermissions(ExternalInvitePermissionsDowngrade, "C1", "T9"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if form != "action=downgrade&channel=C1&target_team=T9" {
+		t.Errorf("Unexpected request %s", form)
+	}
+}
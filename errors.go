@@ -13,6 +13,8 @@ const (
 	ErrInvalidConfiguration = errorsx.String("invalid configuration")
 	ErrMissingHeaders       = errorsx.String("missing headers")
 	ErrExpiredTimestamp     = errorsx.String("timestamp is too old")
+	ErrInvalidSignature     = errorsx.String("signature does not match any signing secret")
+	ErrReplayedRequest      = errorsx.String("request was already received")
 )
 
 // internal errors
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+func main() {
+	botToken := os.Getenv("SLACK_BOT_TOKEN")
+	signingSecret := os.Getenv("SLACK_SIGNING_SECRET")
+
+	api := slack.New(botToken)
+
+	handler := slackevents.NewEventsHandler(signingSecret,
+		slackevents.EventsHandlerOptionDeduplicator(slackevents.NewDeduplicator(nil)),
+		slackevents.EventsHandlerOptionErrorHandler(func(r *http.Request, err error) {
+			fmt.Println("[ERROR] Rejected request:", err)
+		}),
+	)
+	handler.HandleEvent(func(ctx context.Context, ev *slackevents.AppMentionEvent) {
+		api.PostMessageContext(ctx, ev.Channel, slack.MsgOptionText("Yes, hello.", false))
+	})
+
+	http.Handle("/events-endpoint", handler)
+	fmt.Println("[INFO] Server listening")
+	http.ListenAndServe(":3000", nil)
+}
@@ -9,6 +9,9 @@ type (
 	FunctionCompleteSuccessRequest struct {
 		FunctionExecutionID string            `json:"function_execution_id"`
 		Outputs             map[string]string `json:"outputs"`
+
+		// outputValues, when set, replaces Outputs.
+		outputValues json.RawMessage
 	}
 
 	FunctionCompleteErrorRequest struct {
@@ -28,6 +31,31 @@ func FunctionCompleteSuccessRequestOptionOutput(outputs map[string]string) Funct
 	}
 }
 
+// FunctionCompleteSuccessRequestOptionOutputValues sets outputs of any type: a struct whose
+// json tags name the output parameters, or a map, e.g. for outputs that are not strings.
+func FunctionCompleteSuccessRequestOptionOutputValues(outputs interface{}) FunctionCompleteSuccessRequestOption {
+	return func(opt *FunctionCompleteSuccessRequest) error {
+		b, err := json.Marshal(outputs)
+		if err != nil {
+			return err
+		}
+		opt.outputValues = b
+		return nil
+	}
+}
+
+func (r FunctionCompleteSuccessRequest) MarshalJSON() ([]byte, error) {
+	type alias FunctionCompleteSuccessRequest
+	if r.outputValues == nil {
+		return json.Marshal(alias(r))
+	}
+
+	return json.Marshal(struct {
+		FunctionExecutionID string          `json:"function_execution_id"`
+		Outputs             json.RawMessage `json:"outputs"`
+	}{r.FunctionExecutionID, r.outputValues})
+}
+
 // FunctionCompleteSuccess indicates function is completed
 func (api *Client) FunctionCompleteSuccess(functionExecutionId string, options ...FunctionCompleteSuccessRequestOption) error {
 	return api.FunctionCompleteSuccessContext(context.Background(), functionExecutionId, options...)
@@ -40,7 +68,9 @@ func (api *Client) FunctionCompleteSuccessContext(ctx context.Context, functionE
 		FunctionExecutionID: functionExecutionId,
 	}
 	for _, option := range options {
-		option(r)
+		if err := option(r); err != nil {
+			return err
+		}
 	}
 
 	endpoint := api.endpoint + "functions.completeSuccess"
@@ -78,3 +78,24 @@ func TestFunctionComplete(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestFunctionCompleteSuccessRequestOptionOutputValues(t *testing.T) {
+	r := &FunctionCompleteSuccessRequest{FunctionExecutionID: "Fx1"}
+	opt := FunctionCompleteSuccessRequestOptionOutputValues(struct {
+		Approved bool     `json:"approved"`
+		Users    []string `json:"users"`
+	}{true, []string{"U1"}})
+	if err := opt(r); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"function_execution_id":"Fx1","outputs":{"approved":true,"users":["U1"]}}`
+	if string(b) != expected {
+		t.Errorf("Expected %s, got %s", expected, b)
+	}
+}
@@ -0,0 +1,125 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// The Web API errors meaning that a token cannot be used anymore.
+var tokenRevokedErrors = map[string]bool{
+	"invalid_auth":     true,
+	"account_inactive": true,
+	"token_revoked":    true,
+}
+
+// IsTokenRevokedError reports whether err is a Web API error meaning that the token of the
+// request cannot be used anymore: invalid_auth, account_inactive or token_revoked.
+func IsTokenRevokedError(err error) bool {
+	var serr SlackErrorResponse
+	return errors.As(err, &serr) && tokenRevokedErrors[serr.Err]
+}
+
+// OptionAuthErrorHandler sets a callback for the Web API responses meaning that the token
+// of the client cannot be used anymore, with the error code of the response.
+func OptionAuthErrorHandler(f func(code string)) func(*Client) {
+	return func(c *Client) {
+		c.onAuthError = f
+	}
+}
+
+// authErrorWatcher is an httpClient reporting the token revocation errors of the Web API.
+type authErrorWatcher struct {
+	client      httpClient
+	onAuthError func(code string)
+}
+
+func (w *authErrorWatcher) Do(req *http.Request) (*http.Response, error) {
+	resp, err := w.client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "application/json" {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var r SlackResponse
+	if json.Unmarshal(body, &r) == nil && !r.Ok && tokenRevokedErrors[r.Error] {
+		w.onAuthError(r.Error)
+	}
+
+	return resp, nil
+}
+
+// NewInstallationClient returns a client using the bot token of an installation, or its user
+// token when it has no bot token. As soon as the Web API reports that the token cannot be used
+// anymore, the installation is deleted from store: every installation of the workspace or
+// organization for a bot token, only the installation of the user for a user token, which is
+// also cleared from the latest installation when the user installed it.
+func NewInstallationClient(store InstallationStore, inst *Installation, options ...Option) *Client {
+	token, userID := inst.BotToken, ""
+	if token == "" {
+		token, userID = inst.UserToken, inst.UserID
+	}
+
+	var client *Client
+	enterpriseID, teamID := inst.EnterpriseID, inst.TeamID
+	options = append(options, OptionAuthErrorHandler(func(code string) {
+		if err := deleteInstallation(context.Background(), store, enterpriseID, teamID, userID); err != nil {
+			client.log.Printf("failed to delete the installation of team %q (enterprise %q) after %s: %v", teamID, enterpriseID, code, err)
+		}
+	}))
+
+	client = New(token, options...)
+	return client
+}
+
+// DeleteInstallationOnAuthError deletes an installation from store when err means that its
+// token cannot be used anymore, see IsTokenRevokedError. userID is empty for a bot token. It
+// reports whether the installation was deleted. The user token is also cleared from the latest
+// installation when the user installed it.
+func DeleteInstallationOnAuthError(ctx context.Context, store InstallationStore, enterpriseID, teamID, userID string, err error) (bool, error) {
+	if !IsTokenRevokedError(err) {
+		return false, nil
+	}
+
+	if err := deleteInstallation(ctx, store, enterpriseID, teamID, userID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// deleteInstallation deletes the installation of a user, or every installation of the workspace
+// or organization when userID is empty. The latest installation also holds the user token of its
+// installing user, so it is saved again without it.
+func deleteInstallation(ctx context.Context, store InstallationStore, enterpriseID, teamID, userID string) error {
+	if userID != "" {
+		latest, err := store.Find(ctx, enterpriseID, teamID, "")
+		if err != nil && !errors.Is(err, ErrInstallationNotFound) {
+			return err
+		}
+
+		// Saving the latest installation also saves the installation of the user, deleted below.
+		if err == nil && latest.UserID == userID && latest.UserToken != "" {
+			latest.clearUserToken()
+			if err := store.Save(ctx, latest); err != nil {
+				return err
+			}
+		}
+	}
+
+	return store.Delete(ctx, enterpriseID, teamID, userID)
+}
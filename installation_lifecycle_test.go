@@ -0,0 +1,135 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// rewriteTransport sends every request to a test server, whatever its URL.
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestExchangeOAuthV2Token(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/api/oauth.v2.exchange" || r.FormValue("token") != "xoxb-legacy" {
+			w.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"access_token":"xoxe.xoxb-1","token_type":"bot","refresh_token":"xoxe-1","expires_in":43200}`))
+	}))
+	defer ts.Close()
+
+	target, _ := url.Parse(ts.URL)
+	client := &http.Client{Transport: rewriteTransport{target: target}}
+
+	resp, err := ExchangeOAuthV2Token(client, "client", "secret", "xoxb-legacy")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if resp.AccessToken != "xoxe.xoxb-1" || resp.RefreshToken != "xoxe-1" || resp.ExpiresIn != 43200 {
+		t.Errorf("Unexpected response %+v", resp)
+	}
+
+	_, err = ExchangeOAuthV2Token(client, "client", "secret", "xoxb-other")
+	if !IsTokenRevokedError(err) {
+		t.Errorf("Expected a token revoked error, got %v", err)
+	}
+}
+
+func TestIsTokenRevokedError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{SlackErrorResponse{Err: "invalid_auth"}, true},
+		{SlackErrorResponse{Err: "account_inactive"}, true},
+		{SlackErrorResponse{Err: "channel_not_found"}, false},
+		{&RateLimitedError{}, false},
+		{nil, false},
+	}
+
+	for _, test := range tests {
+		if got := IsTokenRevokedError(test.err); got != test.want {
+			t.Errorf("IsTokenRevokedError(%v): expected %t, got %t", test.err, test.want, got)
+		}
+	}
+}
+
+func TestNewInstallationClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.FormValue("token") == "xoxb-revoked" {
+			w.Write([]byte(`{"ok":false,"error":"account_inactive"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"user_id":"U1","team_id":"T1"}`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	store := NewMemoryInstallationStore()
+	valid := &Installation{TeamID: "T1", UserID: "U1", BotToken: "xoxb-valid"}
+	revoked := &Installation{TeamID: "T2", UserID: "U2", BotToken: "xoxb-revoked"}
+	store.Save(ctx, valid)
+	store.Save(ctx, revoked)
+
+	if _, err := NewInstallationClient(store, valid, OptionAPIURL(ts.URL+"/")).AuthTest(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, err := store.Find(ctx, "", "T1", ""); err != nil {
+		t.Errorf("Expected the valid installation to be kept, got %v", err)
+	}
+
+	_, err := NewInstallationClient(store, revoked, OptionAPIURL(ts.URL+"/")).AuthTest()
+	if !IsTokenRevokedError(err) {
+		t.Fatalf("Expected a token revoked error, got %v", err)
+	}
+	for _, userID := range []string{"", "U2"} {
+		if _, err := store.Find(ctx, "", "T2", userID); err != ErrInstallationNotFound {
+			t.Errorf("Expected the revoked installation to be deleted, got %v", err)
+		}
+	}
+}
+
+func TestNewInstallationClient_userToken(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":false,"error":"token_revoked"}`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	store := NewMemoryInstallationStore()
+	store.Save(ctx, &Installation{TeamID: "T1", UserID: "U1", UserToken: "xoxp-1"})
+	revoked := &Installation{TeamID: "T1", UserID: "U2", UserToken: "xoxp-2"}
+	store.Save(ctx, revoked)
+
+	if _, err := NewInstallationClient(store, revoked, OptionAPIURL(ts.URL+"/")).AuthTest(); !IsTokenRevokedError(err) {
+		t.Fatalf("Expected a token revoked error, got %v", err)
+	}
+
+	if _, err := store.Find(ctx, "", "T1", "U2"); err != ErrInstallationNotFound {
+		t.Errorf("Expected the installation of U2 to be deleted, got %v", err)
+	}
+	latest, err := store.Find(ctx, "", "T1", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if latest.UserToken != "" {
+		t.Errorf("Expected the revoked user token to be cleared from the latest installation, got %+v", latest)
+	}
+	if inst, err := store.Find(ctx, "", "T1", "U1"); err != nil || inst.UserToken != "xoxp-1" {
+		t.Errorf("Expected the installation of U1 to be kept, got %+v, %v", inst, err)
+	}
+}
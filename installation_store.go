@@ -0,0 +1,341 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack/internal/errorsx"
+)
+
+// ErrInstallationNotFound is returned by the InstallationStore implementations
+// when there is no installation for the given keys.
+const ErrInstallationNotFound = errorsx.String("installation not found")
+
+// Installation is an installation of an app in a workspace, or in an Enterprise Grid
+// organization, as obtained at the end of the OAuth v2 flow.
+type Installation struct {
+	AppID               string `json:"app_id"`
+	EnterpriseID        string `json:"enterprise_id,omitempty"`
+	EnterpriseName      string `json:"enterprise_name,omitempty"`
+	TeamID              string `json:"team_id,omitempty"`
+	TeamName            string `json:"team_name,omitempty"`
+	IsEnterpriseInstall bool   `json:"is_enterprise_install,omitempty"`
+
+	BotUserID          string    `json:"bot_user_id,omitempty"`
+	BotToken           string    `json:"bot_token,omitempty"`
+	BotScopes          []string  `json:"bot_scopes,omitempty"`
+	BotRefreshToken    string    `json:"bot_refresh_token,omitempty"`
+	BotTokenExpiresAt  time.Time `json:"bot_token_expires_at"`
+	UserID             string    `json:"user_id"`
+	UserToken          string    `json:"user_token,omitempty"`
+	UserScopes         []string  `json:"user_scopes,omitempty"`
+	UserRefreshToken   string    `json:"user_refresh_token,omitempty"`
+	UserTokenExpiresAt time.Time `json:"user_token_expires_at"`
+
+	IncomingWebhook OAuthResponseIncomingWebhook `json:"incoming_webhook"`
+	InstalledAt     time.Time                    `json:"installed_at"`
+}
+
+// NewInstallation returns the installation described by an oauth.v2.access response.
+func NewInstallation(resp *OAuthV2Response) *Installation {
+	now := time.Now()
+	inst := &Installation{
+		AppID:               resp.AppID,
+		EnterpriseID:        resp.Enterprise.ID,
+		EnterpriseName:      resp.Enterprise.Name,
+		TeamID:              resp.Team.ID,
+		TeamName:            resp.Team.Name,
+		IsEnterpriseInstall: resp.IsEnterpriseInstall,
+		UserID:              resp.AuthedUser.ID,
+		UserToken:           resp.AuthedUser.AccessToken,
+		UserScopes:          splitScopes(resp.AuthedUser.Scope),
+		UserRefreshToken:    resp.AuthedUser.RefreshToken,
+		UserTokenExpiresAt:  expiresAt(now, resp.AuthedUser.ExpiresIn),
+		IncomingWebhook:     resp.IncomingWebhook,
+		InstalledAt:         now,
+	}
+
+	// The top-level token is a bot token, unless only user scopes were requested.
+	if resp.TokenType == "bot" {
+		inst.BotUserID = resp.BotUserID
+		inst.BotToken = resp.AccessToken
+		inst.BotScopes = splitScopes(resp.Scope)
+		inst.BotRefreshToken = resp.RefreshToken
+		inst.BotTokenExpiresAt = expiresAt(now, resp.ExpiresIn)
+	}
+
+	return inst
+}
+
+func splitScopes(scope string) []string {
+	if scope == "" {
+		return nil
+	}
+	return strings.Split(scope, ",")
+}
+
+func expiresAt(now time.Time, expiresIn int) time.Time {
+	if expiresIn <= 0 {
+		return time.Time{}
+	}
+	return now.Add(time.Duration(expiresIn) * time.Second)
+}
+
+// InstallationStore persists the installations of an app.
+//
+// Installations are keyed by enterprise ID, team ID and user ID. The enterprise ID is empty
+// outside of Enterprise Grid, and the team ID is empty for organization-wide installations.
+// Saving an installation stores it both as the latest installation of its workspace or
+// organization, found with an empty user ID, and as the installation of its installing user.
+type InstallationStore interface {
+	Save(ctx context.Context, inst *Installation) error
+	// Find returns ErrInstallationNotFound when there is no installation for the keys.
+	Find(ctx context.Context, enterpriseID, teamID, userID string) (*Installation, error)
+	// Delete deletes the installation of a user, or every installation of the workspace
+	// or organization when userID is empty.
+	Delete(ctx context.Context, enterpriseID, teamID, userID string) error
+	// ClearBotToken removes the bot token from every installation of the workspace or
+	// organization, keeping their user tokens.
+	ClearBotToken(ctx context.Context, enterpriseID, teamID string) error
+}
+
+// clearBotToken removes the bot token of inst.
+func (inst *Installation) clearBotToken() {
+	inst.BotToken, inst.BotRefreshToken, inst.BotScopes = "", "", nil
+	inst.BotTokenExpiresAt = time.Time{}
+}
+
+// clearUserToken removes the user token of inst.
+func (inst *Installation) clearUserToken() {
+	inst.UserToken, inst.UserRefreshToken, inst.UserScopes = "", "", nil
+	inst.UserTokenExpiresAt = time.Time{}
+}
+
+type installationKey struct {
+	enterpriseID, teamID, userID string
+}
+
+// MemoryInstallationStore is an InstallationStore keeping the installations in memory.
+type MemoryInstallationStore struct {
+	mu            sync.RWMutex
+	installations map[installationKey]Installation
+}
+
+// NewMemoryInstallationStore returns an empty MemoryInstallationStore.
+func NewMemoryInstallationStore() *MemoryInstallationStore {
+	return &MemoryInstallationStore{
+		installations: make(map[installationKey]Installation),
+	}
+}
+
+// Save implements InstallationStore.
+func (s *MemoryInstallationStore) Save(ctx context.Context, inst *Installation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.installations[installationKey{inst.EnterpriseID, inst.TeamID, ""}] = *inst
+	if inst.UserID != "" {
+		s.installations[installationKey{inst.EnterpriseID, inst.TeamID, inst.UserID}] = *inst
+	}
+
+	return nil
+}
+
+// Find implements InstallationStore.
+func (s *MemoryInstallationStore) Find(ctx context.Context, enterpriseID, teamID, userID string) (*Installation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	inst, ok := s.installations[installationKey{enterpriseID, teamID, userID}]
+	if !ok {
+		return nil, ErrInstallationNotFound
+	}
+
+	return &inst, nil
+}
+
+// Delete implements InstallationStore.
+func (s *MemoryInstallationStore) Delete(ctx context.Context, enterpriseID, teamID, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range s.installations {
+		if key.enterpriseID == enterpriseID && key.teamID == teamID && (userID == "" || key.userID == userID) {
+			delete(s.installations, key)
+		}
+	}
+
+	return nil
+}
+
+// ClearBotToken implements InstallationStore.
+func (s *MemoryInstallationStore) ClearBotToken(ctx context.Context, enterpriseID, teamID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, inst := range s.installations {
+		if key.enterpriseID == enterpriseID && key.teamID == teamID {
+			inst.clearBotToken()
+			s.installations[key] = inst
+		}
+	}
+
+	return nil
+}
+
+// FileInstallationStore is an InstallationStore keeping each installation in a JSON file,
+// under a directory per workspace or organization. The files hold tokens and are only
+// readable by their owner.
+type FileInstallationStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileInstallationStore returns a FileInstallationStore keeping the installations under dir.
+// The directory is created when needed.
+func NewFileInstallationStore(dir string) *FileInstallationStore {
+	return &FileInstallationStore{dir: dir}
+}
+
+func (s *FileInstallationStore) teamDir(enterpriseID, teamID string) string {
+	if enterpriseID == "" {
+		enterpriseID = "none"
+	}
+	if teamID == "" {
+		teamID = "none"
+	}
+	return filepath.Join(s.dir, filepath.Base(enterpriseID)+"-"+filepath.Base(teamID))
+}
+
+func installationFileName(userID string) string {
+	if userID == "" {
+		return "installer-latest.json"
+	}
+	return "installer-" + filepath.Base(userID) + ".json"
+}
+
+// Save implements InstallationStore.
+func (s *FileInstallationStore) Save(ctx context.Context, inst *Installation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := json.Marshal(inst)
+	if err != nil {
+		return err
+	}
+
+	dir := s.teamDir(inst.EnterpriseID, inst.TeamID)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	names := []string{installationFileName("")}
+	if inst.UserID != "" {
+		names = append(names, installationFileName(inst.UserID))
+	}
+
+	for _, name := range names {
+		if err := writeFileAtomic(filepath.Join(dir, name), b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Find implements InstallationStore.
+func (s *FileInstallationStore) Find(ctx context.Context, enterpriseID, teamID, userID string) (*Installation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, err := os.ReadFile(filepath.Join(s.teamDir(enterpriseID, teamID), installationFileName(userID)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrInstallationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	inst := &Installation{}
+	if err := json.Unmarshal(b, inst); err != nil {
+		return nil, fmt.Errorf("invalid installation file: %w", err)
+	}
+
+	return inst, nil
+}
+
+// Delete implements InstallationStore.
+func (s *FileInstallationStore) Delete(ctx context.Context, enterpriseID, teamID, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := s.teamDir(enterpriseID, teamID)
+	if userID == "" {
+		return os.RemoveAll(dir)
+	}
+
+	if err := os.Remove(filepath.Join(dir, installationFileName(userID))); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// ClearBotToken implements InstallationStore.
+func (s *FileInstallationStore) ClearBotToken(ctx context.Context, enterpriseID, teamID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names, err := filepath.Glob(filepath.Join(s.teamDir(enterpriseID, teamID), "installer-*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+
+		inst := &Installation{}
+		if err := json.Unmarshal(b, inst); err != nil {
+			return fmt.Errorf("invalid installation file: %w", err)
+		}
+		inst.clearBotToken()
+
+		if b, err = json.Marshal(inst); err != nil {
+			return err
+		}
+		if err := writeFileAtomic(name, b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeFileAtomic writes a file readable by its owner only, without exposing partial contents.
+func writeFileAtomic(name string, b []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), name)
+}
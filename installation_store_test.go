@@ -0,0 +1,114 @@
+package slack
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testInstallationStore(t *testing.T, store InstallationStore) {
+	t.Helper()
+	ctx := context.Background()
+
+	first := &Installation{TeamID: "T1", UserID: "U1", BotToken: "xoxb-1", UserToken: "xoxp-1"}
+	second := &Installation{TeamID: "T1", UserID: "U2", BotToken: "xoxb-2", UserToken: "xoxp-2"}
+	for _, inst := range []*Installation{first, second} {
+		if err := store.Save(ctx, inst); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	latest, err := store.Find(ctx, "", "T1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if latest.BotToken != "xoxb-2" {
+		t.Errorf("expected the latest installation, got %+v", latest)
+	}
+
+	user, err := store.Find(ctx, "", "T1", "U1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if user.UserToken != "xoxp-1" {
+		t.Errorf("expected the installation of U1, got %+v", user)
+	}
+
+	if _, err := store.Find(ctx, "E1", "T1", ""); err != ErrInstallationNotFound {
+		t.Errorf("expected ErrInstallationNotFound, got %v", err)
+	}
+
+	if err := store.ClearBotToken(ctx, "", "T1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, userID := range []string{"", "U1", "U2"} {
+		inst, err := store.Find(ctx, "", "T1", userID)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if inst.BotToken != "" || inst.UserToken == "" {
+			t.Errorf("expected only the bot token to be cleared, got %+v", inst)
+		}
+	}
+
+	if err := store.Delete(ctx, "", "T1", "U1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := store.Find(ctx, "", "T1", "U1"); err != ErrInstallationNotFound {
+		t.Errorf("expected the installation of U1 to be deleted, got %v", err)
+	}
+	if _, err := store.Find(ctx, "", "T1", "U2"); err != nil {
+		t.Errorf("expected the installation of U2 to be kept, got %v", err)
+	}
+
+	if err := store.Delete(ctx, "", "T1", ""); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, userID := range []string{"", "U2"} {
+		if _, err := store.Find(ctx, "", "T1", userID); err != ErrInstallationNotFound {
+			t.Errorf("expected the installations of T1 to be deleted, got %v", err)
+		}
+	}
+}
+
+func TestMemoryInstallationStore(t *testing.T) {
+	testInstallationStore(t, NewMemoryInstallationStore())
+}
+
+func TestFileInstallationStore(t *testing.T) {
+	dir := t.TempDir()
+	testInstallationStore(t, NewFileInstallationStore(dir))
+
+	store := NewFileInstallationStore(dir)
+	if err := store.Save(context.Background(), &Installation{EnterpriseID: "E1", IsEnterpriseInstall: true, BotToken: "xoxb"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	fi, err := os.Stat(filepath.Join(dir, "E1-none", "installer-latest.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0o600 {
+		t.Errorf("expected the file to be readable by its owner only, got %v", perm)
+	}
+}
+
+func TestNewInstallation(t *testing.T) {
+	inst := NewInstallation(&OAuthV2Response{
+		AccessToken: "xoxb-1",
+		TokenType:   "bot",
+		Scope:       "chat:write,commands",
+		BotUserID:   "B1",
+		Team:        OAuthV2ResponseTeam{ID: "T1", Name: "Team"},
+		ExpiresIn:   43200,
+		AuthedUser:  OAuthV2ResponseAuthedUser{ID: "U1", Scope: "search:read", AccessToken: "xoxp-1"},
+	})
+
+	if inst.BotToken != "xoxb-1" || len(inst.BotScopes) != 2 || inst.BotTokenExpiresAt.IsZero() {
+		t.Errorf("unexpected bot installation %+v", inst)
+	}
+	if inst.UserToken != "xoxp-1" || inst.UserScopes[0] != "search:read" || !inst.UserTokenExpiresAt.IsZero() {
+		t.Errorf("unexpected user installation %+v", inst)
+	}
+}
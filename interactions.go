@@ -58,6 +58,10 @@ type InteractionCallback struct {
 	Container           Container       `json:"container"`
 	Enterprise          Enterprise      `json:"enterprise"`
 	IsEnterpriseInstall bool            `json:"is_enterprise_install"`
+	// FunctionData and BotAccessToken are set for the interactions with the messages or views
+	// of a custom function execution.
+	FunctionData   *FunctionData `json:"function_data,omitempty"`
+	BotAccessToken string        `json:"bot_access_token,omitempty"`
 	DialogSubmissionCallback
 	ViewSubmissionCallback
 	ViewClosedCallback
@@ -72,6 +76,15 @@ type InteractionCallback struct {
 	BlockActionState *BlockActionStates `json:"-"`
 }
 
+// FunctionData is the custom function execution an interaction is part of.
+type FunctionData struct {
+	ExecutionID string `json:"execution_id"`
+	Function    struct {
+		CallbackID string `json:"callback_id"`
+	} `json:"function"`
+	Inputs map[string]interface{} `json:"inputs"`
+}
+
 type BlockActionStates struct {
 	Values map[string]map[string]BlockAction `json:"values"`
 }
@@ -24,22 +24,17 @@ func (api *Client) CreateManifest(manifest *Manifest, token string) (*ManifestRe
 // CreateManifestContext creates an app from an app manifest with a custom context.
 // Slack API docs: https://api.slack.com/methods/apps.manifest.create
 func (api *Client) CreateManifestContext(ctx context.Context, manifest *Manifest, token string) (*ManifestResponse, error) {
-	if token == "" {
-		token = api.configToken
-	}
-
 	jsonBytes, err := json.Marshal(manifest)
 	if err != nil {
 		return nil, err
 	}
 
 	values := url.Values{
-		"token":    {token},
 		"manifest": {string(jsonBytes)},
 	}
 
 	response := &ManifestResponse{}
-	err = api.postMethod(ctx, "apps.manifest.create", values, response)
+	err = api.postConfigMethod(ctx, "apps.manifest.create", token, values, response)
 	if err != nil {
 		return nil, err
 	}
@@ -56,17 +51,12 @@ func (api *Client) DeleteManifest(token string, appId string) (*SlackResponse, e
 // DeleteManifestContext permanently deletes an app created through app manifests with a custom context.
 // Slack API docs: https://api.slack.com/methods/apps.manifest.delete
 func (api *Client) DeleteManifestContext(ctx context.Context, token string, appId string) (*SlackResponse, error) {
-	if token == "" {
-		token = api.configToken
-	}
-
 	values := url.Values{
-		"token":  {token},
 		"app_id": {appId},
 	}
 
 	response := &SlackResponse{}
-	err := api.postMethod(ctx, "apps.manifest.delete", values, response)
+	err := api.postConfigMethod(ctx, "apps.manifest.delete", token, values, response)
 	if err != nil {
 		return nil, err
 	}
@@ -83,17 +73,12 @@ func (api *Client) ExportManifest(token string, appId string) (*Manifest, error)
 // ExportManifestContext exports an app manifest from an existing app with a custom context.
 // Slack API docs: https://api.slack.com/methods/apps.manifest.export
 func (api *Client) ExportManifestContext(ctx context.Context, token string, appId string) (*Manifest, error) {
-	if token == "" {
-		token = api.configToken
-	}
-
 	values := url.Values{
-		"token":  {token},
 		"app_id": {appId},
 	}
 
 	response := &ExportManifestResponse{}
-	err := api.postMethod(ctx, "apps.manifest.export", values, response)
+	err := api.postConfigMethod(ctx, "apps.manifest.export", token, values, response)
 	if err != nil {
 		return nil, err
 	}
@@ -110,23 +95,18 @@ func (api *Client) UpdateManifest(manifest *Manifest, token string, appId string
 // UpdateManifestContext updates an app from an app manifest with a custom context.
 // Slack API docs: https://api.slack.com/methods/apps.manifest.update
 func (api *Client) UpdateManifestContext(ctx context.Context, manifest *Manifest, token string, appId string) (*UpdateManifestResponse, error) {
-	if token == "" {
-		token = api.configToken
-	}
-
 	jsonBytes, err := json.Marshal(manifest)
 	if err != nil {
 		return nil, err
 	}
 
 	values := url.Values{
-		"token":    {token},
 		"app_id":   {appId},
 		"manifest": {string(jsonBytes)},
 	}
 
 	response := &UpdateManifestResponse{}
-	err = api.postMethod(ctx, "apps.manifest.update", values, response)
+	err = api.postConfigMethod(ctx, "apps.manifest.update", token, values, response)
 	if err != nil {
 		return nil, err
 	}
@@ -143,10 +123,6 @@ func (api *Client) ValidateManifest(manifest *Manifest, token string, appId stri
 // ValidateManifestContext sends a request to apps.manifest.validate to validate your app manifest with a custom context.
 // Slack API docs: https://api.slack.com/methods/apps.manifest.validate
 func (api *Client) ValidateManifestContext(ctx context.Context, manifest *Manifest, token string, appId string) (*ManifestResponse, error) {
-	if token == "" {
-		token = api.configToken
-	}
-
 	// Marshal manifest into string
 	jsonBytes, err := json.Marshal(manifest)
 	if err != nil {
@@ -154,7 +130,6 @@ func (api *Client) ValidateManifestContext(ctx context.Context, manifest *Manife
 	}
 
 	values := url.Values{
-		"token":    {token},
 		"manifest": {string(jsonBytes)},
 	}
 
@@ -163,7 +138,7 @@ func (api *Client) ValidateManifestContext(ctx context.Context, manifest *Manife
 	}
 
 	response := &ManifestResponse{}
-	err = api.postMethod(ctx, "apps.manifest.validate", values, response)
+	err = api.postConfigMethod(ctx, "apps.manifest.validate", token, values, response)
 	if err != nil {
 		return nil, err
 	}
@@ -276,13 +251,27 @@ type OAuthScopes struct {
 // ManifestResponse is the response returned by the API for apps.manifest.x endpoints
 type ManifestResponse struct {
 	Errors []ManifestValidationError `json:"errors,omitempty"`
+	// AppID, Credentials and OAuthAuthorizeURL are only returned by apps.manifest.create
+	AppID             string               `json:"app_id,omitempty"`
+	Credentials       *ManifestCredentials `json:"credentials,omitempty"`
+	OAuthAuthorizeURL string               `json:"oauth_authorize_url,omitempty"`
 	SlackResponse
 }
 
+// ManifestCredentials are the credentials of an app created from a manifest
+type ManifestCredentials struct {
+	ClientID          string `json:"client_id"`
+	ClientSecret      string `json:"client_secret"`
+	VerificationToken string `json:"verification_token"`
+	SigningSecret     string `json:"signing_secret"`
+}
+
 // ManifestValidationError is an error message returned for invalid manifests
 type ManifestValidationError struct {
-	Message string `json:"message"`
-	Pointer string `json:"pointer"`
+	Code             string `json:"code,omitempty"`
+	Message          string `json:"message"`
+	Pointer          string `json:"pointer"`
+	RelatedComponent string `json:"related_component,omitempty"`
 }
 
 type ExportManifestResponse struct {
@@ -0,0 +1,407 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ManifestFieldError is an error Slack reported about a value of an app manifest.
+type ManifestFieldError struct {
+	Code             string
+	Message          string
+	Pointer          string
+	RelatedComponent string
+	// Line and Column locate the value in the YAML source of the manifest, when known.
+	Line   int
+	Column int
+}
+
+func (e ManifestFieldError) String() string {
+	var b strings.Builder
+	if e.Line > 0 {
+		fmt.Fprintf(&b, "line %d, column %d: ", e.Line, e.Column)
+	}
+	if e.Pointer != "" {
+		b.WriteString(e.Pointer + ": ")
+	}
+	b.WriteString(e.Message)
+	return b.String()
+}
+
+// ManifestError is returned when Slack rejects an app manifest.
+type ManifestError struct {
+	// Err is the error code of the response, e.g. "invalid_manifest".
+	Err    string
+	Errors []ManifestFieldError
+}
+
+func (e *ManifestError) Error() string {
+	if len(e.Errors) == 0 {
+		return e.Err
+	}
+
+	msgs := make([]string, 0, len(e.Errors))
+	for _, ferr := range e.Errors {
+		msgs = append(msgs, ferr.String())
+	}
+	return e.Err + ": " + strings.Join(msgs, "; ")
+}
+
+// newManifestError converts the error of an apps.manifest.* call into a ManifestError,
+// with the field errors of its response.
+func newManifestError(err error, resp *ManifestResponse) error {
+	var serr SlackErrorResponse
+	if !errors.As(err, &serr) {
+		return err
+	}
+
+	merr := &ManifestError{Err: serr.Err}
+	if resp != nil {
+		for _, verr := range resp.Errors {
+			merr.Errors = append(merr.Errors, ManifestFieldError{
+				Code:             verr.Code,
+				Message:          verr.Message,
+				Pointer:          verr.Pointer,
+				RelatedComponent: verr.RelatedComponent,
+			})
+		}
+	}
+	for _, e := range serr.Errors {
+		if e.AppsManifestCreateResponseError == nil {
+			continue
+		}
+		merr.Errors = append(merr.Errors, ManifestFieldError{
+			Code:             e.AppsManifestCreateResponseError.Code,
+			Message:          e.AppsManifestCreateResponseError.Message,
+			Pointer:          e.AppsManifestCreateResponseError.Pointer,
+			RelatedComponent: e.AppsManifestCreateResponseError.RelatedComponent,
+		})
+	}
+
+	return merr
+}
+
+// ManifestChangeType is the type of a ManifestChange.
+type ManifestChangeType string
+
+const (
+	ManifestChangeAdded    ManifestChangeType = "added"
+	ManifestChangeRemoved  ManifestChangeType = "removed"
+	ManifestChangeModified ManifestChangeType = "modified"
+)
+
+// ManifestChange is a difference between two app manifests.
+type ManifestChange struct {
+	Type ManifestChangeType
+	// Path is the JSON pointer of the value that changed. The values added to or removed
+	// from a list of strings, such as scopes or events, have the path of the list.
+	Path string
+	From interface{}
+	To   interface{}
+}
+
+func (c ManifestChange) String() string {
+	switch c.Type {
+	case ManifestChangeAdded:
+		return fmt.Sprintf("+ %s: %s", c.Path, formatManifestValue(c.To))
+	case ManifestChangeRemoved:
+		return fmt.Sprintf("- %s: %s", c.Path, formatManifestValue(c.From))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Path, formatManifestValue(c.From), formatManifestValue(c.To))
+	}
+}
+
+func formatManifestValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// ManifestDiff is the list of the differences between two app manifests.
+type ManifestDiff []ManifestChange
+
+// String returns the differences, one per line.
+func (d ManifestDiff) String() string {
+	lines := make([]string, 0, len(d))
+	for _, c := range d {
+		lines = append(lines, c.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// manifestIdentityKeys identify the items of the lists of objects, e.g. shortcuts or
+// slash commands, so that reordering them is not reported as a change.
+var manifestIdentityKeys = []string{"callback_id", "command"}
+
+// DiffManifests returns the changes turning the manifest from into the manifest to,
+// e.g. from the manifest returned by ExportManifestContext into a local one.
+// The _metadata of the manifests is ignored.
+func DiffManifests(from, to *Manifest) (ManifestDiff, error) {
+	a, err := genericManifest(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := genericManifest(to)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(a, "_metadata")
+	delete(b, "_metadata")
+
+	var diff ManifestDiff
+	diffManifestValues(&diff, "", a, b)
+	return diff, nil
+}
+
+func genericManifest(m *Manifest) (map[string]interface{}, error) {
+	if m == nil {
+		m = &Manifest{}
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	var v map[string]interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func diffManifestValues(diff *ManifestDiff, path string, a, b interface{}) {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		if bv, ok := b.(map[string]interface{}); ok {
+			diffManifestObjects(diff, path, av, bv)
+			return
+		}
+	case []interface{}:
+		if bv, ok := b.([]interface{}); ok {
+			diffManifestLists(diff, path, av, bv)
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*diff = append(*diff, ManifestChange{Type: ManifestChangeModified, Path: path, From: a, To: b})
+	}
+}
+
+func diffManifestObjects(diff *ManifestDiff, path string, a, b map[string]interface{}) {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		p := path + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(k)
+		av, inA := a[k]
+		bv, inB := b[k]
+		switch {
+		case !inA:
+			*diff = append(*diff, ManifestChange{Type: ManifestChangeAdded, Path: p, To: bv})
+		case !inB:
+			*diff = append(*diff, ManifestChange{Type: ManifestChangeRemoved, Path: p, From: av})
+		default:
+			diffManifestValues(diff, p, av, bv)
+		}
+	}
+}
+
+func diffManifestLists(diff *ManifestDiff, path string, a, b []interface{}) {
+	if isScalarList(a) && isScalarList(b) {
+		// Lists of strings, e.g. scopes or events, are sets.
+		for _, v := range a {
+			if !containsValue(b, v) {
+				*diff = append(*diff, ManifestChange{Type: ManifestChangeRemoved, Path: path, From: v})
+			}
+		}
+		for _, v := range b {
+			if !containsValue(a, v) {
+				*diff = append(*diff, ManifestChange{Type: ManifestChangeAdded, Path: path, To: v})
+			}
+		}
+		return
+	}
+
+	key := identityKey(a, b)
+	if key == "" {
+		for i := 0; i < len(a) || i < len(b); i++ {
+			p := path + "/" + strconv.Itoa(i)
+			switch {
+			case i >= len(a):
+				*diff = append(*diff, ManifestChange{Type: ManifestChangeAdded, Path: p, To: b[i]})
+			case i >= len(b):
+				*diff = append(*diff, ManifestChange{Type: ManifestChangeRemoved, Path: p, From: a[i]})
+			default:
+				diffManifestValues(diff, p, a[i], b[i])
+			}
+		}
+		return
+	}
+
+	// The paths refer to the indexes of the items in the target manifest,
+	// or in the source one for the removed items.
+	for i, av := range a {
+		if j := indexByKey(b, key, av.(map[string]interface{})[key]); j < 0 {
+			*diff = append(*diff, ManifestChange{Type: ManifestChangeRemoved, Path: path + "/" + strconv.Itoa(i), From: av})
+		}
+	}
+	for j, bv := range b {
+		p := path + "/" + strconv.Itoa(j)
+		if i := indexByKey(a, key, bv.(map[string]interface{})[key]); i < 0 {
+			*diff = append(*diff, ManifestChange{Type: ManifestChangeAdded, Path: p, To: bv})
+		} else {
+			diffManifestValues(diff, p, a[i], bv)
+		}
+	}
+}
+
+func isScalarList(l []interface{}) bool {
+	for _, v := range l {
+		switch v.(type) {
+		case map[string]interface{}, []interface{}:
+			return false
+		}
+	}
+	return true
+}
+
+func containsValue(l []interface{}, v interface{}) bool {
+	for _, w := range l {
+		if w == v {
+			return true
+		}
+	}
+	return false
+}
+
+// identityKey returns the key identifying every object of both lists, if any.
+func identityKey(a, b []interface{}) string {
+	for _, key := range manifestIdentityKeys {
+		found := true
+		for _, l := range [][]interface{}{a, b} {
+			for _, v := range l {
+				obj, ok := v.(map[string]interface{})
+				if !ok {
+					found = false
+					break
+				}
+				if _, ok := obj[key].(string); !ok {
+					found = false
+					break
+				}
+			}
+		}
+		if found {
+			return key
+		}
+	}
+	return ""
+}
+
+func indexByKey(l []interface{}, key string, value interface{}) int {
+	for i, v := range l {
+		if v.(map[string]interface{})[key] == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// ApplyManifestResult is the outcome of ApplyManifestContext.
+type ApplyManifestResult struct {
+	AppID string
+	// Created is true when a new app was created.
+	Created bool
+	// Diff holds the changes made to the existing app. It is empty when the app was
+	// created, or when its manifest was already up to date.
+	Diff               ManifestDiff
+	PermissionsUpdated bool
+	// Credentials and OAuthAuthorizeURL are only set when a new app was created.
+	Credentials       *ManifestCredentials
+	OAuthAuthorizeURL string
+}
+
+// ApplyManifest validates an app manifest, then creates an app from it, or updates the app.
+// For more details, see ApplyManifestContext documentation.
+func (api *Client) ApplyManifest(manifest *Manifest, token string, appId string) (*ApplyManifestResult, error) {
+	return api.ApplyManifestContext(context.Background(), manifest, token, appId)
+}
+
+// ApplyManifestContext validates an app manifest, then creates an app from it when appId is
+// empty, or updates the app appId when its exported manifest differs, with a custom context.
+// The manifests Slack rejects are reported with a *ManifestError.
+func (api *Client) ApplyManifestContext(ctx context.Context, manifest *Manifest, token string, appId string) (*ApplyManifestResult, error) {
+	if resp, err := api.ValidateManifestContext(ctx, manifest, token, appId); err != nil {
+		return nil, newManifestError(err, resp)
+	}
+
+	if appId == "" {
+		resp, err := api.CreateManifestContext(ctx, manifest, token)
+		if err != nil {
+			return nil, newManifestError(err, resp)
+		}
+
+		return &ApplyManifestResult{
+			AppID:             resp.AppID,
+			Created:           true,
+			Credentials:       resp.Credentials,
+			OAuthAuthorizeURL: resp.OAuthAuthorizeURL,
+		}, nil
+	}
+
+	deployed, err := api.ExportManifestContext(ctx, token, appId)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := DiffManifests(deployed, manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ApplyManifestResult{AppID: appId, Diff: diff}
+	if len(diff) == 0 {
+		return result, nil
+	}
+
+	resp, err := api.UpdateManifestContext(ctx, manifest, token, appId)
+	if err != nil {
+		var mresp *ManifestResponse
+		if resp != nil {
+			mresp = &resp.ManifestResponse
+		}
+		return nil, newManifestError(err, mresp)
+	}
+	result.PermissionsUpdated = resp.PermissionsUpdated
+
+	return result, nil
+}
+
+// ApplyManifestYAMLContext applies a manifest parsed from YAML like ApplyManifestContext does.
+// The errors of the returned *ManifestError hold the position of the invalid values.
+func (api *Client) ApplyManifestYAMLContext(ctx context.Context, doc *ManifestYAML, token string, appId string) (*ApplyManifestResult, error) {
+	result, err := api.ApplyManifestContext(ctx, &doc.Manifest, token, appId)
+	if err != nil {
+		return nil, doc.Annotate(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,124 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiffManifests(t *testing.T) {
+	deployed := &Manifest{
+		Metadata: ManifestMetadata{MajorVersion: 1},
+		Display:  Display{Name: "Deploy Bot"},
+		Features: Features{
+			SlashCommands: []ManifestSlashCommand{
+				{Command: "/status", Description: "Show the status"},
+				{Command: "/deploy", Description: "Deploy"},
+			},
+		},
+		OAuthConfig: OAuthConfig{Scopes: OAuthScopes{Bot: []string{"commands", "chat:write"}}},
+	}
+	local := &Manifest{
+		Display: Display{Name: "Deploy Bot", Description: "Deploys services"},
+		Features: Features{
+			SlashCommands: []ManifestSlashCommand{
+				{Command: "/deploy", Description: "Deploy a service"},
+				{Command: "/status", Description: "Show the status"},
+			},
+		},
+		OAuthConfig: OAuthConfig{Scopes: OAuthScopes{Bot: []string{"chat:write", "commands", "reactions:read"}}},
+	}
+
+	diff, err := DiffManifests(deployed, local)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := `+ /display_information/description: "Deploys services"
+~ /features/slash_commands/0/description: "Deploy" -> "Deploy a service"
++ /oauth_config/scopes/bot: "reactions:read"`
+	if diff.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, diff)
+	}
+
+	diff, err = DiffManifests(local, local)
+	if err != nil || len(diff) != 0 {
+		t.Errorf("Expected no difference, got %v, %v", diff, err)
+	}
+}
+
+func TestApplyManifestYAMLContext(t *testing.T) {
+	var updated bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/apps.manifest.validate", func(w http.ResponseWriter, r *http.Request) {
+		var m Manifest
+		json.Unmarshal([]byte(r.FormValue("manifest")), &m)
+
+		w.Header().Set("Content-Type", "application/json")
+		if len(m.Features.SlashCommands) > 0 && m.Features.SlashCommands[0].Url == "" {
+			w.Write([]byte(`{"ok":false,"error":"invalid_manifest","errors":[{"message":"must have a url when socket mode is disabled","pointer":"/features/slash_commands/0"}]}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	})
+	mux.HandleFunc("/apps.manifest.create", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"app_id":"A1","credentials":{"client_id":"1.2","signing_secret":"s"},"oauth_authorize_url":"https://slack.com/oauth/v2/authorize?client_id=1.2"}`))
+	})
+	mux.HandleFunc("/apps.manifest.export", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"manifest":{"_metadata":{"major_version":1},"display_information":{"name":"Old Bot"}}}`))
+	})
+	mux.HandleFunc("/apps.manifest.update", func(w http.ResponseWriter, r *http.Request) {
+		updated = true
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"app_id":"A1","permissions_updated":true}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+	ctx := context.Background()
+
+	doc, err := ParseManifestYAML([]byte("display_information:\n  name: Deploy Bot\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	result, err := api.ApplyManifestYAMLContext(ctx, doc, "xoxe-config", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !result.Created || result.AppID != "A1" || result.Credentials.SigningSecret != "s" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	result, err = api.ApplyManifestYAMLContext(ctx, doc, "xoxe-config", "A1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !updated || result.Created || !result.PermissionsUpdated || len(result.Diff) != 1 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	doc, err = ParseManifestYAML([]byte(testManifestYAML))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	doc.Manifest.Settings.SocketModeEnabled = false
+
+	_, err = api.ApplyManifestYAMLContext(ctx, doc, "xoxe-config", "A1")
+	var merr *ManifestError
+	if !errors.As(err, &merr) {
+		t.Fatalf("Expected a ManifestError, got %v", err)
+	}
+	if merr.Err != "invalid_manifest" || len(merr.Errors) != 1 || merr.Errors[0].Line != 12 {
+		t.Errorf("Unexpected error %+v", merr)
+	}
+	if err.Error() != "invalid_manifest: line 12, column 7: /features/slash_commands/0: must have a url when socket mode is disabled" {
+		t.Errorf("Unexpected error message %q", err)
+	}
+}
@@ -0,0 +1,494 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+)
+
+// manifestEventScopes are the bot scopes the bot events require. The events requiring no
+// scope map to an empty string.
+var manifestEventScopes = map[string]string{
+	"app_deleted":                      "",
+	"app_home_opened":                  "",
+	"app_installed":                    "",
+	"app_mention":                      "app_mentions:read",
+	"app_uninstalled":                  "",
+	"app_uninstalled_team":             "",
+	"assistant_thread_context_changed": "assistant:write",
+	"assistant_thread_started":         "assistant:write",
+	"call_rejected":                    "calls:read",
+	"channel_archive":                  "channels:read",
+	"channel_created":                  "channels:read",
+	"channel_deleted":                  "channels:read",
+	"channel_history_changed":          "channels:history",
+	"channel_id_changed":               "channels:read",
+	"channel_left":                     "channels:read",
+	"channel_rename":                   "channels:read",
+	"channel_shared":                   "channels:read",
+	"channel_unarchive":                "channels:read",
+	"channel_unshared":                 "channels:read",
+	"dnd_updated_user":                 "dnd:read",
+	"email_domain_changed":             "team:read",
+	"emoji_changed":                    "emoji:read",
+	"file_change":                      "files:read",
+	"file_created":                     "files:read",
+	"file_deleted":                     "files:read",
+	"file_public":                      "files:read",
+	"file_shared":                      "files:read",
+	"file_unshared":                    "files:read",
+	"function_executed":                "",
+	"group_archive":                    "groups:read",
+	"group_close":                      "groups:read",
+	"group_deleted":                    "groups:read",
+	"group_history_changed":            "groups:history",
+	"group_left":                       "groups:read",
+	"group_open":                       "groups:read",
+	"group_rename":                     "groups:read",
+	"group_unarchive":                  "groups:read",
+	"im_close":                         "im:read",
+	"im_created":                       "im:read",
+	"im_history_changed":               "im:history",
+	"im_open":                          "im:read",
+	"link_shared":                      "links:read",
+	"member_joined_channel":            "channels:read",
+	"member_left_channel":              "channels:read",
+	"message.app_home":                 "",
+	"message.channels":                 "channels:history",
+	"message.groups":                   "groups:history",
+	"message.im":                       "im:history",
+	"message.mpim":                     "mpim:history",
+	"message_metadata_deleted":         "metadata.message:read",
+	"message_metadata_posted":          "metadata.message:read",
+	"message_metadata_updated":         "metadata.message:read",
+	"pin_added":                        "pins:read",
+	"pin_removed":                      "pins:read",
+	"reaction_added":                   "reactions:read",
+	"reaction_removed":                 "reactions:read",
+	"shared_channel_invite_accepted":   "conversations.connect:read",
+	"shared_channel_invite_approved":   "conversations.connect:read",
+	"shared_channel_invite_declined":   "conversations.connect:read",
+	"shared_channel_invite_received":   "conversations.connect:read",
+	"subteam_created":                  "usergroups:read",
+	"subteam_members_changed":          "usergroups:read",
+	"subteam_self_added":               "usergroups:read",
+	"subteam_self_removed":             "usergroups:read",
+	"subteam_updated":                  "usergroups:read",
+	"team_access_granted":              "",
+	"team_access_revoked":              "",
+	"team_domain_change":               "team:read",
+	"team_join":                        "users:read",
+	"team_rename":                      "team:read",
+	"tokens_revoked":                   "",
+	"user_change":                      "users:read",
+	"user_huddle_changed":              "users:read",
+	"user_profile_changed":             "users:read",
+	"user_status_changed":              "users:read",
+	"workflow_step_execute":            "workflow.steps:execute",
+}
+
+// manifestMessageEvents are the bot events delivering the message events: a handler of the
+// message events requires at least one of them.
+var manifestMessageEvents = []string{"message.channels", "message.groups", "message.im", "message.mpim"}
+
+// manifestMethodScopes are the minimum bot scopes of the Web API methods, e.g. the scope
+// reading the public channels for the methods working on any kind of conversation.
+// The methods requiring no scope map to an empty string.
+var manifestMethodScopes = map[string]string{
+	"apps.connections.open":                 "",
+	"assistant.threads.setStatus":           "assistant:write",
+	"assistant.threads.setSuggestedPrompts": "assistant:write",
+	"assistant.threads.setTitle":            "assistant:write",
+	"auth.test":                             "",
+	"bookmarks.add":                         "bookmarks:write",
+	"bookmarks.edit":                        "bookmarks:write",
+	"bookmarks.list":                        "bookmarks:read",
+	"bookmarks.remove":                      "bookmarks:write",
+	"calls.add":                             "calls:write",
+	"calls.end":                             "calls:write",
+	"calls.info":                            "calls:read",
+	"calls.update":                          "calls:write",
+	"chat.delete":                           "chat:write",
+	"chat.deleteScheduledMessage":           "chat:write",
+	"chat.getPermalink":                     "",
+	"chat.postEphemeral":                    "chat:write",
+	"chat.postMessage":                      "chat:write",
+	"chat.scheduleMessage":                  "chat:write",
+	"chat.scheduledMessages.list":           "",
+	"chat.unfurl":                           "links:write",
+	"chat.update":                           "chat:write",
+	"conversations.archive":                 "channels:manage",
+	"conversations.close":                   "channels:manage",
+	"conversations.create":                  "channels:manage",
+	"conversations.history":                 "channels:history",
+	"conversations.info":                    "channels:read",
+	"conversations.invite":                  "channels:manage",
+	"conversations.join":                    "channels:join",
+	"conversations.kick":                    "channels:manage",
+	"conversations.leave":                   "channels:manage",
+	"conversations.list":                    "channels:read",
+	"conversations.mark":                    "channels:manage",
+	"conversations.members":                 "channels:read",
+	"conversations.open":                    "im:write",
+	"conversations.rename":                  "channels:manage",
+	"conversations.replies":                 "channels:history",
+	"conversations.setPurpose":              "channels:manage",
+	"conversations.setTopic":                "channels:manage",
+	"conversations.unarchive":               "channels:manage",
+	"dnd.info":                              "dnd:read",
+	"dnd.teamInfo":                          "dnd:read",
+	"emoji.list":                            "emoji:read",
+	"files.completeUploadExternal":          "files:write",
+	"files.delete":                          "files:write",
+	"files.getUploadURLExternal":            "files:write",
+	"files.info":                            "files:read",
+	"files.list":                            "files:read",
+	"files.remote.add":                      "remote_files:write",
+	"files.remote.info":                     "remote_files:read",
+	"files.remote.list":                     "remote_files:read",
+	"files.remote.remove":                   "remote_files:write",
+	"files.remote.share":                    "remote_files:share",
+	"files.remote.update":                   "remote_files:write",
+	"files.upload":                          "files:write",
+	"functions.completeError":               "",
+	"functions.completeSuccess":             "",
+	"pins.add":                              "pins:write",
+	"pins.list":                             "pins:read",
+	"pins.remove":                           "pins:write",
+	"reactions.add":                         "reactions:write",
+	"reactions.get":                         "reactions:read",
+	"reactions.list":                        "reactions:read",
+	"reactions.remove":                      "reactions:write",
+	"reminders.add":                         "reminders:write",
+	"reminders.delete":                      "reminders:write",
+	"reminders.info":                        "reminders:read",
+	"reminders.list":                        "reminders:read",
+	"team.info":                             "team:read",
+	"team.profile.get":                      "users.profile:read",
+	"usergroups.create":                     "usergroups:write",
+	"usergroups.disable":                    "usergroups:write",
+	"usergroups.enable":                     "usergroups:write",
+	"usergroups.list":                       "usergroups:read",
+	"usergroups.update":                     "usergroups:write",
+	"usergroups.users.list":                 "usergroups:read",
+	"usergroups.users.update":               "usergroups:write",
+	"users.conversations":                   "channels:read",
+	"users.getPresence":                     "users:read",
+	"users.info":                            "users:read",
+	"users.list":                            "users:read",
+	"users.lookupByEmail":                   "users:read.email",
+	"users.profile.get":                     "users.profile:read",
+	"views.open":                            "",
+	"views.publish":                         "",
+	"views.push":                            "",
+	"views.update":                          "",
+	"workflows.stepCompleted":               "workflow.steps:execute",
+	"workflows.stepFailed":                  "workflow.steps:execute",
+	"workflows.updateStep":                  "workflow.steps:execute",
+}
+
+// ManifestRequirements are the features, event subscriptions, scopes and settings an app
+// manifest must have for the handlers and the Web API methods of an app, e.g. as returned by
+// slackevents.Router.ManifestRequirements. Apply adds them to a manifest, and Check verifies
+// that a manifest, e.g. the one of the deployed app, has them.
+type ManifestRequirements struct {
+	SlashCommands []ManifestSlashCommand
+	Shortcuts     []Shortcut
+	// BotEvents holds the required bot events. "message" stands for the message events of
+	// any kind of conversation: it is satisfied by any of message.channels, message.groups,
+	// message.im or message.mpim.
+	BotEvents     []string
+	BotScopes     []string
+	Interactivity bool
+	SocketMode    bool
+}
+
+// AddSlashCommands adds slash commands to the requirements. The empty fields of the commands
+// already required are set from the ones of the added commands with the same name.
+func (r *ManifestRequirements) AddSlashCommands(commands ...ManifestSlashCommand) {
+	for _, cmd := range commands {
+		i := 0
+		for ; i < len(r.SlashCommands); i++ {
+			if r.SlashCommands[i].Command == cmd.Command {
+				break
+			}
+		}
+		if i == len(r.SlashCommands) {
+			r.SlashCommands = append(r.SlashCommands, cmd)
+			continue
+		}
+
+		existing := &r.SlashCommands[i]
+		if existing.Description == "" {
+			existing.Description = cmd.Description
+		}
+		if existing.UsageHint == "" {
+			existing.UsageHint = cmd.UsageHint
+		}
+		if existing.Url == "" {
+			existing.Url = cmd.Url
+		}
+		existing.ShouldEscape = existing.ShouldEscape || cmd.ShouldEscape
+	}
+
+	if len(commands) > 0 {
+		r.AddBotScopes("commands")
+	}
+}
+
+// AddShortcuts adds shortcuts to the requirements. The empty fields of the shortcuts
+// already required are set from the ones of the added shortcuts with the same callback ID.
+func (r *ManifestRequirements) AddShortcuts(shortcuts ...Shortcut) {
+	for _, s := range shortcuts {
+		i := 0
+		for ; i < len(r.Shortcuts); i++ {
+			if r.Shortcuts[i].CallbackID == s.CallbackID {
+				break
+			}
+		}
+		if i == len(r.Shortcuts) {
+			r.Shortcuts = append(r.Shortcuts, s)
+			continue
+		}
+
+		existing := &r.Shortcuts[i]
+		if existing.Name == "" {
+			existing.Name = s.Name
+		}
+		if existing.Description == "" {
+			existing.Description = s.Description
+		}
+		if existing.Type == "" {
+			existing.Type = s.Type
+		}
+	}
+
+	if len(shortcuts) > 0 {
+		r.Interactivity = true
+		r.AddBotScopes("commands")
+	}
+}
+
+// AddBotEvents adds bot events, and the scopes they require, to the requirements. The scopes
+// of "message" depend on the message events the manifest subscribes to, and are required by
+// Missing and Apply. The scopes of the events it does not know must be added with
+// AddBotScopes: it returns an error listing them.
+func (r *ManifestRequirements) AddBotEvents(events ...string) error {
+	var unknown []string
+	for _, evt := range events {
+		r.BotEvents = appendMissing(r.BotEvents, evt)
+
+		scope, ok := manifestEventScopes[evt]
+		if !ok && evt != "message" {
+			unknown = append(unknown, evt)
+			continue
+		}
+		if scope != "" {
+			r.AddBotScopes(scope)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown scopes of the bot events %s", strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// AddBotScopes adds bot scopes to the requirements.
+func (r *ManifestRequirements) AddBotScopes(scopes ...string) {
+	for _, scope := range scopes {
+		r.BotScopes = appendMissing(r.BotScopes, scope)
+	}
+}
+
+// AddMethods adds the minimum bot scopes of Web API methods, e.g. "chat.postMessage", to the
+// requirements. The scopes of the methods it does not know must be added with AddBotScopes:
+// it returns an error listing them.
+func (r *ManifestRequirements) AddMethods(methods ...string) error {
+	var unknown []string
+	for _, method := range methods {
+		scope, ok := manifestMethodScopes[method]
+		if !ok {
+			unknown = append(unknown, method)
+			continue
+		}
+		if scope != "" {
+			r.AddBotScopes(scope)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown scopes of the Web API methods %s", strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// Merge adds the requirements of other to r.
+func (r *ManifestRequirements) Merge(other *ManifestRequirements) {
+	r.AddSlashCommands(other.SlashCommands...)
+	r.AddShortcuts(other.Shortcuts...)
+	r.AddBotEvents(other.BotEvents...)
+	r.AddBotScopes(other.BotScopes...)
+	r.Interactivity = r.Interactivity || other.Interactivity
+	r.SocketMode = r.SocketMode || other.SocketMode
+}
+
+// Missing returns the requirements the manifest does not have, as the changes Apply would make.
+// The slash commands and shortcuts are identified by their command and callback ID.
+func (r *ManifestRequirements) Missing(m *Manifest) ManifestDiff {
+	var diff ManifestDiff
+
+	for _, cmd := range r.SlashCommands {
+		if !hasSlashCommand(m.Features.SlashCommands, cmd.Command) {
+			diff = append(diff, ManifestChange{Type: ManifestChangeAdded, Path: "/features/slash_commands", To: cmd})
+		}
+	}
+	for _, s := range r.Shortcuts {
+		if !hasShortcut(m.Features.Shortcuts, s.CallbackID) {
+			diff = append(diff, ManifestChange{Type: ManifestChangeAdded, Path: "/features/shortcuts", To: s})
+		}
+	}
+	for _, evt := range r.BotEvents {
+		if !hasBotEvent(m.Settings.EventSubscriptions.BotEvents, evt) {
+			diff = append(diff, ManifestChange{Type: ManifestChangeAdded, Path: "/settings/event_subscriptions/bot_events", To: evt})
+		}
+	}
+	for _, scope := range r.botScopes(m) {
+		if !containsString(m.OAuthConfig.Scopes.Bot, scope) {
+			diff = append(diff, ManifestChange{Type: ManifestChangeAdded, Path: "/oauth_config/scopes/bot", To: scope})
+		}
+	}
+	if r.Interactivity && !m.Settings.Interactivity.IsEnabled {
+		diff = append(diff, ManifestChange{Type: ManifestChangeModified, Path: "/settings/interactivity/is_enabled", From: false, To: true})
+	}
+	if r.SocketMode && !m.Settings.SocketModeEnabled {
+		diff = append(diff, ManifestChange{Type: ManifestChangeModified, Path: "/settings/socket_mode_enabled", From: false, To: true})
+	}
+
+	return diff
+}
+
+// Check returns an error listing the requirements the manifest does not have, if any.
+// It is meant to fail the tests of an app when the manifest of the deployed app, see
+// ExportManifestContext, drifted from its handlers.
+func (r *ManifestRequirements) Check(m *Manifest) error {
+	if diff := r.Missing(m); len(diff) > 0 {
+		return fmt.Errorf("the app manifest does not match the requirements of the app:\n%s", diff)
+	}
+	return nil
+}
+
+// Apply adds the requirements the manifest does not have to it. The slash commands and
+// shortcuts the manifest already has are left as they are. A "message" bot event adds the
+// message events of every kind of conversation, unless the manifest has any of them.
+func (r *ManifestRequirements) Apply(m *Manifest) {
+	for _, cmd := range r.SlashCommands {
+		if !hasSlashCommand(m.Features.SlashCommands, cmd.Command) {
+			m.Features.SlashCommands = append(m.Features.SlashCommands, cmd)
+		}
+	}
+	for _, s := range r.Shortcuts {
+		if !hasShortcut(m.Features.Shortcuts, s.CallbackID) {
+			m.Features.Shortcuts = append(m.Features.Shortcuts, s)
+		}
+	}
+
+	scopes := r.botScopes(m)
+	subscriptions := &m.Settings.EventSubscriptions
+	for _, evt := range r.BotEvents {
+		if hasBotEvent(subscriptions.BotEvents, evt) {
+			continue
+		}
+
+		events := []string{evt}
+		if evt == "message" {
+			events = manifestMessageEvents
+		}
+		for _, e := range events {
+			subscriptions.BotEvents = appendMissing(subscriptions.BotEvents, e)
+			if scope := manifestEventScopes[e]; scope != "" {
+				scopes = appendMissing(scopes, scope)
+			}
+		}
+	}
+
+	for _, scope := range scopes {
+		m.OAuthConfig.Scopes.Bot = appendMissing(m.OAuthConfig.Scopes.Bot, scope)
+	}
+	if len(m.OAuthConfig.Scopes.Bot) > 0 && m.Features.BotUser.DisplayName == "" {
+		// Apps with bot scopes must have a bot user.
+		m.Features.BotUser.DisplayName = m.Display.Name
+	}
+
+	m.Settings.Interactivity.IsEnabled = m.Settings.Interactivity.IsEnabled || r.Interactivity
+	m.Settings.SocketModeEnabled = m.Settings.SocketModeEnabled || r.SocketMode
+}
+
+// botScopes returns the bot scopes m must have: the ones of r, and the ones of the message
+// events delivering the "message" bot event once the requirements are applied to m.
+func (r *ManifestRequirements) botScopes(m *Manifest) []string {
+	scopes := append([]string(nil), r.BotScopes...)
+	if !containsString(r.BotEvents, "message") {
+		return scopes
+	}
+
+	events := manifestMessageEvents
+	if hasBotEvent(m.Settings.EventSubscriptions.BotEvents, "message") {
+		events = m.Settings.EventSubscriptions.BotEvents
+	}
+	for _, e := range manifestMessageEvents {
+		if containsString(events, e) {
+			scopes = appendMissing(scopes, manifestEventScopes[e])
+		}
+	}
+
+	return scopes
+}
+
+func hasSlashCommand(commands []ManifestSlashCommand, command string) bool {
+	for _, cmd := range commands {
+		if cmd.Command == command {
+			return true
+		}
+	}
+	return false
+}
+
+func hasShortcut(shortcuts []Shortcut, callbackID string) bool {
+	for _, s := range shortcuts {
+		if s.CallbackID == callbackID {
+			return true
+		}
+	}
+	return false
+}
+
+func hasBotEvent(events []string, evt string) bool {
+	if evt != "message" {
+		return containsString(events, evt)
+	}
+
+	for _, e := range manifestMessageEvents {
+		if containsString(events, e) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(l []string, s string) bool {
+	for _, v := range l {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func appendMissing(l []string, s string) []string {
+	if containsString(l, s) {
+		return l
+	}
+	return append(l, s)
+}
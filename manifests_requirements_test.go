@@ -0,0 +1,106 @@
+package slack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestManifestRequirements(t *testing.T) {
+	reqs := &ManifestRequirements{}
+	reqs.AddSlashCommands(ManifestSlashCommand{Command: "/deploy"})
+	reqs.AddSlashCommands(ManifestSlashCommand{Command: "/deploy", Description: "Deploy a service"})
+	reqs.AddShortcuts(Shortcut{Name: "Deploy", CallbackID: "deploy", Description: "Deploy a service", Type: GlobalShortcut})
+	reqs.AddBotEvents("app_mention", "message")
+	if err := reqs.AddMethods("chat.postMessage", "users.info", "chat.doSomething"); err == nil {
+		t.Error("Expected an error for an unknown method")
+	}
+
+	expectedScopes := []string{"commands", "app_mentions:read", "chat:write", "users:read"}
+	if !reflect.DeepEqual(reqs.BotScopes, expectedScopes) {
+		t.Errorf("Expected scopes %v, got %v", expectedScopes, reqs.BotScopes)
+	}
+	if len(reqs.SlashCommands) != 1 || reqs.SlashCommands[0].Description != "Deploy a service" {
+		t.Errorf("Unexpected slash commands %+v", reqs.SlashCommands)
+	}
+
+	m := &Manifest{
+		Display: Display{Name: "Deploy Bot"},
+		Features: Features{
+			SlashCommands: []ManifestSlashCommand{{Command: "/deploy", Description: "Deploys"}},
+		},
+		Settings: Settings{
+			EventSubscriptions: EventSubscriptions{BotEvents: []string{"message.im"}},
+		},
+		OAuthConfig: OAuthConfig{Scopes: OAuthScopes{Bot: []string{"commands", "im:history"}}},
+	}
+
+	expected := `+ /features/shortcuts: {"name":"Deploy","callback_id":"deploy","description":"Deploy a service","type":"global"}
++ /settings/event_subscriptions/bot_events: "app_mention"
++ /oauth_config/scopes/bot: "app_mentions:read"
++ /oauth_config/scopes/bot: "chat:write"
++ /oauth_config/scopes/bot: "users:read"
+~ /settings/interactivity/is_enabled: false -> true`
+	if diff := reqs.Missing(m); diff.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, diff)
+	}
+	if reqs.Check(m) == nil {
+		t.Error("Expected an error for the missing requirements")
+	}
+
+	reqs.Apply(m)
+	if err := reqs.Check(m); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	if m.Features.SlashCommands[0].Description != "Deploys" {
+		t.Error("Expected the existing slash command to be left as is")
+	}
+	if !reflect.DeepEqual(m.Settings.EventSubscriptions.BotEvents, []string{"message.im", "app_mention"}) {
+		t.Errorf("Unexpected bot events %v", m.Settings.EventSubscriptions.BotEvents)
+	}
+	if m.Features.BotUser.DisplayName != "Deploy Bot" {
+		t.Errorf("Unexpected bot user %+v", m.Features.BotUser)
+	}
+}
+
+func TestManifestRequirementsApplyMessageEvents(t *testing.T) {
+	reqs := &ManifestRequirements{}
+	reqs.AddBotEvents("message")
+
+	m := &Manifest{}
+	reqs.Apply(m)
+
+	if !reflect.DeepEqual(m.Settings.EventSubscriptions.BotEvents, manifestMessageEvents) {
+		t.Errorf("Unexpected bot events %v", m.Settings.EventSubscriptions.BotEvents)
+	}
+	expectedScopes := []string{"channels:history", "groups:history", "im:history", "mpim:history"}
+	if !reflect.DeepEqual(m.OAuthConfig.Scopes.Bot, expectedScopes) {
+		t.Errorf("Unexpected scopes %v", m.OAuthConfig.Scopes.Bot)
+	}
+}
+
+func TestManifestRequirementsMessageEventScopes(t *testing.T) {
+	reqs := &ManifestRequirements{}
+	if err := reqs.AddBotEvents("message", "app_mention", "custom_event"); err == nil {
+		t.Error("Expected an error for an unknown event")
+	}
+	if !reflect.DeepEqual(reqs.BotEvents, []string{"message", "app_mention", "custom_event"}) {
+		t.Errorf("Unexpected bot events %v", reqs.BotEvents)
+	}
+
+	m := &Manifest{
+		Settings: Settings{
+			EventSubscriptions: EventSubscriptions{BotEvents: []string{"message.im", "app_mention", "custom_event"}},
+		},
+		OAuthConfig: OAuthConfig{Scopes: OAuthScopes{Bot: []string{"app_mentions:read"}}},
+	}
+
+	expected := `+ /oauth_config/scopes/bot: "im:history"`
+	if diff := reqs.Missing(m); diff.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, diff)
+	}
+
+	reqs.Apply(m)
+	if err := reqs.Check(m); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,160 @@
+package slack
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// manifestKeyOrder is the order of the top-level keys of the manifests exported by Slack.
+var manifestKeyOrder = []string{"_metadata", "display_information", "features", "oauth_config", "settings"}
+
+// ManifestYAML is an app manifest parsed from YAML. It remembers the position of every
+// value, so that the errors Slack reports for a manifest can be mapped back to its source.
+type ManifestYAML struct {
+	Manifest Manifest
+
+	root *yaml.Node
+}
+
+// ParseManifestYAML parses an app manifest in the YAML format of the app config pages.
+func ParseManifestYAML(b []byte) (*ManifestYAML, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(b, &root); err != nil {
+		return nil, err
+	}
+
+	doc := &ManifestYAML{root: &root}
+	if err := root.Decode(&doc.Manifest); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// MarshalManifestYAML marshals an app manifest in the YAML format of the app config pages.
+func MarshalManifestYAML(manifest *Manifest) ([]byte, error) {
+	var node yaml.Node
+	if err := node.Encode(manifest); err != nil {
+		return nil, err
+	}
+	sortManifestKeys(&node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// sortManifestKeys orders the top-level keys of an encoded manifest like Slack does.
+func sortManifestKeys(node *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	pairs := make(map[string][]*yaml.Node, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs[node.Content[i].Value] = node.Content[i : i+2]
+	}
+
+	content := make([]*yaml.Node, 0, len(node.Content))
+	for _, key := range manifestKeyOrder {
+		if pair, ok := pairs[key]; ok {
+			content = append(content, pair...)
+			delete(pairs, key)
+		}
+	}
+	// Keys unknown to manifestKeyOrder keep their order, at the end.
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if _, ok := pairs[node.Content[i].Value]; ok {
+			content = append(content, node.Content[i:i+2]...)
+		}
+	}
+
+	node.Content = content
+}
+
+// Position returns the line and column, starting at 1, of the value a JSON pointer such as
+// "/features/slash_commands/0/url" refers to. When the value is missing from the source,
+// the position of its closest ancestor is returned. ok is false when the pointer is invalid.
+func (d *ManifestYAML) Position(pointer string) (line, column int, ok bool) {
+	if d.root == nil {
+		return 0, 0, false
+	}
+
+	node := d.root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	if pointer == "" || pointer == "/" {
+		return node.Line, node.Column, true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return 0, 0, false
+	}
+
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		child := childNode(node, token)
+		if child == nil {
+			break
+		}
+		node = child
+	}
+
+	return node.Line, node.Column, true
+}
+
+func childNode(node *yaml.Node, token string) *yaml.Node {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == token {
+				return node.Content[i+1]
+			}
+		}
+	case yaml.SequenceNode:
+		i, err := strconv.Atoi(token)
+		if err == nil && i >= 0 && i < len(node.Content) {
+			return node.Content[i]
+		}
+	}
+
+	return nil
+}
+
+// Annotate sets the position in the YAML source of the errors of a ManifestError.
+// Other errors are returned unchanged.
+func (d *ManifestYAML) Annotate(err error) error {
+	var merr *ManifestError
+	if !errors.As(err, &merr) {
+		return err
+	}
+
+	for i := range merr.Errors {
+		if merr.Errors[i].Pointer == "" {
+			continue
+		}
+		if line, column, ok := d.Position(merr.Errors[i].Pointer); ok {
+			merr.Errors[i].Line, merr.Errors[i].Column = line, column
+		}
+	}
+
+	return err
+}
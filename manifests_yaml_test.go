@@ -0,0 +1,92 @@
+package slack
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testManifestYAML = `_metadata:
+  major_version: 1
+  minor_version: 1
+display_information:
+  name: Deploy Bot
+  description: Deploys services
+features:
+  bot_user:
+    display_name: deploybot
+    always_online: true
+  slash_commands:
+    - command: /deploy
+      description: Deploy a service
+      usage_hint: <service> to <env>
+oauth_config:
+  scopes:
+    bot:
+      - commands
+      - chat:write
+settings:
+  interactivity:
+    is_enabled: true
+  socket_mode_enabled: true
+`
+
+func TestParseManifestYAML(t *testing.T) {
+	doc, err := ParseManifestYAML([]byte(testManifestYAML))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	m := doc.Manifest
+	if m.Display.Name != "Deploy Bot" || m.Features.BotUser.DisplayName != "deploybot" || !m.Settings.SocketModeEnabled {
+		t.Errorf("Unexpected manifest %+v", m)
+	}
+	if len(m.Features.SlashCommands) != 1 || m.Features.SlashCommands[0].UsageHint != "<service> to <env>" {
+		t.Errorf("Unexpected slash commands %+v", m.Features.SlashCommands)
+	}
+	if !reflect.DeepEqual(m.OAuthConfig.Scopes.Bot, []string{"commands", "chat:write"}) {
+		t.Errorf("Unexpected scopes %v", m.OAuthConfig.Scopes.Bot)
+	}
+}
+
+func TestMarshalManifestYAML(t *testing.T) {
+	doc, err := ParseManifestYAML([]byte(testManifestYAML))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	b, err := MarshalManifestYAML(&doc.Manifest)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if string(b) != testManifestYAML {
+		t.Errorf("Expected the manifest to round-trip, got:\n%s", b)
+	}
+}
+
+func TestManifestYAML_Position(t *testing.T) {
+	doc, err := ParseManifestYAML([]byte(testManifestYAML))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	tests := []struct {
+		pointer      string
+		line, column int
+		ok           bool
+	}{
+		{"/display_information/name", 5, 9, true},
+		{"/features/slash_commands/0/usage_hint", 14, 19, true},
+		{"/oauth_config/scopes/bot/1", 19, 9, true},
+		// Missing values are located at their closest ancestor
+		{"/features/slash_commands/0/url", 12, 7, true},
+		{"features", 0, 0, false},
+	}
+
+	for _, test := range tests {
+		line, column, ok := doc.Position(test.pointer)
+		if line != test.line || column != test.column || ok != test.ok {
+			t.Errorf("Position(%q): expected %d:%d %t, got %d:%d %t", test.pointer, test.line, test.column, test.ok, line, column, ok)
+		}
+	}
+}
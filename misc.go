@@ -142,6 +142,22 @@ func (e *RateLimitedError) Retryable() bool {
 	return true
 }
 
+// waitRateLimit waits until a rate limited request can be retried. It returns false when
+// err is another error, or when ctx is done first.
+func waitRateLimit(ctx context.Context, err error) bool {
+	var rlerr *RateLimitedError
+	if !errors.As(err, &rlerr) {
+		return false
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(rlerr.RetryAfter):
+		return true
+	}
+}
+
 func fileUploadReq(ctx context.Context, path string, r io.Reader) (*http.Request, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, path, r)
 	if err != nil {
@@ -3,11 +3,15 @@ package slack
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"log"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/slack-go/slack/slackutilsx"
 )
@@ -289,3 +293,42 @@ func TestSlackResponseWithErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestClientDo(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/limited", func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Retry-After", "3")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	})
+	mux.HandleFunc("/missing", func(rw http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer testing-token" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		rw.WriteHeader(http.StatusNotFound)
+		rw.Write([]byte(`{"detail":"not found"}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token")
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/limited", nil)
+	_, err := api.Do(req)
+	var rlerr *RateLimitedError
+	if !errors.As(err, &rlerr) || rlerr.RetryAfter != 3*time.Second {
+		t.Errorf("expected a rate limited error, got %v", err)
+	}
+
+	// The failed responses are returned for the caller to decode.
+	req, _ = http.NewRequest(http.MethodGet, ts.URL+"/missing", nil)
+	resp, err := api.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	b, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusNotFound || string(b) != `{"detail":"not found"}` {
+		t.Errorf("unexpected response %d %s", resp.StatusCode, b)
+	}
+}
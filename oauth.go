@@ -148,8 +148,13 @@ func GetOAuthV2ResponseContext(ctx context.Context, client httpClient, clientID,
 		"code":          {code},
 		"redirect_uri":  {redirectURI},
 	}
+	return postOAuthV2Access(ctx, client, APIURL, values)
+}
+
+// postOAuthV2Access calls oauth.v2.access on the Web API at apiURL.
+func postOAuthV2Access(ctx context.Context, client httpClient, apiURL string, values url.Values) (*OAuthV2Response, error) {
 	response := &OAuthV2Response{}
-	if err = postForm(ctx, client, APIURL+"oauth.v2.access", values, response, discard{}); err != nil {
+	if err := postForm(ctx, client, apiURL+"oauth.v2.access", values, response, discard{}); err != nil {
 		return nil, err
 	}
 	return response, response.Err()
@@ -170,8 +175,26 @@ func RefreshOAuthV2TokenContext(ctx context.Context, client httpClient, clientID
 		"refresh_token": {refreshToken},
 		"grant_type":    {"refresh_token"},
 	}
+	return postOAuthV2Access(ctx, client, APIURL, values)
+}
+
+// ExchangeOAuthV2Token exchanges a legacy, long-lived access token for a rotating one.
+// For more details, see ExchangeOAuthV2TokenContext documentation.
+func ExchangeOAuthV2Token(client httpClient, clientID, clientSecret, token string) (resp *OAuthV2Response, err error) {
+	return ExchangeOAuthV2TokenContext(context.Background(), client, clientID, clientSecret, token)
+}
+
+// ExchangeOAuthV2TokenContext with a context, exchanges a legacy, long-lived access token for
+// a rotating one. The response holds the new access token, its expiry and its refresh token.
+// Slack API docs: https://api.slack.com/methods/oauth.v2.exchange
+func ExchangeOAuthV2TokenContext(ctx context.Context, client httpClient, clientID, clientSecret, token string) (resp *OAuthV2Response, err error) {
+	values := url.Values{
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+		"token":         {token},
+	}
 	response := &OAuthV2Response{}
-	if err = postForm(ctx, client, APIURL+"oauth.v2.access", values, response, discard{}); err != nil {
+	if err = postForm(ctx, client, APIURL+"oauth.v2.exchange", values, response, discard{}); err != nil {
 		return nil, err
 	}
 	return response, response.Err()
@@ -192,8 +215,13 @@ func GetOpenIDConnectTokenContext(ctx context.Context, client httpClient, client
 		"code":          {code},
 		"redirect_uri":  {redirectURI},
 	}
+	return postOpenIDConnectToken(ctx, client, APIURL, values)
+}
+
+// postOpenIDConnectToken calls openid.connect.token on the Web API at apiURL.
+func postOpenIDConnectToken(ctx context.Context, client httpClient, apiURL string, values url.Values) (*OpenIDConnectResponse, error) {
 	response := &OpenIDConnectResponse{}
-	if err = postForm(ctx, client, APIURL+"openid.connect.token", values, response, discard{}); err != nil {
+	if err := postForm(ctx, client, apiURL+"openid.connect.token", values, response, discard{}); err != nil {
 		return nil, err
 	}
 	return response, response.Err()
@@ -0,0 +1,369 @@
+package slack
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack/internal/errorsx"
+)
+
+const (
+	// OAuthV2AuthorizeURL is the URL users are sent to in order to install an app.
+	OAuthV2AuthorizeURL = "https://slack.com/oauth/v2/authorize"
+
+	// oauthStateCookie binds the state to the browser that started the installation.
+	oauthStateCookie         = "slack-app-oauth-state"
+	defaultOAuthStateTimeout = 10 * time.Minute
+)
+
+// ErrOAuthStateInvalid is returned when the state of an OAuth redirect is unknown, expired,
+// already used, or was issued to another browser.
+const ErrOAuthStateInvalid = errorsx.String("invalid OAuth state")
+
+// OAuthRedirectError is the error Slack redirects to the app with, e.g. "access_denied"
+// when the user cancelled the installation.
+type OAuthRedirectError struct {
+	Code string
+}
+
+func (e OAuthRedirectError) Error() string {
+	return "oauth redirect error: " + e.Code
+}
+
+// OAuthStateStore issues and verifies the state parameter protecting the OAuth flow against CSRF.
+type OAuthStateStore interface {
+	// Issue returns a new state.
+	Issue(ctx context.Context) (string, error)
+	// Consume reports whether state was issued and has not expired, and invalidates it.
+	Consume(ctx context.Context, state string) (bool, error)
+}
+
+// MemoryOAuthStateStore is an OAuthStateStore keeping the states in memory.
+// It is only suited to apps running a single instance.
+type MemoryOAuthStateStore struct {
+	mu      sync.Mutex
+	states  map[string]time.Time
+	timeout time.Duration
+
+	now func() time.Time
+}
+
+// NewMemoryOAuthStateStore returns an empty MemoryOAuthStateStore whose states expire
+// after timeout (default: 10 minutes).
+func NewMemoryOAuthStateStore(timeout time.Duration) *MemoryOAuthStateStore {
+	if timeout <= 0 {
+		timeout = defaultOAuthStateTimeout
+	}
+
+	return &MemoryOAuthStateStore{
+		states:  make(map[string]time.Time),
+		timeout: timeout,
+		now:     time.Now,
+	}
+}
+
+// Issue implements OAuthStateStore.
+func (s *MemoryOAuthStateStore) Issue(ctx context.Context) (string, error) {
+	state, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for st, exp := range s.states {
+		if !now.Before(exp) {
+			delete(s.states, st)
+		}
+	}
+	s.states[state] = now.Add(s.timeout)
+
+	return state, nil
+}
+
+// Consume implements OAuthStateStore.
+func (s *MemoryOAuthStateStore) Consume(ctx context.Context, state string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exp, ok := s.states[state]
+	if !ok {
+		return false, nil
+	}
+	delete(s.states, state)
+
+	return s.now().Before(exp), nil
+}
+
+// OAuthInstallerOption configures an OAuthInstaller.
+type OAuthInstallerOption func(*OAuthInstaller)
+
+// OAuthInstallerOptionScopes sets the bot scopes requested by the app.
+func OAuthInstallerOptionScopes(scopes ...string) OAuthInstallerOption {
+	return func(i *OAuthInstaller) {
+		i.scopes = scopes
+	}
+}
+
+// OAuthInstallerOptionUserScopes sets the user scopes requested by the app.
+func OAuthInstallerOptionUserScopes(scopes ...string) OAuthInstallerOption {
+	return func(i *OAuthInstaller) {
+		i.userScopes = scopes
+	}
+}
+
+// OAuthInstallerOptionRedirectURI sets the redirect URI sent to Slack. It must match one of
+// the redirect URLs of the app's configuration, and be served by OAuthInstaller.RedirectHandler.
+func OAuthInstallerOptionRedirectURI(uri string) OAuthInstallerOption {
+	return func(i *OAuthInstaller) {
+		i.redirectURI = uri
+	}
+}
+
+// OAuthInstallerOptionStateStore sets the store of the states (default: in memory, 10 minutes).
+func OAuthInstallerOptionStateStore(store OAuthStateStore) OAuthInstallerOption {
+	return func(i *OAuthInstaller) {
+		i.stateStore = store
+	}
+}
+
+// OAuthInstallerOptionSuccessHandler sets the callback answering the redirects of the
+// successful installations, once they are saved. The default one renders a short page.
+func OAuthInstallerOptionSuccessHandler(f func(w http.ResponseWriter, r *http.Request, inst *Installation)) OAuthInstallerOption {
+	return func(i *OAuthInstaller) {
+		i.onSuccess = f
+	}
+}
+
+// OAuthInstallerOptionErrorHandler sets the callback answering the failed installations.
+// err is an OAuthRedirectError when the user cancelled the installation, ErrOAuthStateInvalid
+// when the state cannot be verified, or the error of the code exchange or the store.
+func OAuthInstallerOptionErrorHandler(f func(w http.ResponseWriter, r *http.Request, err error)) OAuthInstallerOption {
+	return func(i *OAuthInstaller) {
+		i.onError = f
+	}
+}
+
+// OAuthInstallerOptionHTTPClient sets the HTTP client exchanging the codes.
+func OAuthInstallerOptionHTTPClient(client httpClient) OAuthInstallerOption {
+	return func(i *OAuthInstaller) {
+		i.httpclient = client
+	}
+}
+
+// OAuthInstallerOptionAPIURL sets the Web API URL codes are exchanged with.
+func OAuthInstallerOptionAPIURL(u string) OAuthInstallerOption {
+	return func(i *OAuthInstaller) {
+		i.apiURL = u
+	}
+}
+
+// OAuthInstaller implements the OAuth v2 installation flow of an app: InstallHandler sends
+// the users to Slack, and RedirectHandler exchanges the code Slack redirects them back with
+// for tokens, saved to an InstallationStore.
+//
+// The state parameter is bound to the browser with a cookie, and can only be used once.
+//
+// See: https://api.slack.com/authentication/oauth-v2
+type OAuthInstaller struct {
+	clientID     string
+	clientSecret string
+	store        InstallationStore
+
+	scopes      []string
+	userScopes  []string
+	redirectURI string
+	stateStore  OAuthStateStore
+	onSuccess   func(w http.ResponseWriter, r *http.Request, inst *Installation)
+	onError     func(w http.ResponseWriter, r *http.Request, err error)
+	httpclient  httpClient
+	apiURL      string
+}
+
+// NewOAuthInstaller returns an OAuthInstaller for the app with the given credentials.
+func NewOAuthInstaller(clientID, clientSecret string, store InstallationStore, options ...OAuthInstallerOption) *OAuthInstaller {
+	i := &OAuthInstaller{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		store:        store,
+		stateStore:   NewMemoryOAuthStateStore(0),
+		onSuccess:    defaultOAuthSuccess,
+		onError:      defaultOAuthError,
+		httpclient:   &http.Client{},
+		apiURL:       APIURL,
+	}
+
+	for _, opt := range options {
+		opt(i)
+	}
+
+	return i
+}
+
+// AuthorizeURL returns the URL sending the users to the installation of the app with state.
+func (i *OAuthInstaller) AuthorizeURL(state string) string {
+	values := url.Values{
+		"client_id": {i.clientID},
+		"state":     {state},
+	}
+	if len(i.scopes) > 0 {
+		values.Set("scope", strings.Join(i.scopes, ","))
+	}
+	if len(i.userScopes) > 0 {
+		values.Set("user_scope", strings.Join(i.userScopes, ","))
+	}
+	if i.redirectURI != "" {
+		values.Set("redirect_uri", i.redirectURI)
+	}
+
+	return OAuthV2AuthorizeURL + "?" + values.Encode()
+}
+
+// InstallHandler returns the handler starting the installation: it issues a state and
+// redirects the users to Slack.
+func (i *OAuthInstaller) InstallHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state, err := i.stateStore.Issue(r.Context())
+		if err != nil {
+			i.onError(w, r, err)
+			return
+		}
+
+		setOAuthCookie(w, oauthStateCookie, state, r.TLS != nil || strings.HasPrefix(i.redirectURI, "https://"))
+		http.Redirect(w, r, i.AuthorizeURL(state), http.StatusFound)
+	})
+}
+
+// RedirectHandler returns the handler completing the installation: it verifies the state,
+// exchanges the code, and saves the installation.
+func (i *OAuthInstaller) RedirectHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inst, err := i.complete(r)
+
+		// The state cannot be used anymore, in any case.
+		clearOAuthCookie(w, oauthStateCookie)
+
+		if err != nil {
+			i.onError(w, r, err)
+			return
+		}
+
+		i.onSuccess(w, r, inst)
+	})
+}
+
+func (i *OAuthInstaller) complete(r *http.Request) (*Installation, error) {
+	query := r.URL.Query()
+	if code := query.Get("error"); code != "" {
+		return nil, OAuthRedirectError{Code: code}
+	}
+
+	if err := verifyOAuthState(r, i.stateStore, oauthStateCookie); err != nil {
+		return nil, err
+	}
+
+	values := url.Values{
+		"client_id":     {i.clientID},
+		"client_secret": {i.clientSecret},
+		"code":          {query.Get("code")},
+	}
+	if i.redirectURI != "" {
+		values.Set("redirect_uri", i.redirectURI)
+	}
+
+	resp, err := postOAuthV2Access(r.Context(), i.httpclient, i.apiURL, values)
+	if err != nil {
+		return nil, err
+	}
+
+	inst := NewInstallation(resp)
+	if err := i.store.Save(r.Context(), inst); err != nil {
+		return nil, fmt.Errorf("failed to save installation: %w", err)
+	}
+
+	return inst, nil
+}
+
+// randomToken returns a random, URL safe token.
+func randomToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// setOAuthCookie sets a cookie binding a value of the OAuth flow to the browser.
+func setOAuthCookie(w http.ResponseWriter, name, value string, secure bool) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   int(defaultOAuthStateTimeout / time.Second),
+		Secure:   secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func clearOAuthCookie(w http.ResponseWriter, name string) {
+	http.SetCookie(w, &http.Cookie{Name: name, Path: "/", MaxAge: -1})
+}
+
+// cookieMatches reports whether the cookie of r named name holds value.
+func cookieMatches(r *http.Request, name, value string) bool {
+	cookie, err := r.Cookie(name)
+	return err == nil && value != "" && subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(value)) == 1
+}
+
+// verifyOAuthState verifies the state of an OAuth redirect against the cookie of the
+// browser and the store.
+func verifyOAuthState(r *http.Request, store OAuthStateStore, cookieName string) error {
+	state := r.URL.Query().Get("state")
+	if !cookieMatches(r, cookieName, state) {
+		return ErrOAuthStateInvalid
+	}
+
+	valid, err := store.Consume(r.Context(), state)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return ErrOAuthStateInvalid
+	}
+
+	return nil
+}
+
+func defaultOAuthSuccess(w http.ResponseWriter, r *http.Request, inst *Installation) {
+	name := inst.TeamName
+	if inst.IsEnterpriseInstall {
+		name = inst.EnterpriseName
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "<html><body><p>The app was installed in %s.</p></body></html>", html.EscapeString(name))
+}
+
+func defaultOAuthError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+	var redirectErr OAuthRedirectError
+	if errors.Is(err, ErrOAuthStateInvalid) || errors.Is(err, ErrInvalidIDToken) || errors.As(err, &redirectErr) {
+		status = http.StatusBadRequest
+	}
+
+	http.Error(w, http.StatusText(status), status)
+}
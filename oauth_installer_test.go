@@ -0,0 +1,131 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestOAuthInstaller(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oauth.v2.access" || r.FormValue("code") != "good-code" || r.FormValue("client_secret") != "secret" {
+			w.Write([]byte(`{"ok":false,"error":"invalid_code"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"access_token":"xoxb-1","token_type":"bot","scope":"commands","bot_user_id":"B1","team":{"id":"T1","name":"Team"},"authed_user":{"id":"U1"}}`))
+	}))
+	defer api.Close()
+
+	store := NewMemoryInstallationStore()
+	var errs []error
+	installer := NewOAuthInstaller("client", "secret", store,
+		OAuthInstallerOptionScopes("commands", "chat:write"),
+		OAuthInstallerOptionUserScopes("search:read"),
+		OAuthInstallerOptionRedirectURI("https://example.com/slack/oauth_redirect"),
+		OAuthInstallerOptionAPIURL(api.URL+"/"),
+		OAuthInstallerOptionErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			errs = append(errs, err)
+			w.WriteHeader(http.StatusBadRequest)
+		}),
+	)
+
+	install := func() (string, *http.Cookie) {
+		rec := httptest.NewRecorder()
+		installer.InstallHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slack/install", nil))
+
+		if rec.Code != http.StatusFound {
+			t.Fatalf("expected a redirect, got %d", rec.Code)
+		}
+		location, err := url.Parse(rec.Header().Get("Location"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		query := location.Query()
+		if query.Get("scope") != "commands,chat:write" || query.Get("user_scope") != "search:read" || query.Get("client_id") != "client" {
+			t.Errorf("unexpected authorize URL %s", location)
+		}
+
+		return query.Get("state"), rec.Result().Cookies()[0]
+	}
+
+	redirect := func(query string, cookie *http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/slack/oauth_redirect?"+query, nil)
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		rec := httptest.NewRecorder()
+		installer.RedirectHandler().ServeHTTP(rec, req)
+		return rec
+	}
+
+	state, cookie := install()
+	if !cookie.HttpOnly || !cookie.Secure || cookie.Value != state {
+		t.Errorf("unexpected state cookie %+v", cookie)
+	}
+
+	rec := redirect("code=good-code&state="+state, cookie)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected a success, got %d: %v", rec.Code, errs)
+	}
+
+	inst, err := store.Find(context.Background(), "", "T1", "U1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if inst.BotToken != "xoxb-1" {
+		t.Errorf("unexpected installation %+v", inst)
+	}
+
+	// The state can only be used once.
+	redirect("code=good-code&state="+state, cookie)
+
+	// The state must be issued to the same browser.
+	state, _ = install()
+	redirect("code=good-code&state="+state, nil)
+
+	state, cookie = install()
+	redirect("error=access_denied&state="+state, cookie)
+
+	state, cookie = install()
+	redirect("code=bad-code&state="+state, cookie)
+
+	if len(errs) != 4 {
+		t.Fatalf("expected 4 errors, got %v", errs)
+	}
+	if errs[0] != ErrOAuthStateInvalid || errs[1] != ErrOAuthStateInvalid {
+		t.Errorf("expected ErrOAuthStateInvalid, got %v", errs[:2])
+	}
+	if errs[2] != (OAuthRedirectError{Code: "access_denied"}) {
+		t.Errorf("expected an access_denied error, got %v", errs[2])
+	}
+	if errs[3].Error() != "invalid_code" {
+		t.Errorf("expected an invalid_code error, got %v", errs[3])
+	}
+}
+
+func TestMemoryOAuthStateStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryOAuthStateStore(0)
+
+	state, err := s.Issue(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if ok, _ := s.Consume(ctx, state); !ok {
+		t.Error("expected the state to be valid")
+	}
+	if ok, _ := s.Consume(ctx, state); ok {
+		t.Error("expected the state to be used once")
+	}
+
+	state, _ = s.Issue(ctx)
+	now := s.now().Add(defaultOAuthStateTimeout)
+	s.now = func() time.Time { return now }
+	if ok, _ := s.Consume(ctx, state); ok {
+		t.Error("expected the state to be expired")
+	}
+}
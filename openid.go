@@ -0,0 +1,345 @@
+package slack
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack/internal/errorsx"
+)
+
+const (
+	// OpenIDConnectIssuer is the issuer of the id_tokens of Sign in with Slack.
+	OpenIDConnectIssuer = "https://slack.com"
+	// OpenIDConnectKeysURL is the URL of the keys signing the id_tokens of Sign in with Slack.
+	OpenIDConnectKeysURL = "https://slack.com/openid/connect/keys"
+
+	// defaultIDTokenLeeway tolerates some clock skew with Slack.
+	defaultIDTokenLeeway = time.Minute
+	// minJWKSRefreshInterval limits how often unknown key IDs trigger a refresh of the keys.
+	minJWKSRefreshInterval = time.Minute
+)
+
+// ErrInvalidIDToken is wrapped by the errors returned when an id_token cannot be verified.
+const ErrInvalidIDToken = errorsx.String("invalid id_token")
+
+// OpenIDConnectUserInfo holds the identity of a user who signed in with Slack.
+type OpenIDConnectUserInfo struct {
+	Sub               string `json:"sub"`
+	UserID            string `json:"https://slack.com/user_id"`
+	TeamID            string `json:"https://slack.com/team_id"`
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	DateEmailVerified int64  `json:"date_email_verified"`
+	Name              string `json:"name"`
+	Picture           string `json:"picture"`
+	GivenName         string `json:"given_name"`
+	FamilyName        string `json:"family_name"`
+	Locale            string `json:"locale"`
+	TeamName          string `json:"https://slack.com/team_name"`
+	TeamDomain        string `json:"https://slack.com/team_domain"`
+	UserImage24       string `json:"https://slack.com/user_image_24"`
+	UserImage32       string `json:"https://slack.com/user_image_32"`
+	UserImage48       string `json:"https://slack.com/user_image_48"`
+	UserImage72       string `json:"https://slack.com/user_image_72"`
+	UserImage192      string `json:"https://slack.com/user_image_192"`
+	UserImage512      string `json:"https://slack.com/user_image_512"`
+	TeamImage34       string `json:"https://slack.com/team_image_34"`
+	TeamImage44       string `json:"https://slack.com/team_image_44"`
+	TeamImage68       string `json:"https://slack.com/team_image_68"`
+	TeamImage88       string `json:"https://slack.com/team_image_88"`
+	TeamImage102      string `json:"https://slack.com/team_image_102"`
+	TeamImage132      string `json:"https://slack.com/team_image_132"`
+	TeamImage230      string `json:"https://slack.com/team_image_230"`
+	TeamImageDefault  bool   `json:"https://slack.com/team_image_default"`
+}
+
+type openIDConnectUserInfoResponse struct {
+	OpenIDConnectUserInfo
+	SlackResponse
+}
+
+// GetOpenIDConnectUserInfo returns the identity of the user of the client's token.
+// For more details, see GetOpenIDConnectUserInfoContext documentation.
+func (api *Client) GetOpenIDConnectUserInfo() (*OpenIDConnectUserInfo, error) {
+	return api.GetOpenIDConnectUserInfoContext(context.Background())
+}
+
+// GetOpenIDConnectUserInfoContext returns the identity of the user of the client's token,
+// obtained with GetOpenIDConnectToken, with a custom context.
+// Slack API docs: https://api.slack.com/methods/openid.connect.userInfo
+func (api *Client) GetOpenIDConnectUserInfoContext(ctx context.Context) (*OpenIDConnectUserInfo, error) {
+	values := url.Values{
+		"token": {api.token},
+	}
+
+	response := &openIDConnectUserInfoResponse{}
+	if err := api.postMethod(ctx, "openid.connect.userInfo", values, response); err != nil {
+		return nil, err
+	}
+
+	return &response.OpenIDConnectUserInfo, response.Err()
+}
+
+// IDTokenAudience is the audience of an id_token, a single client ID or a list of them.
+type IDTokenAudience []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *IDTokenAudience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = IDTokenAudience{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+// Contains reports whether clientID is part of the audience.
+func (a IDTokenAudience) Contains(clientID string) bool {
+	for _, aud := range a {
+		if aud == clientID {
+			return true
+		}
+	}
+	return false
+}
+
+// IDTokenClaims are the claims of the id_token of a user who signed in with Slack.
+type IDTokenClaims struct {
+	Issuer    string          `json:"iss"`
+	Audience  IDTokenAudience `json:"aud"`
+	ExpiresAt int64           `json:"exp"`
+	IssuedAt  int64           `json:"iat"`
+	AuthTime  int64           `json:"auth_time"`
+	Nonce     string          `json:"nonce"`
+	AtHash    string          `json:"at_hash"`
+	OpenIDConnectUserInfo
+}
+
+// IDTokenKeySource provides the public keys id_tokens are signed with.
+type IDTokenKeySource interface {
+	// Key returns the key with the given key ID.
+	Key(ctx context.Context, kid string) (*rsa.PublicKey, error)
+}
+
+// StaticIDTokenKeySource is an IDTokenKeySource holding a fixed set of keys by key ID.
+type StaticIDTokenKeySource map[string]*rsa.PublicKey
+
+// Key implements IDTokenKeySource.
+func (s StaticIDTokenKeySource) Key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	key, ok := s[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key ID %q", ErrInvalidIDToken, kid)
+	}
+	return key, nil
+}
+
+// JWKSKeySource is an IDTokenKeySource fetching the keys from a JSON Web Key Set,
+// by default Slack's. The keys are cached, and fetched again when a token is signed
+// with an unknown key.
+type JWKSKeySource struct {
+	url        string
+	httpclient httpClient
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	lastRefresh time.Time
+}
+
+// NewJWKSKeySource returns a JWKSKeySource fetching the keys from jwksURL
+// (default: OpenIDConnectKeysURL) with client (default: a new http.Client).
+func NewJWKSKeySource(jwksURL string, client httpClient) *JWKSKeySource {
+	if jwksURL == "" {
+		jwksURL = OpenIDConnectKeysURL
+	}
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	return &JWKSKeySource{url: jwksURL, httpclient: client}
+}
+
+// Key implements IDTokenKeySource.
+func (s *JWKSKeySource) Key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+
+	if time.Since(s.lastRefresh) < minJWKSRefreshInterval {
+		return nil, fmt.Errorf("%w: unknown key ID %q", ErrInvalidIDToken, kid)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks jsonWebKeySet
+	if err := doPost(s.httpclient, req, newJSONParser(&jwks), discard{}); err != nil {
+		return nil, fmt.Errorf("failed to fetch the keys: %w", err)
+	}
+
+	keys, err := jwks.rsaKeys()
+	if err != nil {
+		return nil, err
+	}
+	s.keys = keys
+	s.lastRefresh = time.Now()
+
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key ID %q", ErrInvalidIDToken, kid)
+	}
+	return key, nil
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+}
+
+// rsaKeys returns the RSA signing keys of the set by key ID.
+func (s jsonWebKeySet) rsaKeys() (map[string]*rsa.PublicKey, error) {
+	keys := make(map[string]*rsa.PublicKey, len(s.Keys))
+	for _, k := range s.Keys {
+		if k.KeyType != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus of key %q: %w", k.KeyID, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent of key %q: %w", k.KeyID, err)
+		}
+
+		keys[k.KeyID] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
+
+// IDTokenVerifier verifies the id_tokens of Sign in with Slack: their RS256 signature,
+// issuer, audience, expiry and nonce.
+//
+// See: https://api.slack.com/authentication/sign-in-with-slack
+type IDTokenVerifier struct {
+	clientID string
+	keys     IDTokenKeySource
+	leeway   time.Duration
+
+	now func() time.Time
+}
+
+// NewIDTokenVerifier returns an IDTokenVerifier for the tokens issued to clientID,
+// signed with the keys of keys (default: Slack's keys).
+func NewIDTokenVerifier(clientID string, keys IDTokenKeySource) *IDTokenVerifier {
+	if keys == nil {
+		keys = NewJWKSKeySource("", nil)
+	}
+
+	return &IDTokenVerifier{
+		clientID: clientID,
+		keys:     keys,
+		leeway:   defaultIDTokenLeeway,
+		now:      time.Now,
+	}
+}
+
+// Verify parses and verifies idToken. When nonce is not empty, it must match the nonce
+// of the token. The errors about the token itself wrap ErrInvalidIDToken.
+func (v *IDTokenVerifier) Verify(ctx context.Context, idToken, nonce string) (*IDTokenClaims, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidIDToken)
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Algorithm != "RS256" {
+		return nil, fmt.Errorf("%w: unexpected algorithm %q", ErrInvalidIDToken, header.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidIDToken)
+	}
+
+	key, err := v.keys.Key(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("%w: invalid signature", ErrInvalidIDToken)
+	}
+
+	claims := &IDTokenClaims{}
+	if err := decodeJWTPart(parts[1], claims); err != nil {
+		return nil, err
+	}
+
+	now := v.now()
+	switch {
+	case claims.Issuer != OpenIDConnectIssuer:
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidIDToken, claims.Issuer)
+	case !claims.Audience.Contains(v.clientID):
+		return nil, fmt.Errorf("%w: not issued to client %q", ErrInvalidIDToken, v.clientID)
+	case now.After(time.Unix(claims.ExpiresAt, 0).Add(v.leeway)):
+		return nil, fmt.Errorf("%w: expired", ErrInvalidIDToken)
+	case now.Add(v.leeway).Before(time.Unix(claims.IssuedAt, 0)):
+		return nil, fmt.Errorf("%w: issued in the future", ErrInvalidIDToken)
+	case nonce != "" && claims.Nonce != nonce:
+		return nil, fmt.Errorf("%w: unexpected nonce", ErrInvalidIDToken)
+	}
+
+	return claims, nil
+}
+
+func decodeJWTPart(part string, dst interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return fmt.Errorf("%w: malformed token", ErrInvalidIDToken)
+	}
+	if err := json.Unmarshal(b, dst); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidIDToken, err)
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package slack
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// OpenIDConnectAuthorizeURL is the URL users are sent to in order to sign in with Slack.
+	OpenIDConnectAuthorizeURL = "https://slack.com/openid/connect/authorize"
+
+	openIDConnectStateCookie = "slack-openid-state"
+	openIDConnectNonceCookie = "slack-openid-nonce"
+)
+
+// OpenIDConnectLoginOption configures an OpenIDConnectLogin.
+type OpenIDConnectLoginOption func(*OpenIDConnectLogin)
+
+// OpenIDConnectLoginOptionScopes sets the requested scopes (default: openid, profile and email).
+func OpenIDConnectLoginOptionScopes(scopes ...string) OpenIDConnectLoginOption {
+	return func(l *OpenIDConnectLogin) {
+		l.scopes = scopes
+	}
+}
+
+// OpenIDConnectLoginOptionRedirectURI sets the redirect URI sent to Slack. It must match one of
+// the redirect URLs of the app's configuration, and be served by OpenIDConnectLogin.RedirectHandler.
+func OpenIDConnectLoginOptionRedirectURI(uri string) OpenIDConnectLoginOption {
+	return func(l *OpenIDConnectLogin) {
+		l.redirectURI = uri
+	}
+}
+
+// OpenIDConnectLoginOptionTeam restricts the sign in to a workspace.
+func OpenIDConnectLoginOptionTeam(teamID string) OpenIDConnectLoginOption {
+	return func(l *OpenIDConnectLogin) {
+		l.teamID = teamID
+	}
+}
+
+// OpenIDConnectLoginOptionStateStore sets the store of the states (default: in memory, 10 minutes).
+func OpenIDConnectLoginOptionStateStore(store OAuthStateStore) OpenIDConnectLoginOption {
+	return func(l *OpenIDConnectLogin) {
+		l.stateStore = store
+	}
+}
+
+// OpenIDConnectLoginOptionKeySource sets the source of the keys verifying the id_tokens
+// (default: Slack's JSON Web Key Set).
+func OpenIDConnectLoginOptionKeySource(keys IDTokenKeySource) OpenIDConnectLoginOption {
+	return func(l *OpenIDConnectLogin) {
+		l.keys = keys
+	}
+}
+
+// OpenIDConnectLoginOptionSuccessHandler sets the callback answering the redirects of the
+// successful sign ins, e.g. to start a session. The default one renders a short page.
+func OpenIDConnectLoginOptionSuccessHandler(f func(w http.ResponseWriter, r *http.Request, claims *IDTokenClaims, resp *OpenIDConnectResponse)) OpenIDConnectLoginOption {
+	return func(l *OpenIDConnectLogin) {
+		l.onSuccess = f
+	}
+}
+
+// OpenIDConnectLoginOptionErrorHandler sets the callback answering the failed sign ins.
+// err is an OAuthRedirectError when the user cancelled, ErrOAuthStateInvalid when the state
+// cannot be verified, an error wrapping ErrInvalidIDToken when the id_token is invalid,
+// or the error of the code exchange.
+func OpenIDConnectLoginOptionErrorHandler(f func(w http.ResponseWriter, r *http.Request, err error)) OpenIDConnectLoginOption {
+	return func(l *OpenIDConnectLogin) {
+		l.onError = f
+	}
+}
+
+// OpenIDConnectLoginOptionHTTPClient sets the HTTP client exchanging the codes.
+func OpenIDConnectLoginOptionHTTPClient(client httpClient) OpenIDConnectLoginOption {
+	return func(l *OpenIDConnectLogin) {
+		l.httpclient = client
+	}
+}
+
+// OpenIDConnectLoginOptionAPIURL sets the Web API URL codes are exchanged with.
+func OpenIDConnectLoginOptionAPIURL(u string) OpenIDConnectLoginOption {
+	return func(l *OpenIDConnectLogin) {
+		l.apiURL = u
+	}
+}
+
+// OpenIDConnectLogin implements Sign in with Slack: LoginHandler sends the users to Slack,
+// and RedirectHandler exchanges the code Slack redirects them back with for an id_token,
+// which is verified before the sign in succeeds.
+//
+// Both the state and the nonce are bound to the browser with cookies, and can only be used once.
+//
+// See: https://api.slack.com/authentication/sign-in-with-slack
+type OpenIDConnectLogin struct {
+	clientID     string
+	clientSecret string
+
+	scopes      []string
+	redirectURI string
+	teamID      string
+	stateStore  OAuthStateStore
+	keys        IDTokenKeySource
+	onSuccess   func(w http.ResponseWriter, r *http.Request, claims *IDTokenClaims, resp *OpenIDConnectResponse)
+	onError     func(w http.ResponseWriter, r *http.Request, err error)
+	httpclient  httpClient
+	apiURL      string
+
+	verifier *IDTokenVerifier
+}
+
+// NewOpenIDConnectLogin returns an OpenIDConnectLogin for the app with the given credentials.
+func NewOpenIDConnectLogin(clientID, clientSecret string, options ...OpenIDConnectLoginOption) *OpenIDConnectLogin {
+	l := &OpenIDConnectLogin{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		scopes:       []string{"openid", "profile", "email"},
+		stateStore:   NewMemoryOAuthStateStore(0),
+		onSuccess:    defaultOpenIDConnectSuccess,
+		onError:      defaultOAuthError,
+		httpclient:   &http.Client{},
+		apiURL:       APIURL,
+	}
+
+	for _, opt := range options {
+		opt(l)
+	}
+
+	if l.keys == nil {
+		l.keys = NewJWKSKeySource("", l.httpclient)
+	}
+	l.verifier = NewIDTokenVerifier(clientID, l.keys)
+
+	return l
+}
+
+// AuthorizeURL returns the URL sending the users to Slack to sign in with state and nonce.
+func (l *OpenIDConnectLogin) AuthorizeURL(state, nonce string) string {
+	values := url.Values{
+		"response_type": {"code"},
+		"client_id":     {l.clientID},
+		"scope":         {strings.Join(l.scopes, " ")},
+		"state":         {state},
+		"nonce":         {nonce},
+	}
+	if l.redirectURI != "" {
+		values.Set("redirect_uri", l.redirectURI)
+	}
+	if l.teamID != "" {
+		values.Set("team", l.teamID)
+	}
+
+	return OpenIDConnectAuthorizeURL + "?" + values.Encode()
+}
+
+// LoginHandler returns the handler starting the sign in: it issues a state and a nonce,
+// and redirects the users to Slack.
+func (l *OpenIDConnectLogin) LoginHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state, err := l.stateStore.Issue(r.Context())
+		if err != nil {
+			l.onError(w, r, err)
+			return
+		}
+
+		nonce, err := randomToken()
+		if err != nil {
+			l.onError(w, r, err)
+			return
+		}
+
+		secure := r.TLS != nil || strings.HasPrefix(l.redirectURI, "https://")
+		setOAuthCookie(w, openIDConnectStateCookie, state, secure)
+		setOAuthCookie(w, openIDConnectNonceCookie, nonce, secure)
+		http.Redirect(w, r, l.AuthorizeURL(state, nonce), http.StatusFound)
+	})
+}
+
+// RedirectHandler returns the handler completing the sign in: it verifies the state,
+// exchanges the code, and verifies the id_token.
+func (l *OpenIDConnectLogin) RedirectHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, resp, err := l.complete(r)
+
+		// The state and the nonce cannot be used anymore, in any case.
+		clearOAuthCookie(w, openIDConnectStateCookie)
+		clearOAuthCookie(w, openIDConnectNonceCookie)
+
+		if err != nil {
+			l.onError(w, r, err)
+			return
+		}
+
+		l.onSuccess(w, r, claims, resp)
+	})
+}
+
+func (l *OpenIDConnectLogin) complete(r *http.Request) (*IDTokenClaims, *OpenIDConnectResponse, error) {
+	query := r.URL.Query()
+	if code := query.Get("error"); code != "" {
+		return nil, nil, OAuthRedirectError{Code: code}
+	}
+
+	if err := verifyOAuthState(r, l.stateStore, openIDConnectStateCookie); err != nil {
+		return nil, nil, err
+	}
+
+	nonce, err := r.Cookie(openIDConnectNonceCookie)
+	if err != nil || nonce.Value == "" {
+		return nil, nil, ErrOAuthStateInvalid
+	}
+
+	values := url.Values{
+		"client_id":     {l.clientID},
+		"client_secret": {l.clientSecret},
+		"code":          {query.Get("code")},
+	}
+	if l.redirectURI != "" {
+		values.Set("redirect_uri", l.redirectURI)
+	}
+
+	resp, err := postOpenIDConnectToken(r.Context(), l.httpclient, l.apiURL, values)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	claims, err := l.verifier.Verify(r.Context(), resp.IdToken, nonce.Value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return claims, resp, nil
+}
+
+func defaultOpenIDConnectSuccess(w http.ResponseWriter, r *http.Request, claims *IDTokenClaims, resp *OpenIDConnectResponse) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "<html><body><p>Signed in as %s.</p></body></html>", html.EscapeString(claims.Name))
+}
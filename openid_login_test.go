@@ -0,0 +1,104 @@
+package slack
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestOpenIDConnectLogin(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The nonce of the current sign in, sent back in the id_token.
+	var nonce string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/openid.connect.token" || r.FormValue("code") != "good-code" {
+			w.Write([]byte(`{"ok":false,"error":"invalid_code"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":           true,
+			"access_token": "xoxp-1",
+			"token_type":   "Bearer",
+			"id_token":     newTestIDToken(t, key, "k1", newTestIDTokenClaims(nonce)),
+		})
+	}))
+	defer api.Close()
+
+	var (
+		signedIn *IDTokenClaims
+		errs     []error
+	)
+	login := NewOpenIDConnectLogin("client", "secret",
+		OpenIDConnectLoginOptionRedirectURI("https://example.com/slack/signin"),
+		OpenIDConnectLoginOptionKeySource(StaticIDTokenKeySource{"k1": &key.PublicKey}),
+		OpenIDConnectLoginOptionAPIURL(api.URL+"/"),
+		OpenIDConnectLoginOptionSuccessHandler(func(w http.ResponseWriter, r *http.Request, claims *IDTokenClaims, resp *OpenIDConnectResponse) {
+			signedIn = claims
+		}),
+		OpenIDConnectLoginOptionErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			errs = append(errs, err)
+		}),
+	)
+
+	start := func() (url.Values, []*http.Cookie) {
+		rec := httptest.NewRecorder()
+		login.LoginHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slack/login", nil))
+
+		location, err := url.Parse(rec.Header().Get("Location"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		query := location.Query()
+		if query.Get("scope") != "openid profile email" || query.Get("response_type") != "code" {
+			t.Errorf("unexpected authorize URL %s", location)
+		}
+
+		return query, rec.Result().Cookies()
+	}
+
+	redirect := func(query string, cookies []*http.Cookie) {
+		req := httptest.NewRequest(http.MethodGet, "/slack/signin?"+query, nil)
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		login.RedirectHandler().ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	query, cookies := start()
+	nonce = query.Get("nonce")
+	redirect("code=good-code&state="+query.Get("state"), cookies)
+
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+	if signedIn == nil || signedIn.UserID != "U1" {
+		t.Fatalf("unexpected claims %+v", signedIn)
+	}
+
+	// The id_token must carry the nonce of the browser.
+	query, cookies = start()
+	nonce = "another-nonce"
+	redirect("code=good-code&state="+query.Get("state"), cookies)
+
+	query, cookies = start()
+	redirect("code=good-code&state="+query.Get("state"), cookies[:1])
+
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	if !errors.Is(errs[0], ErrInvalidIDToken) {
+		t.Errorf("expected ErrInvalidIDToken, got %v", errs[0])
+	}
+	if errs[1] != ErrOAuthStateInvalid {
+		t.Errorf("expected ErrOAuthStateInvalid, got %v", errs[1])
+	}
+}
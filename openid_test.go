@@ -0,0 +1,183 @@
+package slack
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestIDToken signs claims with key the way Slack signs id_tokens.
+func newTestIDToken(t *testing.T, key *rsa.PrivateKey, kid string, claims interface{}) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid})
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func newTestIDTokenClaims(nonce string) map[string]interface{} {
+	now := time.Now()
+	return map[string]interface{}{
+		"iss":                       OpenIDConnectIssuer,
+		"aud":                       "client",
+		"exp":                       now.Add(time.Hour).Unix(),
+		"iat":                       now.Unix(),
+		"nonce":                     nonce,
+		"sub":                       "U1",
+		"https://slack.com/user_id": "U1",
+		"https://slack.com/team_id": "T1",
+		"email":                     "user@example.com",
+		"name":                      "User",
+	}
+}
+
+func getOpenIDConnectUserInfo(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Write([]byte(`{
+		"ok": true,
+		"sub": "U0R7JM",
+		"https://slack.com/user_id": "U0R7JM",
+		"https://slack.com/team_id": "T0R7GR",
+		"email": "krane@slack-corp.com",
+		"email_verified": true,
+		"date_email_verified": 1622128723,
+		"name": "krane",
+		"picture": "https://secure.gravatar.com/avatar/krane.png",
+		"given_name": "Bront",
+		"family_name": "Labradoodle",
+		"locale": "en-US",
+		"https://slack.com/team_name": "kraneflannel",
+		"https://slack.com/team_domain": "kraneflannel",
+		"https://slack.com/team_image_default": true
+	}`))
+}
+
+func TestGetOpenIDConnectUserInfo(t *testing.T) {
+	http.HandleFunc("/openid.connect.userInfo", getOpenIDConnectUserInfo)
+
+	once.Do(startServer)
+	api := New("testing-token", OptionAPIURL("http://"+serverAddr+"/"))
+
+	info, err := api.GetOpenIDConnectUserInfo()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if info.UserID != "U0R7JM" || info.TeamID != "T0R7GR" {
+		t.Errorf("Incorrect IDs %+v", info)
+	}
+	if info.Email != "krane@slack-corp.com" || !info.EmailVerified {
+		t.Errorf("Incorrect email %+v", info)
+	}
+	if info.TeamName != "kraneflannel" || !info.TeamImageDefault {
+		t.Errorf("Incorrect team %+v", info)
+	}
+}
+
+func TestIDTokenVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	v := NewIDTokenVerifier("client", StaticIDTokenKeySource{"k1": &key.PublicKey})
+
+	claims, err := v.Verify(context.Background(), newTestIDToken(t, key, "k1", newTestIDTokenClaims("n1")), "n1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if claims.UserID != "U1" || claims.TeamID != "T1" || claims.Email != "user@example.com" || claims.Name != "User" {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+
+	with := func(key string, value interface{}) map[string]interface{} {
+		claims := newTestIDTokenClaims("n1")
+		claims[key] = value
+		return claims
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"wrong key", newTestIDToken(t, other, "k1", newTestIDTokenClaims("n1"))},
+		{"unknown key", newTestIDToken(t, key, "k2", newTestIDTokenClaims("n1"))},
+		{"wrong nonce", newTestIDToken(t, key, "k1", newTestIDTokenClaims("n2"))},
+		{"wrong audience", newTestIDToken(t, key, "k1", with("aud", []string{"other"}))},
+		{"wrong issuer", newTestIDToken(t, key, "k1", with("iss", "https://example.com"))},
+		{"expired", newTestIDToken(t, key, "k1", with("exp", time.Now().Add(-time.Hour).Unix()))},
+		{"malformed", "not.a-token"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := v.Verify(context.Background(), test.token, "n1"); !errors.Is(err, ErrInvalidIDToken) {
+				t.Errorf("expected ErrInvalidIDToken, got %v", err)
+			}
+		})
+	}
+}
+
+func TestJWKSKeySource(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var fetches int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "k1",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	}))
+	defer ts.Close()
+
+	s := NewJWKSKeySource(ts.URL, nil)
+	for i := 0; i < 2; i++ {
+		got, err := s.Key(context.Background(), "k1")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !got.Equal(&key.PublicKey) {
+			t.Error("unexpected key")
+		}
+	}
+
+	if _, err := s.Key(context.Background(), "k2"); !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("expected ErrInvalidIDToken, got %v", err)
+	}
+	if fetches != 1 {
+		t.Errorf("expected the keys to be fetched once, got %d", fetches)
+	}
+}
@@ -0,0 +1,59 @@
+package scim
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Filter is a SCIM filter expression, e.g. `userName eq "bront"`, selecting the resources of a
+// listing. The helpers of this file build the expressions with the values properly quoted.
+type Filter string
+
+func compare(attr string, op string, value string) Filter {
+	// The values are JSON strings.
+	b, _ := json.Marshal(value)
+	return Filter(attr + " " + op + " " + string(b))
+}
+
+// Eq matches the resources whose attribute attr equals value.
+func Eq(attr string, value string) Filter {
+	return compare(attr, "eq", value)
+}
+
+// Co matches the resources whose attribute attr contains value.
+func Co(attr string, value string) Filter {
+	return compare(attr, "co", value)
+}
+
+// Sw matches the resources whose attribute attr starts with value.
+func Sw(attr string, value string) Filter {
+	return compare(attr, "sw", value)
+}
+
+// Pr matches the resources having a value for the attribute attr.
+func Pr(attr string) Filter {
+	return Filter(attr + " pr")
+}
+
+func join(op string, filters []Filter) Filter {
+	parts := make([]string, 0, len(filters))
+	for _, f := range filters {
+		if f != "" {
+			parts = append(parts, "("+string(f)+")")
+		}
+	}
+	if len(parts) == 1 {
+		return Filter(strings.TrimSuffix(strings.TrimPrefix(parts[0], "("), ")"))
+	}
+	return Filter(strings.Join(parts, " "+op+" "))
+}
+
+// And matches the resources matched by all the filters.
+func And(filters ...Filter) Filter {
+	return join("and", filters)
+}
+
+// Or matches the resources matched by any of the filters.
+func Or(filters ...Filter) Filter {
+	return join("or", filters)
+}
@@ -0,0 +1,104 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+)
+
+// Member is a member of a Group.
+type Member struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// Group is a SCIM group, an IDP group in Slack.
+type Group struct {
+	Schemas     []string `json:"schemas,omitempty"`
+	ID          string   `json:"id,omitempty"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Members     []Member `json:"members,omitempty"`
+	Meta        *Meta    `json:"meta,omitempty"`
+}
+
+func (g Group) withSchemas() Group {
+	if len(g.Schemas) == 0 {
+		g.Schemas = []string{SchemaGroup}
+	}
+	return g
+}
+
+// GroupList is a page of groups.
+type GroupList struct {
+	ListResponse
+	Resources []Group `json:"Resources"`
+}
+
+// GetGroup returns the group id.
+func (c *Client) GetGroup(ctx context.Context, id string) (*Group, error) {
+	group := &Group{}
+	if err := c.do(ctx, http.MethodGet, resourcePath("Groups", id), nil, nil, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// ListGroups returns a page of the groups matching params.Filter, if any.
+func (c *Client) ListGroups(ctx context.Context, params ListParams) (*GroupList, error) {
+	list := &GroupList{}
+	if err := c.do(ctx, http.MethodGet, "Groups", params.values(), nil, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ListAllGroups returns all the groups matching filter, if any, waiting for the rate limits.
+func (c *Client) ListAllGroups(ctx context.Context, filter Filter) ([]Group, error) {
+	var groups []Group
+	params := ListParams{Filter: filter}
+	for {
+		list, err := c.ListGroups(ctx, params)
+		if err != nil {
+			if waitRateLimit(ctx, err) {
+				continue
+			}
+			return nil, err
+		}
+
+		groups = append(groups, list.Resources...)
+		if params.StartIndex = list.next(len(list.Resources)); params.StartIndex == 0 {
+			return groups, nil
+		}
+	}
+}
+
+// CreateGroup creates a group, and returns it.
+func (c *Client) CreateGroup(ctx context.Context, group Group) (*Group, error) {
+	created := &Group{}
+	if err := c.do(ctx, http.MethodPost, "Groups", nil, group.withSchemas(), created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// ReplaceGroup replaces the name and the members of the group id, and returns it.
+func (c *Client) ReplaceGroup(ctx context.Context, id string, group Group) (*Group, error) {
+	replaced := &Group{}
+	if err := c.do(ctx, http.MethodPut, resourcePath("Groups", id), nil, group.withSchemas(), replaced); err != nil {
+		return nil, err
+	}
+	return replaced, nil
+}
+
+// PatchGroup updates the name or the members of the group id, e.g.
+//
+//	client.PatchGroup(ctx, id, scim.PatchAdd("members", []scim.Member{{Value: "U1"}}))
+//
+// Slack does not return the group.
+func (c *Client) PatchGroup(ctx context.Context, id string, ops ...PatchOperation) error {
+	return c.do(ctx, http.MethodPatch, resourcePath("Groups", id), nil, newPatchRequest(ops), nil)
+}
+
+// DeleteGroup deletes the group id.
+func (c *Client) DeleteGroup(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, resourcePath("Groups", id), nil, nil, nil)
+}
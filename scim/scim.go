@@ -0,0 +1,268 @@
+// Package scim is a client of the SCIM API of Slack, provisioning the users and the groups
+// of a workspace or of an Enterprise Grid organisation, usually from an identity provider.
+//
+// The requests are sent with the HTTP client, the token and the logger of a slack.Client:
+//
+//	api := slack.New("xoxp-...")
+//	client := scim.New(api)
+//	users, err := client.ListAllUsers(ctx, scim.Eq("userName", "bront"))
+//
+// See: https://api.slack.com/admins/scim2
+package scim
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// APIURL is the URL of the version 2 of the SCIM API of Slack.
+const APIURL = "https://api.slack.com/scim/v2/"
+
+const (
+	SchemaUser           = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup          = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaEnterpriseUser = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	SchemaSlackGuest     = "urn:ietf:params:scim:schemas:extension:slack:guest:2.0:User"
+	SchemaPatchOp        = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaListResponse   = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaError          = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// Client is a client of the SCIM API.
+type Client struct {
+	api      *slack.Client
+	endpoint string
+}
+
+// Option is an option of a Client.
+type Option func(*Client)
+
+// OptionAPIURL sets the URL of the SCIM API. Only useful for testing.
+func OptionAPIURL(u string) Option {
+	return func(c *Client) { c.endpoint = u }
+}
+
+// New returns a Client sending its requests with api, whose token must be the token of an
+// admin with the admin scope.
+func New(api *slack.Client, options ...Option) *Client {
+	c := &Client{
+		api:      api,
+		endpoint: APIURL,
+	}
+
+	for _, opt := range options {
+		opt(c)
+	}
+
+	return c
+}
+
+// Error is an error returned by the SCIM API.
+type Error struct {
+	StatusCode int
+	// ScimType is the SCIM error type, e.g. "uniqueness", if any.
+	ScimType string
+	Detail   string
+}
+
+func (e *Error) Error() string {
+	msg := "scim: " + strconv.Itoa(e.StatusCode)
+	if e.ScimType != "" {
+		msg += " " + e.ScimType
+	}
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
+// HTTPStatusCode returns the HTTP status code of the response.
+func (e *Error) HTTPStatusCode() int {
+	return e.StatusCode
+}
+
+// decodeError decodes the error of a failed response. Both the SCIM 2.0 errors and the
+// errors of the version 1 of the API of Slack are supported.
+func decodeError(resp *http.Response) error {
+	serr := &Error{StatusCode: resp.StatusCode}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var v struct {
+		ScimType string `json:"scimType"`
+		Detail   string `json:"detail"`
+		Errors   *struct {
+			Description string `json:"description"`
+		} `json:"Errors"`
+	}
+	if err := json.Unmarshal(body, &v); err != nil {
+		serr.Detail = strings.TrimSpace(resp.Status)
+		return serr
+	}
+
+	serr.ScimType = v.ScimType
+	serr.Detail = v.Detail
+	if v.Errors != nil && serr.Detail == "" {
+		serr.Detail = v.Errors.Description
+	}
+
+	return serr
+}
+
+// do sends a request to the SCIM API, and decodes the response into dst.
+func (c *Client) do(ctx context.Context, method string, path string, query url.Values, body interface{}, dst interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reqBody)
+	if err != nil {
+		return err
+	}
+	if len(query) > 0 {
+		req.URL.RawQuery = query.Encode()
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.api.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return decodeError(resp)
+	}
+
+	if dst == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(dst)
+}
+
+// waitRateLimit waits until a rate limited request can be retried. It returns false when
+// err is another error, or when ctx is done first.
+func waitRateLimit(ctx context.Context, err error) bool {
+	var rlerr *slack.RateLimitedError
+	if !errors.As(err, &rlerr) {
+		return false
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(rlerr.RetryAfter):
+		return true
+	}
+}
+
+// ListParams are the parameters of the requests listing resources.
+type ListParams struct {
+	Filter Filter
+	// StartIndex is the 1-based index of the first resource.
+	StartIndex int
+	Count      int
+}
+
+func (p ListParams) values() url.Values {
+	values := url.Values{}
+	if p.Filter != "" {
+		values.Set("filter", string(p.Filter))
+	}
+	if p.StartIndex != 0 {
+		values.Set("startIndex", strconv.Itoa(p.StartIndex))
+	}
+	if p.Count != 0 {
+		values.Set("count", strconv.Itoa(p.Count))
+	}
+	return values
+}
+
+// ListResponse is the page of a listing.
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	StartIndex   int      `json:"startIndex"`
+}
+
+// next returns the start index of the next page, or 0 for the last page.
+func (r ListResponse) next(n int) int {
+	if n == 0 {
+		return 0
+	}
+
+	start := r.StartIndex
+	if start == 0 {
+		start = 1
+	}
+	if start+n > r.TotalResults {
+		return 0
+	}
+	return start + n
+}
+
+// Meta are the metadata of a resource.
+type Meta struct {
+	Created  string `json:"created,omitempty"`
+	Location string `json:"location,omitempty"`
+}
+
+// PatchOperation is an operation of a PATCH request.
+type PatchOperation struct {
+	// Op is "add", "replace" or "remove".
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// PatchAdd returns an operation adding value at path, e.g. members to a group.
+func PatchAdd(path string, value interface{}) PatchOperation {
+	return PatchOperation{Op: "add", Path: path, Value: value}
+}
+
+// PatchReplace returns an operation replacing the value at path.
+func PatchReplace(path string, value interface{}) PatchOperation {
+	return PatchOperation{Op: "replace", Path: path, Value: value}
+}
+
+// PatchRemove returns an operation removing the value at path, e.g. `members[value eq "U1"]`.
+func PatchRemove(path string) PatchOperation {
+	return PatchOperation{Op: "remove", Path: path}
+}
+
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+func newPatchRequest(ops []PatchOperation) patchRequest {
+	return patchRequest{Schemas: []string{SchemaPatchOp}, Operations: ops}
+}
+
+func resourcePath(resource string, id string) string {
+	return fmt.Sprintf("%s/%s", resource, url.PathEscape(id))
+}
@@ -0,0 +1,165 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func newTestClient(t *testing.T, mux *http.ServeMux) *Client {
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	return New(slack.New("xoxp-1"), OptionAPIURL(ts.URL+"/"))
+}
+
+func TestListAllUsers(t *testing.T) {
+	var requests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/Users", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("Authorization") != "Bearer xoxp-1" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Query().Get("filter") != `userName sw "b"` {
+			t.Errorf("unexpected filter %q", r.URL.Query().Get("filter"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case requests == 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case r.URL.Query().Get("startIndex") == "":
+			w.Write([]byte(`{"totalResults":2,"itemsPerPage":1,"startIndex":1,"Resources":[{"id":"U1","userName":"bront","active":true,"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User":{"department":"Sales"}}]}`))
+		case r.URL.Query().Get("startIndex") == "2":
+			w.Write([]byte(`{"totalResults":2,"itemsPerPage":1,"startIndex":2,"Resources":[{"id":"U2","userName":"brent","urn:ietf:params:scim:schemas:extension:slack:guest:2.0:User":{"type":"multi"}}]}`))
+		default:
+			t.Errorf("unexpected startIndex %q", r.URL.Query().Get("startIndex"))
+		}
+	})
+	client := newTestClient(t, mux)
+
+	users, err := client.ListAllUsers(context.Background(), Sw("userName", "b"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	active := true
+	expected := []User{
+		{ID: "U1", UserName: "bront", Active: &active, EnterpriseUser: &EnterpriseUser{Department: "Sales"}},
+		{ID: "U2", UserName: "brent", SlackGuest: &SlackGuest{Type: "multi"}},
+	}
+	if !reflect.DeepEqual(users, expected) {
+		t.Errorf("expected %+v, got %+v", expected, users)
+	}
+	if requests != 3 {
+		t.Errorf("expected the rate limited request to be retried, got %d requests", requests)
+	}
+}
+
+func TestCreateUser(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/Users", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+
+		var user map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+			t.Fatal(err)
+		}
+		schemas := []interface{}{SchemaUser, SchemaEnterpriseUser}
+		if !reflect.DeepEqual(user["schemas"], schemas) {
+			t.Errorf("expected schemas %v, got %v", schemas, user["schemas"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"U1","userName":"bront"}`))
+	})
+	client := newTestClient(t, mux)
+
+	user, err := client.CreateUser(context.Background(), User{
+		UserName:       "bront",
+		Emails:         []MultiValued{{Value: "bront@example.com", Primary: true}},
+		EnterpriseUser: &EnterpriseUser{EmployeeNumber: "42"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if user.ID != "U1" {
+		t.Errorf("expected U1, got %s", user.ID)
+	}
+}
+
+func TestPatchGroup(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/Groups/S1", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		expected := `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"add","path":"members","value":[{"value":"U1"}]},{"op":"remove","path":"members[value eq \"U2\"]"}]}`
+		if string(b) != expected {
+			t.Errorf("expected %s, got %s", expected, b)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client := newTestClient(t, mux)
+
+	err := client.PatchGroup(context.Background(), "S1",
+		PatchAdd("members", []Member{{Value: "U1"}}),
+		PatchRemove(`members[value eq "U2"]`),
+	)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected Error
+	}{
+		{"scim2", `{"schemas":["urn:ietf:params:scim:api:messages:2.0:Error"],"status":"409","scimType":"uniqueness","detail":"username taken"}`, Error{StatusCode: 409, ScimType: "uniqueness", Detail: "username taken"}},
+		{"v1", `{"Errors":{"description":"username taken","code":409}}`, Error{StatusCode: 409, Detail: "username taken"}},
+		{"html", `<html></html>`, Error{StatusCode: 409, Detail: "409 Conflict"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/Users/U1", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(test.body))
+			})
+			client := newTestClient(t, mux)
+
+			_, err := client.ReplaceUser(context.Background(), "U1", User{UserName: "bront"})
+			var serr *Error
+			if !errors.As(err, &serr) {
+				t.Fatalf("expected a *Error, got %v", err)
+			}
+			if *serr != test.expected {
+				t.Errorf("expected %+v, got %+v", test.expected, *serr)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	f := And(Eq("userName", `br"ont`), Or(Pr("title"), Co("emails.value", "@example.com")))
+	expected := `(userName eq "br\"ont") and ((title pr) or (emails.value co "@example.com"))`
+	if string(f) != expected {
+		t.Errorf("expected %s, got %s", expected, f)
+	}
+
+	if f := And(Eq("active", "true")); string(f) != `active eq "true"` {
+		t.Errorf("unexpected filter %s", f)
+	}
+}
@@ -0,0 +1,184 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+)
+
+// Name is the name of a User.
+type Name struct {
+	GivenName       string `json:"givenName,omitempty"`
+	FamilyName      string `json:"familyName,omitempty"`
+	Formatted       string `json:"formatted,omitempty"`
+	HonorificPrefix string `json:"honorificPrefix,omitempty"`
+}
+
+// MultiValued is a value of a multi-valued attribute of a User, e.g. an email or a photo.
+type MultiValued struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Display string `json:"display,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// Address is an address of a User.
+type Address struct {
+	StreetAddress string `json:"streetAddress,omitempty"`
+	Locality      string `json:"locality,omitempty"`
+	Region        string `json:"region,omitempty"`
+	PostalCode    string `json:"postalCode,omitempty"`
+	Country       string `json:"country,omitempty"`
+	Primary       bool   `json:"primary,omitempty"`
+}
+
+// GroupRef is a group a User is a member of.
+type GroupRef struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// Manager is the manager of a User.
+type Manager struct {
+	ManagerID string `json:"managerId,omitempty"`
+}
+
+// EnterpriseUser are the attributes of the enterprise extension of a User.
+type EnterpriseUser struct {
+	EmployeeNumber string   `json:"employeeNumber,omitempty"`
+	CostCenter     string   `json:"costCenter,omitempty"`
+	Organization   string   `json:"organization,omitempty"`
+	Division       string   `json:"division,omitempty"`
+	Department     string   `json:"department,omitempty"`
+	Manager        *Manager `json:"manager,omitempty"`
+}
+
+// SlackGuest are the attributes of the guest extension of Slack, making a User a guest.
+type SlackGuest struct {
+	// Type is "multi" for a multi-channel guest, or "single" for a single-channel guest.
+	Type string `json:"type"`
+	// Expiration is the ISO 8601 date the guest account expires, if any.
+	Expiration string `json:"expiration,omitempty"`
+}
+
+// User is a SCIM user.
+type User struct {
+	Schemas           []string      `json:"schemas,omitempty"`
+	ID                string        `json:"id,omitempty"`
+	ExternalID        string        `json:"externalId,omitempty"`
+	UserName          string        `json:"userName,omitempty"`
+	NickName          string        `json:"nickName,omitempty"`
+	Name              *Name         `json:"name,omitempty"`
+	DisplayName       string        `json:"displayName,omitempty"`
+	ProfileURL        string        `json:"profileUrl,omitempty"`
+	Title             string        `json:"title,omitempty"`
+	Timezone          string        `json:"timezone,omitempty"`
+	UserType          string        `json:"userType,omitempty"`
+	PreferredLanguage string        `json:"preferredLanguage,omitempty"`
+	Locale            string        `json:"locale,omitempty"`
+	Active            *bool         `json:"active,omitempty"`
+	Emails            []MultiValued `json:"emails,omitempty"`
+	PhoneNumbers      []MultiValued `json:"phoneNumbers,omitempty"`
+	Photos            []MultiValued `json:"photos,omitempty"`
+	Roles             []MultiValued `json:"roles,omitempty"`
+	Addresses         []Address     `json:"addresses,omitempty"`
+	// Groups is read-only: the members of the groups are updated with PatchGroup.
+	Groups         []GroupRef      `json:"groups,omitempty"`
+	EnterpriseUser *EnterpriseUser `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	SlackGuest     *SlackGuest     `json:"urn:ietf:params:scim:schemas:extension:slack:guest:2.0:User,omitempty"`
+	Meta           *Meta           `json:"meta,omitempty"`
+}
+
+// withSchemas returns a copy of u declaring the schemas of its attributes, when it does not
+// declare any.
+func (u User) withSchemas() User {
+	if len(u.Schemas) > 0 {
+		return u
+	}
+
+	u.Schemas = []string{SchemaUser}
+	if u.EnterpriseUser != nil {
+		u.Schemas = append(u.Schemas, SchemaEnterpriseUser)
+	}
+	if u.SlackGuest != nil {
+		u.Schemas = append(u.Schemas, SchemaSlackGuest)
+	}
+	return u
+}
+
+// UserList is a page of users.
+type UserList struct {
+	ListResponse
+	Resources []User `json:"Resources"`
+}
+
+// GetUser returns the user id.
+func (c *Client) GetUser(ctx context.Context, id string) (*User, error) {
+	user := &User{}
+	if err := c.do(ctx, http.MethodGet, resourcePath("Users", id), nil, nil, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ListUsers returns a page of the users matching params.Filter, if any.
+func (c *Client) ListUsers(ctx context.Context, params ListParams) (*UserList, error) {
+	list := &UserList{}
+	if err := c.do(ctx, http.MethodGet, "Users", params.values(), nil, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ListAllUsers returns all the users matching filter, if any, waiting for the rate limits.
+func (c *Client) ListAllUsers(ctx context.Context, filter Filter) ([]User, error) {
+	var users []User
+	params := ListParams{Filter: filter}
+	for {
+		list, err := c.ListUsers(ctx, params)
+		if err != nil {
+			if waitRateLimit(ctx, err) {
+				continue
+			}
+			return nil, err
+		}
+
+		users = append(users, list.Resources...)
+		if params.StartIndex = list.next(len(list.Resources)); params.StartIndex == 0 {
+			return users, nil
+		}
+	}
+}
+
+// CreateUser creates a user, and returns it. The schemas of user are set from its attributes
+// when it does not declare any.
+func (c *Client) CreateUser(ctx context.Context, user User) (*User, error) {
+	created := &User{}
+	if err := c.do(ctx, http.MethodPost, "Users", nil, user.withSchemas(), created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// ReplaceUser replaces all the attributes of the user id, and returns it. The attributes
+// missing from user are removed.
+func (c *Client) ReplaceUser(ctx context.Context, id string, user User) (*User, error) {
+	replaced := &User{}
+	if err := c.do(ctx, http.MethodPut, resourcePath("Users", id), nil, user.withSchemas(), replaced); err != nil {
+		return nil, err
+	}
+	return replaced, nil
+}
+
+// PatchUser updates some attributes of the user id, and returns it.
+func (c *Client) PatchUser(ctx context.Context, id string, ops ...PatchOperation) (*User, error) {
+	patched := &User{}
+	if err := c.do(ctx, http.MethodPatch, resourcePath("Users", id), nil, newPatchRequest(ops), patched); err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
+
+// DeleteUser deactivates the user id.
+func (c *Client) DeleteUser(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, resourcePath("Users", id), nil, nil, nil)
+}
@@ -0,0 +1,235 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// signatureMaxAge is how long a request signature is accepted, see NewSecretsVerifier.
+	signatureMaxAge = 5 * time.Minute
+
+	defaultVerifierMaxBodyBytes = 1 << 20
+)
+
+type signingSecretContextKey struct{}
+
+// VerifiedSigningSecret returns the signing secret the request was verified with
+// by a SignatureVerifier middleware.
+func VerifiedSigningSecret(ctx context.Context) (string, bool) {
+	secret, ok := ctx.Value(signingSecretContextKey{}).(string)
+	return secret, ok
+}
+
+// NonceStore remembers the signatures of the requests already received, so that
+// a SignatureVerifier can reject the requests replayed within the validity window.
+type NonceStore interface {
+	// Add records nonce until expiresAt, and reports whether it was not already recorded.
+	Add(nonce string, expiresAt time.Time) bool
+}
+
+// MemoryNonceStore is a NonceStore keeping the nonces in memory. It is only suited to apps
+// running a single instance: a replicated app needs a store shared by its instances.
+type MemoryNonceStore struct {
+	mu        sync.Mutex
+	nonces    map[string]time.Time
+	lastSweep time.Time
+
+	now func() time.Time
+}
+
+// NewMemoryNonceStore returns an empty MemoryNonceStore.
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{
+		nonces: make(map[string]time.Time),
+		now:    time.Now,
+	}
+}
+
+// Add implements NonceStore.
+func (s *MemoryNonceStore) Add(nonce string, expiresAt time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) > time.Minute {
+		for n, exp := range s.nonces {
+			if !now.Before(exp) {
+				delete(s.nonces, n)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	if exp, ok := s.nonces[nonce]; ok && now.Before(exp) {
+		return false
+	}
+
+	s.nonces[nonce] = expiresAt
+	return true
+}
+
+// Len returns the number of nonces held by the store, expired ones included.
+func (s *MemoryNonceStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.nonces)
+}
+
+// SignatureVerifierOption configures a SignatureVerifier.
+type SignatureVerifierOption func(*SignatureVerifier)
+
+// SignatureVerifierOptionNonceStore rejects the requests whose signature was already seen.
+func SignatureVerifierOptionNonceStore(store NonceStore) SignatureVerifierOption {
+	return func(v *SignatureVerifier) {
+		v.nonces = store
+	}
+}
+
+// SignatureVerifierOptionMaxBodyBytes limits the size of the request bodies (default: 1MiB).
+func SignatureVerifierOptionMaxBodyBytes(n int64) SignatureVerifierOption {
+	return func(v *SignatureVerifier) {
+		v.maxBodyBytes = n
+	}
+}
+
+// SignatureVerifierOptionErrorHandler sets a callback for the requests that are rejected.
+func SignatureVerifierOptionErrorHandler(f func(r *http.Request, err error)) SignatureVerifierOption {
+	return func(v *SignatureVerifier) {
+		v.onError = f
+	}
+}
+
+// SignatureVerifierOptionDebug logs the signatures that do not match.
+func SignatureVerifierOptionDebug(d Debug) SignatureVerifierOption {
+	return func(v *SignatureVerifier) {
+		v.debug = d
+	}
+}
+
+// SignatureVerifier verifies that the requests to an app come from Slack. Unlike a
+// SecretsVerifier, it accepts several signing secrets, which allows rotating the signing
+// secret without downtime, and can reject replayed requests.
+//
+// See: https://api.slack.com/authentication/verifying-requests-from-slack
+type SignatureVerifier struct {
+	mu      sync.RWMutex
+	secrets []string
+
+	nonces       NonceStore
+	maxBodyBytes int64
+	onError      func(r *http.Request, err error)
+	debug        Debug
+}
+
+// NewSignatureVerifier returns a SignatureVerifier accepting the requests signed with any of secrets.
+func NewSignatureVerifier(secrets []string, options ...SignatureVerifierOption) *SignatureVerifier {
+	v := &SignatureVerifier{
+		maxBodyBytes: defaultVerifierMaxBodyBytes,
+	}
+	v.SetSecrets(secrets...)
+
+	for _, opt := range options {
+		opt(v)
+	}
+
+	return v
+}
+
+// SetSecrets replaces the set of accepted signing secrets, e.g. to retire the previous
+// secret once a rotation is complete.
+func (v *SignatureVerifier) SetSecrets(secrets ...string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.secrets = append([]string(nil), secrets...)
+}
+
+// Verify reads the body of r and verifies its signature. It returns the body and the
+// secret matching the signature.
+func (v *SignatureVerifier) Verify(w http.ResponseWriter, r *http.Request) (body []byte, secret string, err error) {
+	v.mu.RLock()
+	secrets := v.secrets
+	v.mu.RUnlock()
+
+	if len(secrets) == 0 {
+		return nil, "", ErrInvalidConfiguration
+	}
+
+	// Check the headers and the timestamp before reading the body.
+	if _, err = NewSecretsVerifier(r.Header, secrets[0]); err != nil {
+		return nil, "", err
+	}
+
+	if body, err = io.ReadAll(http.MaxBytesReader(w, r.Body, v.maxBodyBytes)); err != nil {
+		return nil, "", err
+	}
+
+	for _, s := range secrets {
+		sv, err := NewSecretsVerifier(r.Header, s)
+		if err != nil {
+			return nil, "", err
+		}
+		sv.WithDebug(v.debug)
+
+		if _, err = sv.Write(body); err != nil {
+			return nil, "", err
+		}
+		if sv.Ensure() == nil {
+			secret = s
+			break
+		}
+	}
+
+	if secret == "" {
+		return nil, "", ErrInvalidSignature
+	}
+
+	if v.nonces != nil {
+		timestamp, _ := strconv.ParseInt(r.Header.Get(hTimestamp), 10, 64)
+		if !v.nonces.Add(r.Header.Get(hSignature), time.Unix(timestamp, 0).Add(signatureMaxAge)) {
+			return nil, "", ErrReplayedRequest
+		}
+	}
+
+	return body, secret, nil
+}
+
+// Middleware returns a handler verifying the requests before calling next. The requests that
+// cannot be verified are answered with 401 Unauthorized, or 413 Request Entity Too Large.
+//
+// The body of the verified requests is restored for next to read it, and the matching secret
+// is available from the request's context with VerifiedSigningSecret.
+func (v *SignatureVerifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, secret, err := v.Verify(w, r)
+		if err != nil {
+			if v.onError != nil {
+				v.onError(r, err)
+			}
+
+			var tooLarge *http.MaxBytesError
+			switch {
+			case errors.As(err, &tooLarge):
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+			case errors.Is(err, ErrInvalidConfiguration):
+				w.WriteHeader(http.StatusInternalServerError)
+			default:
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), signingSecretContextKey{}, secret)))
+	})
+}
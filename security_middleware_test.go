@@ -0,0 +1,132 @@
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newSignedTestRequest(secret, body string, ts time.Time) *http.Request {
+	stimestamp := strconv.FormatInt(ts.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%s:%s", stimestamp, body)
+
+	req := httptest.NewRequest(http.MethodPost, "/slack/events", strings.NewReader(body))
+	req.Header.Set(hTimestamp, stimestamp)
+	req.Header.Set(hSignature, "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestSignatureVerifierMiddleware(t *testing.T) {
+	const oldSecret, newSecret = "old-secret", "new-secret"
+
+	var (
+		gotBody   string
+		gotSecret string
+		errs      []error
+	)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		gotSecret, _ = VerifiedSigningSecret(r.Context())
+	})
+
+	v := NewSignatureVerifier([]string{newSecret, oldSecret},
+		SignatureVerifierOptionNonceStore(NewMemoryNonceStore()),
+		SignatureVerifierOptionMaxBodyBytes(64),
+		SignatureVerifierOptionErrorHandler(func(r *http.Request, err error) {
+			errs = append(errs, err)
+		}),
+	)
+	h := v.Middleware(next)
+
+	replayed := newSignedTestRequest(oldSecret, "payload=1", time.Now())
+
+	tests := []struct {
+		name       string
+		req        *http.Request
+		wantStatus int
+		wantSecret string
+		wantErr    error
+	}{
+		{"new secret", newSignedTestRequest(newSecret, "payload=0", time.Now()), http.StatusOK, newSecret, nil},
+		{"old secret", replayed, http.StatusOK, oldSecret, nil},
+		{"replayed", replayed.Clone(replayed.Context()), http.StatusUnauthorized, "", ErrReplayedRequest},
+		{"unknown secret", newSignedTestRequest("other", "payload=2", time.Now()), http.StatusUnauthorized, "", ErrInvalidSignature},
+		{"expired", newSignedTestRequest(newSecret, "payload=3", time.Now().Add(-10*time.Minute)), http.StatusUnauthorized, "", ErrExpiredTimestamp},
+		{"too large", newSignedTestRequest(newSecret, strings.Repeat("x", 65), time.Now()), http.StatusRequestEntityTooLarge, "", nil},
+	}
+
+	// The replayed request needs its own body.
+	tests[2].req.Body = io.NopCloser(strings.NewReader("payload=1"))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotBody, gotSecret, errs = "", "", nil
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, test.req)
+
+			if rec.Code != test.wantStatus {
+				t.Fatalf("status: expected %d, got %d", test.wantStatus, rec.Code)
+			}
+			if gotSecret != test.wantSecret {
+				t.Errorf("secret: expected %q, got %q", test.wantSecret, gotSecret)
+			}
+			if test.wantStatus == http.StatusOK && !strings.HasPrefix(gotBody, "payload=") {
+				t.Errorf("body was not restored, got %q", gotBody)
+			}
+			if test.wantErr != nil && (len(errs) != 1 || errs[0] != test.wantErr) {
+				t.Errorf("errors: expected %v, got %v", test.wantErr, errs)
+			}
+		})
+	}
+}
+
+func TestSignatureVerifier_SetSecrets(t *testing.T) {
+	v := NewSignatureVerifier([]string{"old-secret"})
+	v.SetSecrets("new-secret")
+
+	_, _, err := v.Verify(httptest.NewRecorder(), newSignedTestRequest("old-secret", "a=b", time.Now()))
+	if err != ErrInvalidSignature {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+
+	v.SetSecrets()
+	_, _, err = v.Verify(httptest.NewRecorder(), newSignedTestRequest("new-secret", "a=b", time.Now()))
+	if err != ErrInvalidConfiguration {
+		t.Errorf("expected ErrInvalidConfiguration, got %v", err)
+	}
+}
+
+func TestMemoryNonceStore(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	s := NewMemoryNonceStore()
+	s.now = func() time.Time { return now }
+
+	if !s.Add("a", now.Add(5*time.Minute)) {
+		t.Fatal("expected the first nonce to be added")
+	}
+	if s.Add("a", now.Add(5*time.Minute)) {
+		t.Fatal("expected the nonce to be rejected")
+	}
+
+	now = now.Add(6 * time.Minute)
+	if !s.Add("b", now.Add(5*time.Minute)) {
+		t.Fatal("expected a new nonce to be added")
+	}
+	if s.Len() != 1 {
+		t.Errorf("expected the expired nonce to be swept, got %d nonces", s.Len())
+	}
+	if !s.Add("a", now.Add(5*time.Minute)) {
+		t.Error("expected an expired nonce to be accepted again")
+	}
+}
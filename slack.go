@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"time"
 )
 
 const (
@@ -65,6 +66,13 @@ type Client struct {
 	debug              bool
 	log                ilogger
 	httpclient         httpClient
+	onAuthError        func(code string)
+
+	// configMus guards the configuration tokens, see configMutexes.
+	configMus            *configMutexes
+	configTokenExpiresAt time.Time
+	configTokenRotation  bool
+	onConfigTokenRotated func(ctx context.Context, resp *TokenResponse) error
 }
 
 // Option defines an option for a Client
@@ -111,6 +119,24 @@ func OptionConfigRefreshToken(token string) func(*Client) {
 	return func(c *Client) { c.configRefreshToken = token }
 }
 
+// OptionConfigTokenExpiresAt sets the expiration time of the configuration token of the client,
+// see OptionConfigTokenRotation.
+func OptionConfigTokenExpiresAt(t time.Time) func(*Client) {
+	return func(c *Client) { c.configTokenExpiresAt = t }
+}
+
+// OptionConfigTokenRotation enables the automatic rotation of the configuration token of the
+// client, which requires a configuration refresh token. The token is rotated before being used
+// when it is about to expire, and when the Web API reports that it expired. The rotated tokens
+// replace the ones of the client, and are passed to onRotate, when not nil, to be persisted:
+// the previous refresh token cannot be used anymore.
+func OptionConfigTokenRotation(onRotate func(ctx context.Context, resp *TokenResponse) error) func(*Client) {
+	return func(c *Client) {
+		c.configTokenRotation = true
+		c.onConfigTokenRotated = onRotate
+	}
+}
+
 // New builds a slack client from the provided token and options.
 func New(token string, options ...Option) *Client {
 	s := &Client{
@@ -124,6 +150,10 @@ func New(token string, options ...Option) *Client {
 		opt(s)
 	}
 
+	if s.onAuthError != nil {
+		s.httpclient = &authErrorWatcher{client: s.httpclient, onAuthError: s.onAuthError}
+	}
+
 	return s
 }
 
@@ -168,6 +198,33 @@ func (api *Client) postMethod(ctx context.Context, path string, values url.Value
 	return postForm(ctx, api.httpclient, api.endpoint+path, values, intf, api)
 }
 
+// Do sends an HTTP request to an API outside of the Web API, e.g. SCIM, with the HTTP client
+// of the client, authenticated with its token unless req already has an Authorization header.
+// A rate limited request returns a *RateLimitedError, and the responses of the failed requests
+// are logged when debugging is enabled. The caller must close the body of the response.
+func (api *Client) Do(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Authorization") == "" {
+		req.Header.Set("Authorization", "Bearer "+api.token)
+	}
+
+	api.Debugf("%s %s", req.Method, req.URL)
+	resp, err := api.httpclient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests && resp.Header.Get("Retry-After") != "" {
+		defer resp.Body.Close()
+		return nil, checkStatusCode(resp, api)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		logResponse(resp, api)
+	}
+
+	return resp, nil
+}
+
 // get a slack web method.
 func (api *Client) getMethod(ctx context.Context, path string, token string, values url.Values, intf interface{}) error {
 	return getResource(ctx, api.httpclient, api.endpoint+path, token, values, intf, api)
@@ -0,0 +1,86 @@
+package slackevents
+
+import (
+	"context"
+
+	"github.com/slack-go/slack"
+)
+
+// AdminAppRequest returns the request of the event as a slack.AdminAppRequest, e.g. to resolve
+// it with slack.Client.ProcessAdminAppRequest.
+func (e AppRequestedEvent) AdminAppRequest() slack.AdminAppRequest {
+	r := e.AppRequest
+	req := slack.AdminAppRequest{
+		ID:                 r.ID,
+		App:                slack.AdminApp(r.App),
+		PreviousResolution: slack.AdminAppRequestResolution{Status: r.PreviousResolution.Status},
+		User:               slack.AdminAppRequestUser(r.User),
+		Team:               slack.AdminAppRequestTeam(r.Team),
+		Message:            r.Message,
+	}
+
+	for _, scope := range r.PreviousResolution.Scopes {
+		req.PreviousResolution.Scopes = append(req.PreviousResolution.Scopes, slack.AdminAppScope(scope))
+	}
+	for _, scope := range r.Scopes {
+		req.Scopes = append(req.Scopes, slack.AdminAppScope(scope))
+	}
+
+	// The enterprise is decoded as a JSON object, and is null outside of Enterprise Grid.
+	if enterprise, ok := r.Enterprise.(map[string]interface{}); ok {
+		req.Enterprise = &slack.AdminAppRequestTeam{}
+		req.Enterprise.ID, _ = enterprise["id"].(string)
+		req.Enterprise.Name, _ = enterprise["name"].(string)
+		req.Enterprise.Domain, _ = enterprise["domain"].(string)
+	}
+
+	return req
+}
+
+// AppRequestProcessor resolves the app installation requests of app_requested events
+// according to a policy, see slack.Client.ProcessAdminAppRequest.
+type AppRequestProcessor struct {
+	Client *slack.Client
+	Policy slack.AdminAppRequestPolicy
+	// OnResult, when set, is called with the result of the requests that are not skipped.
+	OnResult func(ctx context.Context, evt EventsAPIEvent, result slack.AdminAppRequestResult)
+}
+
+// NewAppRequestProcessor returns an AppRequestProcessor applying policy with client.
+func NewAppRequestProcessor(client *slack.Client, policy slack.AdminAppRequestPolicy) *AppRequestProcessor {
+	return &AppRequestProcessor{Client: client, Policy: policy}
+}
+
+// HandleEvent resolves the request of an app_requested event. It returns false when evt is
+// another event, or when the request is skipped.
+func (p *AppRequestProcessor) HandleEvent(ctx context.Context, evt EventsAPIEvent) (slack.AdminAppRequestResult, bool) {
+	data, ok := evt.InnerEvent.Data.(*AppRequestedEvent)
+	if !ok {
+		return slack.AdminAppRequestResult{}, false
+	}
+
+	result, ok := p.Client.ProcessAdminAppRequest(ctx, data.AdminAppRequest(), p.Policy)
+	if ok && p.OnResult != nil {
+		p.OnResult(ctx, evt, result)
+	}
+
+	return result, ok
+}
+
+// RouterHandler returns a handler resolving the requests of the events it receives.
+func (p *AppRequestProcessor) RouterHandler() RouterHandlerFunc {
+	return func(ctx context.Context, req *Request) {
+		evt, ok := req.EventsAPIEvent()
+		if !ok {
+			return
+		}
+
+		req.Ack(ctx)
+		p.HandleEvent(ctx, evt)
+	}
+}
+
+// HandleAppRequests routes the app_requested events to p.
+func (r *Router) HandleAppRequests(p *AppRequestProcessor) {
+	r.HandleEvents(AppRequested, p.RouterHandler())
+}
@@ -0,0 +1,69 @@
+package slackevents
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestAppRequestProcessor(t *testing.T) {
+	var approved string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin.apps.approve", func(w http.ResponseWriter, r *http.Request) {
+		approved = r.FormValue("request_id") + " " + r.FormValue("team_id")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := slack.New("xoxp-1", slack.OptionAPIURL(ts.URL+"/"))
+	p := NewAppRequestProcessor(api, func(ctx context.Context, request slack.AdminAppRequest) (slack.AdminAppRequestAction, error) {
+		if len(request.SensitiveScopes()) > 0 {
+			return slack.AdminAppRequestSkip, nil
+		}
+		return slack.AdminAppRequestApprove, nil
+	})
+
+	var results []slack.AdminAppRequestResult
+	p.OnResult = func(ctx context.Context, evt EventsAPIEvent, result slack.AdminAppRequestResult) {
+		results = append(results, result)
+	}
+
+	r := NewRouter()
+	r.HandleAppRequests(p)
+
+	evt := parseTestEvent(t, `{"type":"event_callback","team_id":"T1","event":{"type":"app_requested","app_request":{"id":"R1","app":{"id":"A1"},"team":{"id":"T1"},"enterprise":null,"scopes":[{"name":"chat:write","token_type":"bot"}]}}}`)
+	if !r.Dispatch(context.Background(), NewRequest(RequestTypeEventsAPI, evt, nil)) {
+		t.Fatal("expected app_requested to be handled")
+	}
+
+	if approved != "R1 T1" || len(results) != 1 || results[0].Err != nil {
+		t.Errorf("expected the request to be approved, got %q, %+v", approved, results)
+	}
+}
+
+func TestAppRequestedEvent_AdminAppRequest(t *testing.T) {
+	evt := parseTestEvent(t, `{"type":"event_callback","team_id":"T1","event":{"type":"app_requested","app_request":{
+		"id":"R1","app":{"id":"A1","name":"Deploy"},"user":{"id":"U1"},"team":{"id":"T1"},
+		"enterprise":{"id":"E1","name":"Acme"},"message":"please",
+		"previous_resolution":{"status":"restricted","scopes":[{"name":"chat:write","token_type":"bot"}]},
+		"scopes":[{"name":"admin","is_sensitive":true,"token_type":"user"}]}}}`)
+
+	req := evt.InnerEvent.Data.(*AppRequestedEvent).AdminAppRequest()
+	if req.ID != "R1" || req.App.Name != "Deploy" || req.User.ID != "U1" || req.Team.ID != "T1" || req.Message != "please" {
+		t.Errorf("unexpected request %+v", req)
+	}
+	if req.Enterprise == nil || req.Enterprise.ID != "E1" || req.Enterprise.Name != "Acme" {
+		t.Errorf("unexpected enterprise %+v", req.Enterprise)
+	}
+	if req.PreviousResolution.Status != "restricted" || len(req.PreviousResolution.Scopes) != 1 || req.PreviousResolution.Scopes[0].Name != "chat:write" {
+		t.Errorf("unexpected previous resolution %+v", req.PreviousResolution)
+	}
+	if len(req.SensitiveScopes()) != 1 || req.SensitiveScopes()[0].Name != "admin" {
+		t.Errorf("unexpected scopes %+v", req.Scopes)
+	}
+}
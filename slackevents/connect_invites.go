@@ -0,0 +1,63 @@
+package slackevents
+
+import (
+	"context"
+
+	"github.com/slack-go/slack"
+)
+
+// ConnectInviteProcessor processes the pending Slack Connect invites according to a policy,
+// see slack.Client.ProcessConnectInvitesContext, whenever an invite is received, requested or
+// accepted: shared_channel_invite_received, shared_channel_invite_requested and
+// shared_channel_invite_accepted events.
+type ConnectInviteProcessor struct {
+	Client *slack.Client
+	Policy slack.ConnectInvitePolicy
+	// Params are the parameters listing the invites, e.g. the team of an organization-wide app.
+	Params slack.ListConnectInvitesParams
+	// OnResults, when set, is called with the results of the processing, and its error.
+	OnResults func(ctx context.Context, evt EventsAPIEvent, results []slack.ConnectInviteResult, err error)
+}
+
+// NewConnectInviteProcessor returns a ConnectInviteProcessor applying policy with client.
+func NewConnectInviteProcessor(client *slack.Client, policy slack.ConnectInvitePolicy) *ConnectInviteProcessor {
+	return &ConnectInviteProcessor{Client: client, Policy: policy}
+}
+
+// HandleEvent processes the pending invites when evt is about a new or accepted invite.
+// Other events are ignored.
+func (p *ConnectInviteProcessor) HandleEvent(ctx context.Context, evt EventsAPIEvent) ([]slack.ConnectInviteResult, error) {
+	switch evt.InnerEvent.Data.(type) {
+	case *SharedChannelInviteReceivedEvent, *SharedChannelInviteRequestedEvent, *SharedChannelInviteAcceptedEvent:
+	default:
+		return nil, nil
+	}
+
+	results, err := p.Client.ProcessConnectInvitesContext(ctx, p.Params, p.Policy)
+	if p.OnResults != nil {
+		p.OnResults(ctx, evt, results, err)
+	}
+
+	return results, err
+}
+
+// RouterHandler returns a handler processing the pending invites on the events it receives.
+func (p *ConnectInviteProcessor) RouterHandler() RouterHandlerFunc {
+	return func(ctx context.Context, req *Request) {
+		evt, ok := req.EventsAPIEvent()
+		if !ok {
+			return
+		}
+
+		req.Ack(ctx)
+		p.HandleEvent(ctx, evt)
+	}
+}
+
+// HandleConnectInvites routes the events about new or accepted Slack Connect invites to p.
+func (r *Router) HandleConnectInvites(p *ConnectInviteProcessor) {
+	f := p.RouterHandler()
+	r.HandleEvents(SharedChannelInviteReceived, f)
+	r.HandleEvents(SharedChannelInviteRequested, f)
+	r.HandleEvents(SharedChannelInviteAccepted, f)
+}
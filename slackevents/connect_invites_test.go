@@ -0,0 +1,51 @@
+package slackevents
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestConnectInviteProcessor(t *testing.T) {
+	var accepted string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/conversations.listConnectInvites", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"invites":[{"direction":"incoming","channel":{"name":"partner"},"invite":{"id":"I1"}}]}`))
+	})
+	mux.HandleFunc("/conversations.acceptSharedInvite", func(w http.ResponseWriter, r *http.Request) {
+		accepted = r.FormValue("invite_id")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := slack.New("xoxb-1", slack.OptionAPIURL(ts.URL+"/"))
+	p := NewConnectInviteProcessor(api, func(ctx context.Context, invite slack.ConnectInvite, acceptance *slack.ConnectInviteAcceptance) (slack.ConnectInviteDecision, error) {
+		return slack.ConnectInviteDecision{Action: slack.ConnectInviteAccept}, nil
+	})
+
+	var results []slack.ConnectInviteResult
+	p.OnResults = func(ctx context.Context, evt EventsAPIEvent, r []slack.ConnectInviteResult, err error) {
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+		results = r
+	}
+
+	r := NewRouter()
+	r.HandleConnectInvites(p)
+
+	evt := parseTestEvent(t, `{"type":"event_callback","team_id":"T1","event":{"type":"shared_channel_invite_received","invite":{"id":"I1"},"channel":{"id":"C1","name":"partner"}}}`)
+	if !r.Dispatch(context.Background(), NewRequest(RequestTypeEventsAPI, evt, nil)) {
+		t.Fatal("expected shared_channel_invite_received to be handled")
+	}
+
+	if accepted != "I1" || len(results) != 1 || results[0].Err != nil {
+		t.Errorf("expected the invite to be accepted, got %q, %+v", accepted, results)
+	}
+}
@@ -0,0 +1,174 @@
+package slackevents
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultDedupCapacity = 10000
+	defaultDedupTTL      = 10 * time.Minute
+)
+
+// DedupStore records the keys of deliveries that have already been processed
+// so that retried deliveries of the same event can be suppressed.
+type DedupStore interface {
+	// Seen records key and reports whether it had already been recorded.
+	Seen(key string) bool
+}
+
+type dedupEntry struct {
+	key     string
+	expires time.Time
+}
+
+// MemoryDedupStore is an in-memory DedupStore that forgets keys once they have not
+// been seen for its TTL, or when it holds more keys than its capacity, evicting the
+// least recently seen key first.
+type MemoryDedupStore struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	entries  *list.List
+	index    map[string]*list.Element
+	now      func() time.Time
+}
+
+// NewMemoryDedupStore returns a MemoryDedupStore holding at most capacity keys,
+// each for at most ttl. Non-positive values select the defaults of 10000 keys
+// and 10 minutes, which comfortably covers Slack's retry schedule.
+func NewMemoryDedupStore(capacity int, ttl time.Duration) *MemoryDedupStore {
+	if capacity <= 0 {
+		capacity = defaultDedupCapacity
+	}
+	if ttl <= 0 {
+		ttl = defaultDedupTTL
+	}
+
+	return &MemoryDedupStore{
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  list.New(),
+		index:    make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+// Seen implements DedupStore.
+func (s *MemoryDedupStore) Seen(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.expire(now)
+
+	if elem, ok := s.index[key]; ok {
+		elem.Value.(*dedupEntry).expires = now.Add(s.ttl)
+		s.entries.MoveToFront(elem)
+		return true
+	}
+
+	s.index[key] = s.entries.PushFront(&dedupEntry{key: key, expires: now.Add(s.ttl)})
+	for s.entries.Len() > s.capacity {
+		s.remove(s.entries.Back())
+	}
+
+	return false
+}
+
+// Len returns the number of keys currently held by the store.
+func (s *MemoryDedupStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.entries.Len()
+}
+
+// expire drops the entries whose TTL elapsed. Entries are kept in the order they
+// were last seen, which is also the order of their expiry, so only the expired
+// entries at the back are visited.
+func (s *MemoryDedupStore) expire(now time.Time) {
+	for elem := s.entries.Back(); elem != nil && now.After(elem.Value.(*dedupEntry).expires); elem = s.entries.Back() {
+		s.remove(elem)
+	}
+}
+
+func (s *MemoryDedupStore) remove(elem *list.Element) {
+	s.entries.Remove(elem)
+	delete(s.index, elem.Value.(*dedupEntry).key)
+}
+
+// DedupStats holds the counters of a Deduplicator.
+type DedupStats struct {
+	// Checked is the number of deliveries that were looked up.
+	Checked uint64
+	// Suppressed is the number of deliveries that were recognised as duplicates.
+	Suppressed uint64
+}
+
+// Deduplicator suppresses retried deliveries of the same event. It is consulted
+// by the Socket Mode and HTTP handlers before an event is dispatched.
+type Deduplicator struct {
+	store DedupStore
+
+	// OnDuplicate, if set, is called with the key of every suppressed delivery.
+	OnDuplicate func(key string)
+
+	checked    uint64
+	suppressed uint64
+}
+
+// NewDeduplicator returns a Deduplicator backed by store. When store is nil a
+// MemoryDedupStore with the default capacity and TTL is used.
+func NewDeduplicator(store DedupStore) *Deduplicator {
+	if store == nil {
+		store = NewMemoryDedupStore(0, 0)
+	}
+
+	return &Deduplicator{store: store}
+}
+
+// IsDuplicate records key and reports whether a delivery with the same key was
+// already seen. Empty keys are never considered duplicates.
+func (d *Deduplicator) IsDuplicate(key string) bool {
+	if key == "" {
+		return false
+	}
+
+	atomic.AddUint64(&d.checked, 1)
+	if !d.store.Seen(key) {
+		return false
+	}
+
+	atomic.AddUint64(&d.suppressed, 1)
+	if d.OnDuplicate != nil {
+		d.OnDuplicate(key)
+	}
+
+	return true
+}
+
+// IsDuplicateEvent is IsDuplicate keyed by the event ID of an Events API callback.
+func (d *Deduplicator) IsDuplicateEvent(event EventsAPIEvent) bool {
+	return d.IsDuplicate(EventID(event))
+}
+
+// Stats returns a snapshot of the Deduplicator counters.
+func (d *Deduplicator) Stats() DedupStats {
+	return DedupStats{
+		Checked:    atomic.LoadUint64(&d.checked),
+		Suppressed: atomic.LoadUint64(&d.suppressed),
+	}
+}
+
+// EventID returns the event_id of an Events API callback event, which stays the
+// same across retried deliveries. It returns an empty string for other outer events.
+func EventID(event EventsAPIEvent) string {
+	if cb, ok := event.Data.(*EventsAPICallbackEvent); ok && cb != nil {
+		return cb.EventID
+	}
+
+	return ""
+}
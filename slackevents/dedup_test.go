@@ -0,0 +1,74 @@
+package slackevents
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryDedupStore(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	s := NewMemoryDedupStore(2, time.Minute)
+	s.now = func() time.Time { return now }
+
+	if s.Seen("a") {
+		t.Fatal("a should not have been seen yet")
+	}
+	if !s.Seen("a") {
+		t.Fatal("a should have been seen")
+	}
+
+	s.Seen("b")
+	s.Seen("c")
+	if s.Len() != 2 {
+		t.Fatalf("expected the store to be capped at 2 keys, got %d", s.Len())
+	}
+	if s.Seen("a") {
+		t.Fatal("a should have been evicted as the least recently seen key")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if s.Seen("c") {
+		t.Fatal("c should have expired")
+	}
+	if s.Len() != 1 {
+		t.Fatalf("expected expired keys to be dropped, got %d", s.Len())
+	}
+
+	// Seeing a key again extends its TTL.
+	now = now.Add(45 * time.Second)
+	if !s.Seen("c") {
+		t.Fatal("c should have been seen")
+	}
+	now = now.Add(45 * time.Second)
+	if !s.Seen("c") {
+		t.Fatal("c should not have expired since it was last seen")
+	}
+}
+
+func TestDeduplicator(t *testing.T) {
+	var duplicates []string
+	d := NewDeduplicator(nil)
+	d.OnDuplicate = func(key string) { duplicates = append(duplicates, key) }
+
+	event := EventsAPIEvent{
+		Type: CallbackEvent,
+		Data: &EventsAPICallbackEvent{EventID: "Ev123"},
+	}
+
+	if d.IsDuplicateEvent(event) {
+		t.Fatal("first delivery should not be a duplicate")
+	}
+	if !d.IsDuplicateEvent(event) {
+		t.Fatal("second delivery should be a duplicate")
+	}
+	if d.IsDuplicate("") {
+		t.Fatal("empty keys should never be duplicates")
+	}
+
+	if stats := d.Stats(); stats.Checked != 2 || stats.Suppressed != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if len(duplicates) != 1 || duplicates[0] != "Ev123" {
+		t.Fatalf("unexpected OnDuplicate calls %v", duplicates)
+	}
+}
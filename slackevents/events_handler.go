@@ -0,0 +1,240 @@
+package slackevents
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+// defaultMaxBodyBytes is the default size limit of the request bodies read by the HTTP handlers.
+const defaultMaxBodyBytes = 1 << 20
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// EventDelivery is an Events API event delivered over HTTP.
+type EventDelivery struct {
+	Event EventsAPIEvent
+	// RetryNum is the number of the retried delivery, as sent in the X-Slack-Retry-Num header.
+	// It is zero for the first delivery.
+	RetryNum int
+	// RetryReason is the reason of the retried delivery, as sent in the X-Slack-Retry-Reason header.
+	RetryReason string
+}
+
+// EventsHandlerFunc handles an Events API event delivered over HTTP.
+type EventsHandlerFunc func(ctx context.Context, delivery EventDelivery)
+
+// EventsHandlerOption configures an EventsHandler.
+type EventsHandlerOption func(*EventsHandler)
+
+// EventsHandlerOptionMaxBodyBytes limits the size of the request bodies (default: 1MiB).
+func EventsHandlerOptionMaxBodyBytes(n int64) EventsHandlerOption {
+	return func(h *EventsHandler) {
+		h.maxBodyBytes = n
+	}
+}
+
+// EventsHandlerOptionRateLimited sets a callback for the app_rate_limited notifications
+// Slack sends when the app's event subscriptions exceed the rate limit.
+func EventsHandlerOptionRateLimited(f func(ctx context.Context, evt *EventsAPIAppRateLimited)) EventsHandlerOption {
+	return func(h *EventsHandler) {
+		h.rateLimited = f
+	}
+}
+
+// EventsHandlerOptionErrorHandler sets a callback for the requests that are rejected,
+// because they cannot be verified or parsed.
+func EventsHandlerOptionErrorHandler(f func(r *http.Request, err error)) EventsHandlerOption {
+	return func(h *EventsHandler) {
+		h.onError = f
+	}
+}
+
+// EventsHandlerOptionDeduplicator suppresses the retried deliveries of the events already dispatched.
+func EventsHandlerOptionDeduplicator(d *Deduplicator) EventsHandlerOption {
+	return func(h *EventsHandler) {
+		h.router.Deduplicator = d
+	}
+}
+
+// EventsHandler is an http.Handler serving the Events API request URL of an app.
+//
+// It verifies the signature of every request, answers url_verification challenges, reports
+// app_rate_limited notifications, and acknowledges event callbacks right away before
+// dispatching them asynchronously with its Router, on which the handlers of this package
+// written for a Router are registered, e.g. h.Router().HandleInstallationCleanup(c).
+type EventsHandler struct {
+	signingSecret string
+	maxBodyBytes  int64
+	rateLimited   func(ctx context.Context, evt *EventsAPIAppRateLimited)
+	onError       func(r *http.Request, err error)
+
+	router *Router
+}
+
+// NewEventsHandler returns an EventsHandler verifying requests with the app's signing secret.
+func NewEventsHandler(signingSecret string, options ...EventsHandlerOption) *EventsHandler {
+	h := &EventsHandler{
+		signingSecret: signingSecret,
+		maxBodyBytes:  defaultMaxBodyBytes,
+		router:        NewRouter(),
+	}
+
+	for _, opt := range options {
+		opt(h)
+	}
+
+	return h
+}
+
+// Router returns the router the events are dispatched with, e.g. to register the handlers
+// written for a Router. The events are acknowledged before they are dispatched, so
+// Request.Ack has no effect.
+func (h *EventsHandler) Router() *Router {
+	return h.router
+}
+
+// Handle adds a handler for an inner event type.
+func (h *EventsHandler) Handle(t EventsAPIType, f EventsHandlerFunc) {
+	if f == nil {
+		panic("invalid handler cannot be nil")
+	}
+
+	h.router.HandleEvents(t, eventsRouterHandler(f))
+}
+
+// HandleDefault sets the handler of the events no other handler matched.
+func (h *EventsHandler) HandleDefault(f EventsHandlerFunc) {
+	if f == nil {
+		h.router.HandleDefault(nil)
+		return
+	}
+
+	h.router.HandleDefault(eventsRouterHandler(f))
+}
+
+// eventsRouterHandler adapts f to a Router.
+func eventsRouterHandler(f EventsHandlerFunc) RouterHandlerFunc {
+	return func(ctx context.Context, req *Request) {
+		evt, ok := req.EventsAPIEvent()
+		if !ok {
+			return
+		}
+
+		f(ctx, EventDelivery{Event: evt, RetryNum: req.RetryAttempt, RetryReason: req.RetryReason})
+	}
+}
+
+// HandleEvent adds a handler for the inner event type of its second argument. f must be a
+// function taking a context.Context and a pointer to one of the inner event types of
+// EventsAPIInnerEventMapping, for example:
+//
+//	h.HandleEvent(func(ctx context.Context, evt *slackevents.AppMentionEvent) { ... })
+//
+// HandleEvent panics when f does not have such a signature.
+func (h *EventsHandler) HandleEvent(f interface{}) {
+	fn := reflect.ValueOf(f)
+	ft := fn.Type()
+	if ft.Kind() != reflect.Func || ft.NumIn() != 2 || ft.NumOut() != 0 || ft.In(0) != contextType || ft.In(1).Kind() != reflect.Ptr {
+		panic(fmt.Sprintf("invalid handler %T: expected func(context.Context, *Event)", f))
+	}
+
+	eventType := ft.In(1).Elem()
+	var registered bool
+	for t, v := range EventsAPIInnerEventMapping {
+		if reflect.TypeOf(v) != eventType {
+			continue
+		}
+
+		h.Handle(t, func(ctx context.Context, delivery EventDelivery) {
+			data := reflect.ValueOf(delivery.Event.InnerEvent.Data)
+			if data.Type() == ft.In(1) {
+				fn.Call([]reflect.Value{reflect.ValueOf(ctx), data})
+			}
+		})
+		registered = true
+	}
+
+	if !registered {
+		panic(fmt.Sprintf("invalid handler %T: %s is not an Events API inner event", f, eventType))
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *EventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := readVerifiedBody(w, r, h.signingSecret, h.maxBodyBytes)
+	if err != nil {
+		h.reject(w, r, err)
+		return
+	}
+
+	evt, err := ParseEvent(json.RawMessage(body), OptionNoVerifyToken())
+	if err != nil {
+		h.reject(w, r, &httpError{status: http.StatusBadRequest, err: err})
+		return
+	}
+
+	switch evt.Type {
+	case URLVerification:
+		writeChallenge(w, evt.Data.(*EventsAPIURLVerificationEvent))
+	case AppRateLimited:
+		if h.rateLimited != nil {
+			h.rateLimited(r.Context(), evt.Data.(*EventsAPIAppRateLimited))
+		}
+		w.WriteHeader(http.StatusOK)
+	case CallbackEvent:
+		req := NewRequest(RequestTypeEventsAPI, evt, nil)
+		req.RetryAttempt, _ = strconv.Atoi(r.Header.Get(HeaderRetryNum))
+		req.RetryReason = r.Header.Get(HeaderRetryReason)
+
+		// Slack expects a response within 3 seconds, so we acknowledge before handling.
+		w.WriteHeader(http.StatusOK)
+
+		// The handlers outlive the HTTP request.
+		go h.router.Dispatch(context.Background(), req)
+	default:
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (h *EventsHandler) reject(w http.ResponseWriter, r *http.Request, err error) {
+	reject(w, r, h.onError, err)
+}
+
+// reject reports err to onError, when set, and answers the request with the status of err.
+func reject(w http.ResponseWriter, r *http.Request, onError func(r *http.Request, err error), err error) {
+	if onError != nil {
+		onError(r, err)
+	}
+
+	status := http.StatusBadRequest
+	var herr *httpError
+	if errors.As(err, &herr) {
+		status = herr.status
+	}
+
+	w.WriteHeader(status)
+}
+
+// httpError is an error carrying the HTTP status it should be answered with.
+type httpError struct {
+	status int
+	err    error
+}
+
+func (e *httpError) Error() string {
+	return e.err.Error()
+}
+
+func (e *httpError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,140 @@
+package slackevents
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventsHandler(t *testing.T) {
+	mentions := make(chan *AppMentionEvent, 2)
+	deliveries := make(chan EventDelivery, 2)
+	var rateLimited *EventsAPIAppRateLimited
+	var rejected []error
+
+	h := NewEventsHandler(testSigningSecret,
+		EventsHandlerOptionMaxBodyBytes(512),
+		EventsHandlerOptionDeduplicator(NewDeduplicator(nil)),
+		EventsHandlerOptionRateLimited(func(ctx context.Context, evt *EventsAPIAppRateLimited) { rateLimited = evt }),
+		EventsHandlerOptionErrorHandler(func(r *http.Request, err error) { rejected = append(rejected, err) }),
+	)
+	h.HandleEvent(func(ctx context.Context, evt *AppMentionEvent) { mentions <- evt })
+	h.Handle(AppMention, func(ctx context.Context, delivery EventDelivery) { deliveries <- delivery })
+
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve(newSignedRequest(t, "application/json", `{"type":"url_verification","challenge":"abc"}`))
+	if w.Code != http.StatusOK || w.Body.String() != "abc" {
+		t.Fatalf("unexpected challenge response %d %q", w.Code, w.Body.String())
+	}
+
+	w = serve(newSignedRequest(t, "application/json", `{"type":"app_rate_limited","team_id":"T1","minute_rate_limited":1518467820}`))
+	if w.Code != http.StatusOK || rateLimited == nil || rateLimited.TeamID != "T1" {
+		t.Fatalf("expected the rate limit notification to be reported, got %d %+v", w.Code, rateLimited)
+	}
+
+	mention := `{"type":"event_callback","event_id":"Ev1","event":{"type":"app_mention","user":"U1","text":"hi"}}`
+	req := newSignedRequest(t, "application/json", mention)
+	req.Header.Set("X-Slack-Retry-Num", "1")
+	req.Header.Set("X-Slack-Retry-Reason", "http_timeout")
+	if w = serve(req); w.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d", w.Code)
+	}
+
+	select {
+	case evt := <-mentions:
+		if evt.User != "U1" || evt.Text != "hi" {
+			t.Fatalf("unexpected event %+v", evt)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the typed handler")
+	}
+	select {
+	case delivery := <-deliveries:
+		if delivery.RetryNum != 1 || delivery.RetryReason != "http_timeout" {
+			t.Fatalf("unexpected delivery %+v", delivery)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the handler")
+	}
+
+	// The retried delivery is acknowledged but not dispatched again.
+	if w = serve(newSignedRequest(t, "application/json", mention)); w.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d", w.Code)
+	}
+	select {
+	case <-deliveries:
+		t.Fatal("duplicate delivery was dispatched")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if w = serve(newSignedRequest(t, "application/json", `{"type":"event_callback","event":{"type":"app_mention","text":"`+strings.Repeat("a", 1024)+`"}}`)); w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected oversized bodies to be rejected, got %d", w.Code)
+	}
+
+	req = newSignedRequest(t, "application/json", mention)
+	req.Header.Set("X-Slack-Signature", "v0=deadbeef")
+	if w = serve(req); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected unsigned requests to be rejected, got %d", w.Code)
+	}
+
+	if w = serve(httptest.NewRequest(http.MethodGet, "/", nil)); w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("unexpected status %d", w.Code)
+	}
+
+	if len(rejected) != 2 {
+		t.Fatalf("expected 2 rejected requests, got %v", rejected)
+	}
+}
+
+func TestEventsHandler_Router(t *testing.T) {
+	requests := make(chan *Request, 1)
+
+	h := NewEventsHandler(testSigningSecret)
+	h.Router().HandleEvents(AppMention, func(ctx context.Context, req *Request) {
+		// The event was acknowledged already.
+		req.Ack(ctx, "ignored")
+		requests <- req
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newSignedRequest(t, "application/json", `{"type":"event_callback","event_id":"Ev1","event":{"type":"app_mention","user":"U1"}}`))
+	if w.Code != http.StatusOK || w.Body.Len() != 0 {
+		t.Fatalf("unexpected response %d %q", w.Code, w.Body.String())
+	}
+
+	select {
+	case req := <-requests:
+		if evt, ok := req.EventsAPIEvent(); !ok || evt.InnerEvent.Type != string(AppMention) {
+			t.Fatalf("unexpected request %+v", req)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the router handler")
+	}
+}
+
+func TestEventsHandler_HandleEventInvalid(t *testing.T) {
+	for _, f := range []interface{}{
+		"not a function",
+		func(evt *AppMentionEvent) {},
+		func(ctx context.Context, evt AppMentionEvent) {},
+		func(ctx context.Context, evt *EventsAPIEvent) {},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%T should have panicked", f)
+				}
+			}()
+
+			NewEventsHandler(testSigningSecret).HandleEvent(f)
+		}()
+	}
+}
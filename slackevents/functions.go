@@ -0,0 +1,248 @@
+package slackevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/slack-go/slack"
+)
+
+// functionInteractionsBuffer is the number of interactions buffered for an execution
+// before WaitForInteraction is called.
+const functionInteractionsBuffer = 8
+
+// FunctionRequest is an execution of a custom function, see FunctionRegistry.
+type FunctionRequest struct {
+	Event FunctionExecutedEvent
+	// Client is authenticated with the bot access token of the execution: it must be used
+	// to call the Web API within the workflow, e.g. to post the messages of the function.
+	Client *slack.Client
+
+	interactions chan slack.InteractionCallback
+}
+
+// DecodeInputs decodes the inputs of the function into v, usually a pointer to a struct whose
+// json tags name the input parameters.
+func (r *FunctionRequest) DecodeInputs(v interface{}) error {
+	b, err := json.Marshal(r.Event.Inputs)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+// WaitForInteraction blocks until a user interacts with a message or a view posted by the
+// execution, e.g. clicks a button, and returns the interaction. The interaction is already
+// acknowledged. The interactions happening before the call are not lost.
+func (r *FunctionRequest) WaitForInteraction(ctx context.Context) (slack.InteractionCallback, error) {
+	select {
+	case cb := <-r.interactions:
+		return cb, nil
+	case <-ctx.Done():
+		return slack.InteractionCallback{}, ctx.Err()
+	}
+}
+
+// FunctionHandlerFunc runs a custom function. The execution is completed with the returned
+// outputs, a struct whose json tags name the output parameters or a map, when err is nil,
+// and with the message of err otherwise.
+type FunctionHandlerFunc func(ctx context.Context, req *FunctionRequest) (outputs interface{}, err error)
+
+// FunctionRegistryOption configures a FunctionRegistry.
+type FunctionRegistryOption func(*FunctionRegistry)
+
+// FunctionRegistryOptionClientOptions sets the options of the clients of the executions.
+func FunctionRegistryOptionClientOptions(options ...slack.Option) FunctionRegistryOption {
+	return func(r *FunctionRegistry) {
+		r.clientOptions = options
+	}
+}
+
+// FunctionRegistryOptionErrorHandler sets a callback for the executions that could not be
+// completed, e.g. because the Web API could not be reached.
+func FunctionRegistryOptionErrorHandler(f func(ctx context.Context, req *FunctionRequest, err error)) FunctionRegistryOption {
+	return func(r *FunctionRegistry) {
+		r.onError = f
+	}
+}
+
+// FunctionRegistry runs the custom functions of an app, keyed by their callback ID, on
+// function_executed events: the inputs are decoded, the handler runs, and the execution is
+// completed with its outputs, or with an error when it returns one or panics.
+//
+// The interactions with the messages and views of an execution are delivered to it, see
+// FunctionRequest.WaitForInteraction. Both the events and the interactions are received
+// through a Router, see Router.HandleFunctions.
+//
+// See: https://api.slack.com/automation/functions/custom-bolt
+type FunctionRegistry struct {
+	clientOptions []slack.Option
+	onError       func(ctx context.Context, req *FunctionRequest, err error)
+
+	functions map[string]FunctionHandlerFunc
+
+	mu         sync.Mutex
+	executions map[string]*FunctionRequest
+}
+
+// NewFunctionRegistry returns an empty FunctionRegistry.
+func NewFunctionRegistry(options ...FunctionRegistryOption) *FunctionRegistry {
+	r := &FunctionRegistry{
+		functions:  make(map[string]FunctionHandlerFunc),
+		executions: make(map[string]*FunctionRequest),
+	}
+
+	for _, opt := range options {
+		opt(r)
+	}
+
+	return r
+}
+
+// Register adds the handler of the function with the given callback ID.
+func (r *FunctionRegistry) Register(callbackID string, f FunctionHandlerFunc) {
+	if callbackID == "" {
+		panic("invalid callbackID cannot be empty")
+	}
+	if f == nil {
+		panic("invalid handler cannot be nil")
+	}
+	if _, exist := r.functions[callbackID]; exist {
+		panic("multiple registrations for function " + callbackID)
+	}
+	r.functions[callbackID] = f
+}
+
+// HandleEvent runs the function executed by evt, and returns once the execution is
+// completed. It reports whether a function handled the event: other events, the functions
+// of other callback IDs, and the executions already running are ignored.
+func (r *FunctionRegistry) HandleEvent(ctx context.Context, evt EventsAPIEvent) bool {
+	data, ok := evt.InnerEvent.Data.(*FunctionExecutedEvent)
+	if !ok {
+		return false
+	}
+
+	f, ok := r.functions[data.Function.CallbackID]
+	if !ok {
+		return false
+	}
+
+	req := &FunctionRequest{
+		Event:        *data,
+		Client:       slack.New(data.BotAccessToken, r.clientOptions...),
+		interactions: make(chan slack.InteractionCallback, functionInteractionsBuffer),
+	}
+
+	r.mu.Lock()
+	_, running := r.executions[data.FunctionExecutionID]
+	if !running {
+		r.executions[data.FunctionExecutionID] = req
+	}
+	r.mu.Unlock()
+
+	if running {
+		return true
+	}
+	defer func() {
+		r.mu.Lock()
+		delete(r.executions, data.FunctionExecutionID)
+		r.mu.Unlock()
+	}()
+
+	outputs, err := r.run(ctx, f, req)
+
+	// Complete the execution even when ctx is cancelled, e.g. on shutdown.
+	ctx = context.WithoutCancel(ctx)
+	if err != nil {
+		err = req.Client.FunctionCompleteErrorContext(ctx, data.FunctionExecutionID, err.Error())
+	} else if outputs != nil {
+		err = req.Client.FunctionCompleteSuccessContext(ctx, data.FunctionExecutionID, slack.FunctionCompleteSuccessRequestOptionOutputValues(outputs))
+	} else {
+		err = req.Client.FunctionCompleteSuccessContext(ctx, data.FunctionExecutionID)
+	}
+
+	if err != nil && r.onError != nil {
+		r.onError(ctx, req, err)
+	}
+
+	return true
+}
+
+// run runs f, converting its panics into errors.
+func (r *FunctionRegistry) run(ctx context.Context, f FunctionHandlerFunc, req *FunctionRequest) (outputs interface{}, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			outputs, err = nil, fmt.Errorf("panic: %v", p)
+		}
+	}()
+
+	return f(ctx, req)
+}
+
+// HandleInteraction delivers an interaction to the running execution it is part of, and
+// reports whether it did. It is not acknowledged.
+func (r *FunctionRegistry) HandleInteraction(cb slack.InteractionCallback) bool {
+	if cb.FunctionData == nil {
+		return false
+	}
+
+	r.mu.Lock()
+	req, ok := r.executions[cb.FunctionData.ExecutionID]
+	r.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	select {
+	case req.interactions <- cb:
+		return true
+	default:
+		// The function does not wait for the interactions.
+		return false
+	}
+}
+
+// isExecutionInteraction reports whether req is an interaction with a message or a view of a
+// running execution.
+func (r *FunctionRegistry) isExecutionInteraction(req *Request) bool {
+	cb, ok := req.InteractionCallback()
+	if !ok || cb.FunctionData == nil {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok = r.executions[cb.FunctionData.ExecutionID]
+	return ok
+}
+
+// RouterHandler returns a handler running the functions of the function_executed events,
+// and delivering the interactions to their executions. The events are acknowledged before
+// the functions run.
+func (r *FunctionRegistry) RouterHandler() RouterHandlerFunc {
+	return func(ctx context.Context, req *Request) {
+		if evt, ok := req.EventsAPIEvent(); ok {
+			req.Ack(ctx)
+			r.HandleEvent(ctx, evt)
+			return
+		}
+
+		if cb, ok := req.InteractionCallback(); ok && r.HandleInteraction(cb) {
+			req.Ack(ctx)
+		}
+	}
+}
+
+// HandleFunctions routes the function_executed events to reg, and the interactions with the
+// messages and views of its running executions. The other interactions are left to the other
+// handlers.
+func (r *Router) HandleFunctions(reg *FunctionRegistry) {
+	f := reg.RouterHandler()
+	r.HandleEvents(FunctionExecuted, f)
+	r.HandleMatch(RequestTypeInteractive, reg.isExecutionInteraction, f)
+}
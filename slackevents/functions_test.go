@@ -0,0 +1,184 @@
+package slackevents
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+type completionRecorder struct {
+	mu          sync.Mutex
+	completions map[string]string
+	tokens      []string
+}
+
+func (c *completionRecorder) server() *httptest.Server {
+	c.completions = make(map[string]string)
+	mux := http.NewServeMux()
+	for _, method := range []string{"functions.completeSuccess", "functions.completeError"} {
+		method := method
+		mux.HandleFunc("/"+method, func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			var req struct {
+				FunctionExecutionID string `json:"function_execution_id"`
+			}
+			json.Unmarshal(body, &req)
+
+			c.mu.Lock()
+			c.completions[req.FunctionExecutionID] = method + " " + string(body)
+			c.tokens = append(c.tokens, r.Header.Get("Authorization"))
+			c.mu.Unlock()
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"ok":true}`))
+		})
+	}
+	return httptest.NewServer(mux)
+}
+
+func functionExecutedEvent(t *testing.T, callbackID, executionID, inputs string) EventsAPIEvent {
+	return parseTestEvent(t, `{"type":"event_callback","team_id":"T1","event":{"type":"function_executed",
+		"function":{"callback_id":"`+callbackID+`"},"inputs":`+inputs+`,
+		"function_execution_id":"`+executionID+`","bot_access_token":"xwfp-1"}}`)
+}
+
+func TestFunctionRegistry(t *testing.T) {
+	c := &completionRecorder{}
+	ts := c.server()
+	defer ts.Close()
+
+	reg := NewFunctionRegistry(FunctionRegistryOptionClientOptions(slack.OptionAPIURL(ts.URL + "/")))
+	reg.Register("sum", func(ctx context.Context, req *FunctionRequest) (interface{}, error) {
+		var in struct {
+			A int `json:"a"`
+			B int `json:"b"`
+		}
+		if err := req.DecodeInputs(&in); err != nil {
+			return nil, err
+		}
+		return struct {
+			Sum int `json:"sum"`
+		}{in.A + in.B}, nil
+	})
+	reg.Register("fail", func(ctx context.Context, req *FunctionRequest) (interface{}, error) {
+		return nil, errors.New("no luck")
+	})
+	reg.Register("panic", func(ctx context.Context, req *FunctionRequest) (interface{}, error) {
+		panic("boom")
+	})
+
+	r := NewRouter()
+	r.HandleFunctions(reg)
+
+	ctx := context.Background()
+	for _, evt := range []EventsAPIEvent{
+		functionExecutedEvent(t, "sum", "Fx1", `{"a":1,"b":2}`),
+		functionExecutedEvent(t, "fail", "Fx2", `{}`),
+		functionExecutedEvent(t, "panic", "Fx3", `{}`),
+	} {
+		ack := &recordingAcknowledger{}
+		r.Dispatch(ctx, NewRequest(RequestTypeEventsAPI, evt, ack))
+		if len(ack.payloads) != 1 {
+			t.Errorf("expected a single acknowledgement, got %v", ack.payloads)
+		}
+	}
+
+	expected := map[string]string{
+		"Fx1": `functions.completeSuccess {"function_execution_id":"Fx1","outputs":{"sum":3}}`,
+		"Fx2": `functions.completeError {"function_execution_id":"Fx2","error":"no luck"}`,
+		"Fx3": `functions.completeError {"function_execution_id":"Fx3","error":"panic: boom"}`,
+	}
+	for id, want := range expected {
+		if got := c.completions[id]; got != want {
+			t.Errorf("expected %s to be completed with %s, got %s", id, want, got)
+		}
+	}
+	for _, token := range c.tokens {
+		if token != "Bearer xwfp-1" {
+			t.Errorf("expected the bot access token of the execution to be used, got %q", token)
+		}
+	}
+}
+
+func TestFunctionRegistry_WaitForInteraction(t *testing.T) {
+	c := &completionRecorder{}
+	ts := c.server()
+	defer ts.Close()
+
+	started := make(chan struct{})
+	reg := NewFunctionRegistry(FunctionRegistryOptionClientOptions(slack.OptionAPIURL(ts.URL + "/")))
+	reg.Register("approve", func(ctx context.Context, req *FunctionRequest) (interface{}, error) {
+		close(started)
+
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		cb, err := req.WaitForInteraction(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]bool{"approved": cb.ActionCallback.BlockActions[0].ActionID == "approve"}, nil
+	})
+
+	r := NewRouter()
+	r.HandleFunctions(reg)
+
+	ctx := context.Background()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.Dispatch(ctx, NewRequest(RequestTypeEventsAPI, functionExecutedEvent(t, "approve", "Fx1", `{}`), nil))
+	}()
+	<-started
+
+	other := slack.InteractionCallback{Type: slack.InteractionTypeBlockActions, FunctionData: &slack.FunctionData{ExecutionID: "Fx2"}}
+	if reg.HandleInteraction(other) {
+		t.Error("expected the interactions of other executions not to be delivered")
+	}
+
+	ack := &recordingAcknowledger{}
+	cb := slack.InteractionCallback{
+		Type:           slack.InteractionTypeBlockActions,
+		FunctionData:   &slack.FunctionData{ExecutionID: "Fx1"},
+		ActionCallback: slack.ActionCallbacks{BlockActions: []*slack.BlockAction{{ActionID: "approve"}}},
+	}
+	r.Dispatch(ctx, NewRequest(RequestTypeInteractive, cb, ack))
+	if len(ack.payloads) != 1 {
+		t.Errorf("expected the interaction to be acknowledged, got %v", ack.payloads)
+	}
+
+	<-done
+	want := `functions.completeSuccess {"function_execution_id":"Fx1","outputs":{"approved":true}}`
+	if got := c.completions["Fx1"]; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestRouter_HandleFunctionsDefault(t *testing.T) {
+	rec := &handlerRecorder{}
+
+	r := NewRouter()
+	r.HandleFunctions(NewFunctionRegistry())
+	r.HandleDefault(rec.handler("default"))
+
+	for _, cb := range []slack.InteractionCallback{
+		{Type: slack.InteractionTypeBlockActions, ActionCallback: slack.ActionCallbacks{BlockActions: []*slack.BlockAction{{ActionID: "approve"}}}},
+		{Type: slack.InteractionTypeViewSubmission, FunctionData: &slack.FunctionData{ExecutionID: "Fx1"}},
+	} {
+		if !r.Dispatch(context.Background(), NewRequest(RequestTypeInteractive, cb, nil)) {
+			t.Fatal("expected the interaction to be handled")
+		}
+	}
+
+	if got := rec.sorted(); len(got) != 2 || got[0] != "default" || got[1] != "default" {
+		t.Errorf("expected the interactions outside of executions to reach the default handler, got %v", got)
+	}
+}
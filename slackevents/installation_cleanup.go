@@ -0,0 +1,138 @@
+package slackevents
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// InstallationCleaner purges the credentials of an InstallationStore when Slack reports
+// that they were revoked: every installation of a workspace or organization on
+// app_uninstalled, and the revoked bot or user tokens on tokens_revoked.
+type InstallationCleaner struct {
+	Store slack.InstallationStore
+	// OnError, when set, is called with the errors of the store.
+	OnError func(ctx context.Context, evt EventsAPIEvent, err error)
+}
+
+// NewInstallationCleaner returns an InstallationCleaner purging store.
+func NewInstallationCleaner(store slack.InstallationStore) *InstallationCleaner {
+	return &InstallationCleaner{Store: store}
+}
+
+// HandleEvent purges the credentials revoked by evt. Other events are ignored.
+func (c *InstallationCleaner) HandleEvent(ctx context.Context, evt EventsAPIEvent) error {
+	cb, ok := evt.Data.(*EventsAPICallbackEvent)
+	if !ok {
+		return nil
+	}
+
+	var err error
+	switch data := evt.InnerEvent.Data.(type) {
+	case *AppUninstalledEvent:
+		err = c.uninstall(ctx, cb.EnterpriseID, cb.TeamID)
+	case *TokensRevokedEvent:
+		err = c.revoke(ctx, cb.EnterpriseID, cb.TeamID, data.Tokens.Bot, data.Tokens.Oauth)
+	}
+
+	if err != nil && c.OnError != nil {
+		c.OnError(ctx, evt, err)
+	}
+
+	return err
+}
+
+// find returns the latest installation of a workspace, or of its organization
+// for organization-wide installations.
+func (c *InstallationCleaner) find(ctx context.Context, enterpriseID, teamID string) (*slack.Installation, error) {
+	inst, err := c.Store.Find(ctx, enterpriseID, teamID, "")
+	if errors.Is(err, slack.ErrInstallationNotFound) && enterpriseID != "" && teamID != "" {
+		inst, err = c.Store.Find(ctx, enterpriseID, "", "")
+	}
+	return inst, err
+}
+
+func (c *InstallationCleaner) uninstall(ctx context.Context, enterpriseID, teamID string) error {
+	inst, err := c.find(ctx, enterpriseID, teamID)
+	if errors.Is(err, slack.ErrInstallationNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.Store.Delete(ctx, inst.EnterpriseID, inst.TeamID, "")
+}
+
+// revoke disables the revoked tokens. Every installation of a user also holds the bot token of
+// the time, so a revoked bot token is cleared from all the installations of the workspace or
+// organization, while the installations of the users whose tokens were revoked are deleted.
+func (c *InstallationCleaner) revoke(ctx context.Context, enterpriseID, teamID string, botUserIDs, userIDs []string) error {
+	inst, err := c.find(ctx, enterpriseID, teamID)
+	if errors.Is(err, slack.ErrInstallationNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var botRevoked, userRevoked bool
+	for _, id := range botUserIDs {
+		if id == inst.BotUserID && inst.BotToken != "" {
+			inst.BotToken, inst.BotRefreshToken, inst.BotScopes = "", "", nil
+			inst.BotTokenExpiresAt = time.Time{}
+			botRevoked = true
+		}
+	}
+	for _, id := range userIDs {
+		if id == inst.UserID && inst.UserToken != "" {
+			inst.UserToken, inst.UserRefreshToken, inst.UserScopes = "", "", nil
+			inst.UserTokenExpiresAt = time.Time{}
+			userRevoked = true
+		}
+	}
+
+	if botRevoked {
+		if err := c.Store.ClearBotToken(ctx, inst.EnterpriseID, inst.TeamID); err != nil {
+			return err
+		}
+	}
+
+	// Saving the latest installation also saves the installation of its installing user,
+	// which is deleted below.
+	if userRevoked {
+		if err := c.Store.Save(ctx, inst); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range userIDs {
+		if err := c.Store.Delete(ctx, inst.EnterpriseID, inst.TeamID, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RouterHandler returns a handler purging the credentials revoked by the events it receives.
+func (c *InstallationCleaner) RouterHandler() RouterHandlerFunc {
+	return func(ctx context.Context, req *Request) {
+		evt, ok := req.EventsAPIEvent()
+		if !ok {
+			return
+		}
+
+		req.Ack(ctx)
+		c.HandleEvent(ctx, evt)
+	}
+}
+
+// HandleInstallationCleanup routes the app_uninstalled and tokens_revoked events to c.
+func (r *Router) HandleInstallationCleanup(c *InstallationCleaner) {
+	f := c.RouterHandler()
+	r.HandleEvents(AppUninstalled, f)
+	r.HandleEvents(TokensRevoked, f)
+}
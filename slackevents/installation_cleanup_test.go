@@ -0,0 +1,94 @@
+package slackevents
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func parseTestEvent(t *testing.T, body string) EventsAPIEvent {
+	t.Helper()
+
+	evt, err := ParseEvent(json.RawMessage(body), OptionNoVerifyToken())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return evt
+}
+
+func TestInstallationCleaner(t *testing.T) {
+	ctx := context.Background()
+	store := slack.NewMemoryInstallationStore()
+	store.Save(ctx, &slack.Installation{TeamID: "T1", UserID: "U1", BotUserID: "B1", BotToken: "xoxb-1", UserToken: "xoxp-1"})
+	store.Save(ctx, &slack.Installation{TeamID: "T2", UserID: "U2", BotUserID: "B2", BotToken: "xoxb-2"})
+
+	r := NewRouter()
+	r.HandleInstallationCleanup(NewInstallationCleaner(store))
+
+	revoked := parseTestEvent(t, `{"type":"event_callback","team_id":"T1","event":{"type":"tokens_revoked","tokens":{"oauth":["U1"],"bot":["B1"]}}}`)
+	if !r.Dispatch(ctx, NewRequest(RequestTypeEventsAPI, revoked, nil)) {
+		t.Fatal("expected tokens_revoked to be handled")
+	}
+
+	latest, err := store.Find(ctx, "", "T1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if latest.BotToken != "" || latest.UserToken != "" {
+		t.Errorf("expected the revoked tokens to be cleared, got %+v", latest)
+	}
+	if _, err := store.Find(ctx, "", "T1", "U1"); err != slack.ErrInstallationNotFound {
+		t.Errorf("expected the user installation to be deleted, got %v", err)
+	}
+
+	uninstalled := parseTestEvent(t, `{"type":"event_callback","team_id":"T2","event":{"type":"app_uninstalled"}}`)
+	r.Dispatch(ctx, NewRequest(RequestTypeEventsAPI, uninstalled, nil))
+
+	for _, userID := range []string{"", "U2"} {
+		if _, err := store.Find(ctx, "", "T2", userID); err != slack.ErrInstallationNotFound {
+			t.Errorf("expected the installations of T2 to be deleted, got %v", err)
+		}
+	}
+}
+
+func TestInstallationCleaner_revokedBotToken(t *testing.T) {
+	ctx := context.Background()
+	store := slack.NewMemoryInstallationStore()
+	store.Save(ctx, &slack.Installation{TeamID: "T1", UserID: "U1", BotUserID: "B1", BotToken: "xoxb-1", UserToken: "xoxp-1"})
+	store.Save(ctx, &slack.Installation{TeamID: "T1", UserID: "U2", BotUserID: "B1", BotToken: "xoxb-1", UserToken: "xoxp-2"})
+
+	c := NewInstallationCleaner(store)
+	err := c.HandleEvent(ctx, parseTestEvent(t, `{"type":"event_callback","team_id":"T1","event":{"type":"tokens_revoked","tokens":{"bot":["B1"]}}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]string{"": "xoxp-2", "U1": "xoxp-1", "U2": "xoxp-2"}
+	for userID, userToken := range expected {
+		inst, err := store.Find(ctx, "", "T1", userID)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if inst.BotToken != "" || inst.UserToken != userToken {
+			t.Errorf("expected only the bot token to be cleared, got %+v", inst)
+		}
+	}
+}
+
+func TestInstallationCleaner_enterpriseInstall(t *testing.T) {
+	ctx := context.Background()
+	store := slack.NewMemoryInstallationStore()
+	store.Save(ctx, &slack.Installation{EnterpriseID: "E1", IsEnterpriseInstall: true, BotToken: "xoxb-1"})
+
+	c := NewInstallationCleaner(store)
+	err := c.HandleEvent(ctx, parseTestEvent(t, `{"type":"event_callback","enterprise_id":"E1","team_id":"T1","event":{"type":"app_uninstalled"}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := store.Find(ctx, "E1", "", ""); err != slack.ErrInstallationNotFound {
+		t.Errorf("expected the organization installation to be deleted, got %v", err)
+	}
+}
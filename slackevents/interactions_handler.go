@@ -0,0 +1,211 @@
+package slackevents
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/slack-go/slack"
+)
+
+var errNoResponseURL = errors.New("the request has no response_url")
+
+// InteractionRequest is an interactivity payload delivered over HTTP.
+type InteractionRequest struct {
+	Callback slack.InteractionCallback
+
+	req *Request
+}
+
+// Respond answers the request synchronously, payload being sent as the JSON body of the HTTP
+// response. Only the first response is sent, and only if it happens within 2.5 seconds.
+func (r *InteractionRequest) Respond(payload interface{}) error {
+	return r.req.Ack(context.Background(), payload)
+}
+
+// RespondViewSubmission answers a view_submission, e.g. to display validation errors,
+// or to update, push or clear the modal.
+func (r *InteractionRequest) RespondViewSubmission(resp *slack.ViewSubmissionResponse) error {
+	return r.Respond(resp)
+}
+
+// RespondOptions answers a block_suggestion with the options of an external select.
+func (r *InteractionRequest) RespondOptions(options ...*slack.OptionBlockObject) error {
+	return r.Respond(&slack.OptionsResponse{Options: options})
+}
+
+// RespondOptionGroups answers a block_suggestion with the option groups of an external select.
+func (r *InteractionRequest) RespondOptionGroups(groups ...*slack.OptionGroupBlockObject) error {
+	return r.Respond(&slack.OptionGroupsResponse{OptionGroups: groups})
+}
+
+// ResponseURL returns the URL to send follow-up messages to. For view submissions,
+// it is the first of the URLs requested by the view's conversation selects.
+func (r *InteractionRequest) ResponseURL() string {
+	if r.Callback.ResponseURL != "" {
+		return r.Callback.ResponseURL
+	}
+	if len(r.Callback.ResponseURLs) > 0 {
+		return r.Callback.ResponseURLs[0].ResponseURL
+	}
+	return ""
+}
+
+// FollowUp sends a message to the response URL of the request, after it was answered.
+// responseType is slack.ResponseTypeInChannel or slack.ResponseTypeEphemeral.
+func (r *InteractionRequest) FollowUp(ctx context.Context, api *slack.Client, responseType string, options ...slack.MsgOption) error {
+	return followUp(ctx, api, r.ResponseURL(), responseType, options...)
+}
+
+// InteractionHandlerFunc handles an interactivity payload delivered over HTTP.
+type InteractionHandlerFunc func(ctx context.Context, req *InteractionRequest)
+
+// InteractionsHandlerOption configures an InteractionsHandler.
+type InteractionsHandlerOption func(*InteractionsHandler)
+
+// InteractionsHandlerOptionMaxBodyBytes limits the size of the request bodies (default: 1MiB).
+func InteractionsHandlerOptionMaxBodyBytes(n int64) InteractionsHandlerOption {
+	return func(h *InteractionsHandler) {
+		h.maxBodyBytes = n
+	}
+}
+
+// InteractionsHandlerOptionErrorHandler sets a callback for the requests that are rejected,
+// because they cannot be verified or parsed.
+func InteractionsHandlerOptionErrorHandler(f func(r *http.Request, err error)) InteractionsHandlerOption {
+	return func(h *InteractionsHandler) {
+		h.onError = f
+	}
+}
+
+// InteractionsHandler is an http.Handler serving the interactivity request URL of an app.
+//
+// It verifies the signature of every request and dispatches the payloads asynchronously
+// with its Router. The HTTP response is sent as soon as a handler responds, or after
+// 2.5 seconds, whichever comes first.
+type InteractionsHandler struct {
+	signingSecret string
+	maxBodyBytes  int64
+	onError       func(r *http.Request, err error)
+
+	router *Router
+}
+
+// NewInteractionsHandler returns an InteractionsHandler verifying requests with the app's signing secret.
+func NewInteractionsHandler(signingSecret string, options ...InteractionsHandlerOption) *InteractionsHandler {
+	h := &InteractionsHandler{
+		signingSecret: signingSecret,
+		maxBodyBytes:  defaultMaxBodyBytes,
+		router:        NewRouter(),
+	}
+
+	for _, opt := range options {
+		opt(h)
+	}
+
+	return h
+}
+
+// Router returns the router the interactions are dispatched with, e.g. to register the
+// handlers written for a Router. Request.Ack answers the request like
+// InteractionRequest.Respond does.
+func (h *InteractionsHandler) Router() *Router {
+	return h.router
+}
+
+// Handle adds a handler for an interaction type.
+func (h *InteractionsHandler) Handle(t slack.InteractionType, f InteractionHandlerFunc) {
+	if f == nil {
+		panic("invalid handler cannot be nil")
+	}
+
+	h.router.HandleInteraction(t, interactionRouterHandler(f))
+}
+
+// HandleCallbackID adds a handler for the interactions with the given callback ID:
+// shortcuts, message actions, and submissions or closings of views.
+func (h *InteractionsHandler) HandleCallbackID(callbackID string, f InteractionHandlerFunc) {
+	h.router.HandleInteractionCallbackID(callbackID, interactionRouterHandler(f))
+}
+
+// HandleAction adds a handler for the block actions and the block suggestions of the
+// element with the given action ID.
+func (h *InteractionsHandler) HandleAction(actionID string, f InteractionHandlerFunc) {
+	h.router.HandleInteractionBlockAction(actionID, interactionRouterHandler(f))
+}
+
+// HandleDefault sets the handler of the interactions no other handler matched.
+func (h *InteractionsHandler) HandleDefault(f InteractionHandlerFunc) {
+	if f == nil {
+		h.router.HandleDefault(nil)
+		return
+	}
+
+	h.router.HandleDefault(interactionRouterHandler(f))
+}
+
+// interactionRouterHandler adapts f to a Router. It returns nil when f is nil, so that the
+// Router rejects the registration.
+func interactionRouterHandler(f InteractionHandlerFunc) RouterHandlerFunc {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, req *Request) {
+		callback, ok := req.InteractionCallback()
+		if !ok {
+			return
+		}
+
+		f(ctx, &InteractionRequest{Callback: callback, req: req})
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *InteractionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := readVerifiedBody(w, r, h.signingSecret, h.maxBodyBytes)
+	if err != nil {
+		reject(w, r, h.onError, err)
+		return
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		reject(w, r, h.onError, &httpError{status: http.StatusBadRequest, err: err})
+		return
+	}
+
+	payload := values.Get("payload")
+	if payload == "" {
+		reject(w, r, h.onError, &httpError{status: http.StatusBadRequest, err: errors.New("payload is empty")})
+		return
+	}
+
+	callback, err := parseInteractionCallback(payload)
+	if err != nil {
+		reject(w, r, h.onError, &httpError{status: http.StatusBadRequest, err: err})
+		return
+	}
+
+	req := NewRequest(RequestTypeInteractive, callback, nil)
+	serveRequest(w, req, defaultAckTimeout, func(ctx context.Context) {
+		h.router.Dispatch(ctx, req)
+	})
+}
+
+// followUp sends a message to a response URL.
+func followUp(ctx context.Context, api *slack.Client, responseURL, responseType string, options ...slack.MsgOption) error {
+	if responseURL == "" {
+		return errNoResponseURL
+	}
+
+	options = append(options, slack.MsgOptionResponseURL(responseURL, responseType))
+	_, _, err := api.PostMessageContext(ctx, "", options...)
+	return err
+}
@@ -0,0 +1,125 @@
+package slackevents
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func newInteractionRequest(t *testing.T, payload string) *http.Request {
+	t.Helper()
+
+	return newSignedRequest(t, "application/x-www-form-urlencoded", url.Values{"payload": {payload}}.Encode())
+}
+
+func TestInteractionsHandler(t *testing.T) {
+	h := NewInteractionsHandler(testSigningSecret)
+	h.HandleCallbackID("create_ticket", func(ctx context.Context, req *InteractionRequest) {
+		req.RespondViewSubmission(slack.NewErrorsViewSubmissionResponse(map[string]string{"title": "required"}))
+	})
+	h.HandleAction("pick_repo", func(ctx context.Context, req *InteractionRequest) {
+		req.RespondOptions(slack.NewOptionBlockObject("slack-go", slack.NewTextBlockObject(slack.PlainTextType, "slack-go", false, false), nil))
+	})
+
+	var types []slack.InteractionType
+	h.Handle(slack.InteractionTypeBlockActions, func(ctx context.Context, req *InteractionRequest) {
+		types = append(types, req.Callback.Type)
+	})
+
+	tests := []struct {
+		name       string
+		req        *http.Request
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "view submission",
+			req:        newInteractionRequest(t, `{"type":"view_submission","view":{"callback_id":"create_ticket"}}`),
+			wantStatus: http.StatusOK,
+			wantBody:   `{"response_action":"errors","errors":{"title":"required"}}`,
+		},
+		{
+			name:       "block suggestion",
+			req:        newInteractionRequest(t, `{"type":"block_suggestion","action_id":"pick_repo","value":"sla"}`),
+			wantStatus: http.StatusOK,
+			wantBody:   `{"options":[{"text":{"type":"plain_text","text":"slack-go","emoji":false},"value":"slack-go"}]}`,
+		},
+		{
+			name:       "unanswered block actions",
+			req:        newInteractionRequest(t, `{"type":"block_actions","actions":[{"action_id":"approve"}]}`),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing payload",
+			req:        newSignedRequest(t, "application/x-www-form-urlencoded", "command=%2Fecho"),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid signature",
+			req: func() *http.Request {
+				req := newInteractionRequest(t, `{"type":"block_actions"}`)
+				req.Header.Set("X-Slack-Signature", "v0=00")
+				return req
+			}(),
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, test.req)
+
+			if rec.Code != test.wantStatus {
+				t.Fatalf("status: expected %d, got %d", test.wantStatus, rec.Code)
+			}
+			if rec.Body.String() != test.wantBody {
+				t.Errorf("body: expected %q, got %q", test.wantBody, rec.Body.String())
+			}
+		})
+	}
+
+	if len(types) != 1 || types[0] != slack.InteractionTypeBlockActions {
+		t.Errorf("expected the block actions handler to run once, got %v", types)
+	}
+}
+
+func TestInteractionRequest_FollowUp(t *testing.T) {
+	received := make(chan map[string]interface{}, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg map[string]interface{}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &msg)
+		received <- msg
+		w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	req := &InteractionRequest{
+		Callback: slack.InteractionCallback{
+			ViewSubmissionCallback: slack.ViewSubmissionCallback{
+				ResponseURLs: []slack.ViewSubmissionCallbackResponseURL{{ResponseURL: ts.URL}},
+			},
+		},
+	}
+
+	api := slack.New("testing-token")
+	if err := req.FollowUp(context.Background(), api, slack.ResponseTypeInChannel, slack.MsgOptionText("done", false)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	msg := <-received
+	if msg["text"] != "done" || msg["response_type"] != slack.ResponseTypeInChannel {
+		t.Errorf("unexpected follow-up %v", msg)
+	}
+
+	if err := (&InteractionRequest{}).FollowUp(context.Background(), api, slack.ResponseTypeEphemeral); err != errNoResponseURL {
+		t.Errorf("expected errNoResponseURL, got %v", err)
+	}
+}
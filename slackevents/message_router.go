@@ -0,0 +1,580 @@
+package slackevents
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/slack-go/slack/slackutilsx"
+)
+
+// MessageArgumentType is the type of an argument of a MessageRouter command.
+type MessageArgumentType string
+
+const (
+	// MessageArgumentString is any single word, or a "double quoted" sentence.
+	MessageArgumentString MessageArgumentType = "string"
+	// MessageArgumentInt is an integer.
+	MessageArgumentInt MessageArgumentType = "int"
+	// MessageArgumentUser is a user mention, e.g. <@U0123456>. Its value is the user ID.
+	MessageArgumentUser MessageArgumentType = "user"
+	// MessageArgumentChannel is a channel reference, e.g. <#C0123456|general>. Its value is the channel ID.
+	MessageArgumentChannel MessageArgumentType = "channel"
+	// MessageArgumentDuration is a duration as understood by time.ParseDuration, with
+	// an additional "d" unit for days, e.g. 90m or 2d.
+	MessageArgumentDuration MessageArgumentType = "duration"
+)
+
+// MessageRequest is a message matched by a MessageRouter route.
+type MessageRequest struct {
+	// Event is the matched event, either a *MessageEvent or an *AppMentionEvent.
+	Event interface{}
+
+	User            string
+	Channel         string
+	ChannelType     slackutilsx.ChannelType
+	TimeStamp       string
+	ThreadTimeStamp string
+	// Text is the text of the message, without the leading mention of the bot.
+	Text string
+	// IsMention is true when the bot was mentioned at the start of the message.
+	IsMention bool
+
+	// Matches holds the submatches of a regular expression route, and the remaining text
+	// after the prefix of a prefix route.
+	Matches []string
+	// Args holds the typed arguments of a command route, by name.
+	Args map[string]interface{}
+}
+
+// StringArg returns the string argument with the given name.
+func (m *MessageRequest) StringArg(name string) string {
+	s, _ := m.Args[name].(string)
+	return s
+}
+
+// IntArg returns the integer argument with the given name.
+func (m *MessageRequest) IntArg(name string) int {
+	i, _ := m.Args[name].(int)
+	return i
+}
+
+// DurationArg returns the duration argument with the given name.
+func (m *MessageRequest) DurationArg(name string) time.Duration {
+	d, _ := m.Args[name].(time.Duration)
+	return d
+}
+
+// UserArg returns the user ID of the user argument with the given name.
+func (m *MessageRequest) UserArg(name string) string {
+	return m.StringArg(name)
+}
+
+// ChannelArg returns the channel ID of the channel argument with the given name.
+func (m *MessageRequest) ChannelArg(name string) string {
+	return m.StringArg(name)
+}
+
+// MessageHandlerFunc handles a message matched by a MessageRouter route.
+type MessageHandlerFunc func(ctx context.Context, msg *MessageRequest)
+
+// MessageRouteOption configures a MessageRouter route.
+type MessageRouteOption func(*messageRoute)
+
+// MessageRouteDescription sets the description of the route shown in the help listing.
+func MessageRouteDescription(description string) MessageRouteOption {
+	return func(r *messageRoute) {
+		r.description = description
+	}
+}
+
+// MessageRouteUsage overrides the usage of the route shown in the help listing.
+func MessageRouteUsage(usage string) MessageRouteOption {
+	return func(r *messageRoute) {
+		r.usage = usage
+	}
+}
+
+// MessageRouteDirectOnly restricts the route to direct messages.
+func MessageRouteDirectOnly() MessageRouteOption {
+	return func(r *messageRoute) {
+		r.directOnly = true
+		r.channelOnly = false
+	}
+}
+
+// MessageRouteChannelOnly restricts the route to messages posted in channels.
+func MessageRouteChannelOnly() MessageRouteOption {
+	return func(r *messageRoute) {
+		r.channelOnly = true
+		r.directOnly = false
+	}
+}
+
+// MessageRouteHidden hides the route from the help listing.
+func MessageRouteHidden() MessageRouteOption {
+	return func(r *messageRoute) {
+		r.hidden = true
+	}
+}
+
+type messageArgument struct {
+	name     string
+	literal  string
+	typ      MessageArgumentType
+	optional bool
+	rest     bool
+}
+
+type messageRoute struct {
+	usage       string
+	description string
+	directOnly  bool
+	channelOnly bool
+	hidden      bool
+
+	// match returns whether the text matches the route, along with the captured values.
+	match   func(text string) (matches []string, args map[string]interface{}, ok bool)
+	handler MessageHandlerFunc
+}
+
+// MessageRouter routes the text of message and app_mention events to handlers, by prefix,
+// regular expression or command pattern. Routes are tried in the order they were registered
+// and the first matching route handles the message.
+//
+// Messages posted by the bot itself, message edits and deletions are ignored. In channels,
+// message events mentioning the bot are ignored as well, as they are delivered again as
+// app_mention events.
+type MessageRouter struct {
+	// BotUserID is the user ID of the bot, used to recognise its mentions and its own messages.
+	BotUserID string
+	// BotID is the bot ID of the bot, used to recognise its own messages.
+	BotID string
+	// IgnoreBots ignores the messages posted by any bot, not only ours.
+	IgnoreBots bool
+	// NotFound, if set, handles messages mentioning the bot or sent to it directly that match no route.
+	NotFound MessageHandlerFunc
+
+	routes []*messageRoute
+}
+
+// NewMessageRouter returns a MessageRouter for the bot with the given user ID.
+func NewMessageRouter(botUserID string) *MessageRouter {
+	return &MessageRouter{BotUserID: botUserID}
+}
+
+// HandlePrefix routes the messages starting with prefix, case insensitively. When prefix ends
+// with a letter or a digit, it must be followed by a space or the end of the text, so that
+// "!deploy" does not match "!deployment". The text following the prefix is available as the
+// single element of MessageRequest.Matches.
+func (r *MessageRouter) HandlePrefix(prefix string, f MessageHandlerFunc, options ...MessageRouteOption) {
+	if prefix == "" {
+		panic("invalid prefix cannot be empty")
+	}
+
+	last := []rune(prefix)[len([]rune(prefix))-1]
+	wordPrefix := unicode.IsLetter(last) || unicode.IsDigit(last)
+
+	r.add(prefix+" ...", func(text string) ([]string, map[string]interface{}, bool) {
+		if len(text) < len(prefix) || !strings.EqualFold(text[:len(prefix)], prefix) {
+			return nil, nil, false
+		}
+
+		rest := text[len(prefix):]
+		if wordPrefix && rest != "" && !unicode.IsSpace([]rune(rest)[0]) {
+			return nil, nil, false
+		}
+
+		return []string{strings.TrimSpace(rest)}, nil, true
+	}, f, options)
+}
+
+// HandleRegexp routes the messages matching re. The submatches are available as MessageRequest.Matches.
+func (r *MessageRouter) HandleRegexp(re *regexp.Regexp, f MessageHandlerFunc, options ...MessageRouteOption) {
+	if re == nil {
+		panic("invalid regular expression cannot be nil")
+	}
+
+	r.add(re.String(), func(text string) ([]string, map[string]interface{}, bool) {
+		matches := re.FindStringSubmatch(text)
+		if matches == nil {
+			return nil, nil, false
+		}
+
+		args := make(map[string]interface{})
+		for i, name := range re.SubexpNames() {
+			if name != "" {
+				args[name] = matches[i]
+			}
+		}
+
+		return matches, args, true
+	}, f, options)
+}
+
+// HandleCommand routes the messages matching a command pattern. A pattern is a list of words
+// separated by spaces, where literal words match case insensitively and arguments are written
+// as <name> or <name:type>, type being one of the MessageArgumentType values and defaulting to
+// string. Trailing arguments can be made optional by surrounding them with brackets, as in
+// [<name:type>], and the last argument can capture the rest of the text as in <name...>.
+//
+// For example:
+//
+//	deploy <service> to <env> [<delay:duration>]
+//	remind <who:user> <what...>
+//
+// HandleCommand panics when the pattern is invalid.
+func (r *MessageRouter) HandleCommand(pattern string, f MessageHandlerFunc, options ...MessageRouteOption) {
+	arguments, err := parseMessagePattern(pattern)
+	if err != nil {
+		panic(fmt.Sprintf("invalid command pattern %q: %s", pattern, err))
+	}
+
+	r.add(pattern, func(text string) ([]string, map[string]interface{}, bool) {
+		args, ok := matchMessageArguments(arguments, text)
+		return nil, args, ok
+	}, f, options)
+}
+
+func (r *MessageRouter) add(usage string, match func(string) ([]string, map[string]interface{}, bool), f MessageHandlerFunc, options []MessageRouteOption) {
+	if f == nil {
+		panic("invalid handler cannot be nil")
+	}
+
+	route := &messageRoute{usage: usage, match: match, handler: f}
+	for _, opt := range options {
+		opt(route)
+	}
+
+	r.routes = append(r.routes, route)
+}
+
+// Help returns the listing of the visible routes, one per line, in registration order.
+func (r *MessageRouter) Help() string {
+	var b strings.Builder
+	for _, route := range r.routes {
+		if route.hidden {
+			continue
+		}
+
+		fmt.Fprintf(&b, "`%s`", route.usage)
+		if route.description != "" {
+			fmt.Fprintf(&b, " - %s", route.description)
+		}
+		switch {
+		case route.directOnly:
+			b.WriteString(" (direct messages only)")
+		case route.channelOnly:
+			b.WriteString(" (channels only)")
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// Dispatch routes the inner message or app_mention event of an Events API event. It reports
+// whether the event was handled, including by the NotFound handler.
+func (r *MessageRouter) Dispatch(ctx context.Context, event EventsAPIEvent) bool {
+	msg, ok := r.newRequest(event.InnerEvent.Data)
+	if !ok {
+		return false
+	}
+
+	for _, route := range r.routes {
+		if route.directOnly && msg.ChannelType != slackutilsx.CTypeDM {
+			continue
+		}
+		if route.channelOnly && msg.ChannelType == slackutilsx.CTypeDM {
+			continue
+		}
+
+		matches, args, ok := route.match(msg.Text)
+		if !ok {
+			continue
+		}
+
+		msg.Matches = matches
+		msg.Args = args
+		route.handler(ctx, msg)
+
+		return true
+	}
+
+	if r.NotFound != nil && (msg.IsMention || msg.ChannelType == slackutilsx.CTypeDM) {
+		r.NotFound(ctx, msg)
+		return true
+	}
+
+	return false
+}
+
+// newRequest turns a message or app_mention event into a MessageRequest, unless it
+// should be ignored.
+func (r *MessageRouter) newRequest(data interface{}) (*MessageRequest, bool) {
+	var msg *MessageRequest
+
+	switch ev := data.(type) {
+	case *MessageEvent:
+		switch ev.SubType {
+		case "", "thread_broadcast", "file_share", "me_message":
+		case "bot_message":
+			if r.IgnoreBots {
+				return nil, false
+			}
+		default:
+			// message_changed, message_deleted, channel_join etc.
+			return nil, false
+		}
+		if r.isSelf(ev.User, ev.BotID) || (r.IgnoreBots && ev.BotID != "") {
+			return nil, false
+		}
+
+		msg = &MessageRequest{
+			Event:           ev,
+			User:            ev.User,
+			Channel:         ev.Channel,
+			ChannelType:     messageChannelType(ev.Channel, ev.ChannelType),
+			TimeStamp:       ev.TimeStamp,
+			ThreadTimeStamp: ev.ThreadTimeStamp,
+			Text:            ev.Text,
+		}
+		if msg.ChannelType != slackutilsx.CTypeDM && r.BotUserID != "" && strings.Contains(ev.Text, "<@"+r.BotUserID) {
+			// Delivered again as an app_mention event
+			return nil, false
+		}
+	case *AppMentionEvent:
+		if ev.Edited != nil || r.isSelf(ev.User, ev.BotID) || (r.IgnoreBots && ev.BotID != "") {
+			return nil, false
+		}
+
+		msg = &MessageRequest{
+			Event:           ev,
+			User:            ev.User,
+			Channel:         ev.Channel,
+			ChannelType:     messageChannelType(ev.Channel, ""),
+			TimeStamp:       ev.TimeStamp,
+			ThreadTimeStamp: ev.ThreadTimeStamp,
+			Text:            ev.Text,
+		}
+	default:
+		return nil, false
+	}
+
+	msg.Text, msg.IsMention = r.stripMention(msg.Text)
+
+	return msg, true
+}
+
+func (r *MessageRouter) isSelf(user, botID string) bool {
+	return (r.BotUserID != "" && user == r.BotUserID) || (r.BotID != "" && botID == r.BotID)
+}
+
+// stripMention removes the leading mention of the bot from text, along with the
+// punctuation usually following it.
+func (r *MessageRouter) stripMention(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	if r.BotUserID == "" || !strings.HasPrefix(text, "<@"+r.BotUserID) {
+		return text, false
+	}
+
+	end := strings.IndexByte(text, '>')
+	if end < 0 {
+		return text, false
+	}
+	if id := strings.SplitN(text[2:end], "|", 2)[0]; id != r.BotUserID {
+		return text, false
+	}
+
+	return strings.TrimLeftFunc(strings.TrimLeft(text[end+1:], ":,"), unicode.IsSpace), true
+}
+
+func messageChannelType(channel, channelType string) slackutilsx.ChannelType {
+	switch channelType {
+	case "im":
+		return slackutilsx.CTypeDM
+	case "group", "mpim":
+		return slackutilsx.CTypeGroup
+	case "channel":
+		return slackutilsx.CTypeChannel
+	default:
+		return slackutilsx.DetectChannelType(channel)
+	}
+}
+
+func parseMessagePattern(pattern string) ([]messageArgument, error) {
+	var arguments []messageArgument
+
+	for _, word := range strings.Fields(pattern) {
+		if n := len(arguments); n > 0 && arguments[n-1].rest {
+			return nil, fmt.Errorf("%q follows an argument capturing the rest of the text", word)
+		}
+
+		optional := strings.HasPrefix(word, "[") && strings.HasSuffix(word, "]")
+		if optional {
+			word = word[1 : len(word)-1]
+		}
+
+		if !strings.HasPrefix(word, "<") || !strings.HasSuffix(word, ">") {
+			if optional {
+				return nil, fmt.Errorf("literal %q cannot be optional", word)
+			}
+			if n := len(arguments); n > 0 && arguments[n-1].optional {
+				return nil, fmt.Errorf("literal %q follows an optional argument", word)
+			}
+			arguments = append(arguments, messageArgument{literal: word})
+			continue
+		}
+
+		arg := messageArgument{name: word[1 : len(word)-1], typ: MessageArgumentString, optional: optional}
+		if strings.HasSuffix(arg.name, "...") {
+			arg.name = strings.TrimSuffix(arg.name, "...")
+			arg.rest = true
+		}
+		if i := strings.IndexByte(arg.name, ':'); i >= 0 {
+			arg.typ = MessageArgumentType(arg.name[i+1:])
+			arg.name = arg.name[:i]
+		}
+
+		switch arg.typ {
+		case MessageArgumentString, MessageArgumentInt, MessageArgumentUser, MessageArgumentChannel, MessageArgumentDuration:
+		default:
+			return nil, fmt.Errorf("unknown argument type %q", arg.typ)
+		}
+		if arg.name == "" {
+			return nil, fmt.Errorf("argument name cannot be empty")
+		}
+		if arg.rest && arg.typ != MessageArgumentString {
+			return nil, fmt.Errorf("argument %q capturing the rest of the text must be a string", arg.name)
+		}
+		if n := len(arguments); n > 0 && arguments[n-1].optional && !optional {
+			return nil, fmt.Errorf("argument %q follows an optional argument", arg.name)
+		}
+
+		arguments = append(arguments, arg)
+	}
+
+	if len(arguments) == 0 {
+		return nil, fmt.Errorf("pattern cannot be empty")
+	}
+
+	return arguments, nil
+}
+
+func matchMessageArguments(arguments []messageArgument, text string) (map[string]interface{}, bool) {
+	tokens := tokenizeMessage(text)
+	args := make(map[string]interface{})
+
+	for i, arg := range arguments {
+		if i >= len(tokens) {
+			if arg.optional {
+				continue
+			}
+			return nil, false
+		}
+
+		tok := tokens[i]
+		if arg.literal != "" {
+			if !strings.EqualFold(tok.value, arg.literal) {
+				return nil, false
+			}
+			continue
+		}
+
+		if arg.rest {
+			args[arg.name] = strings.TrimSpace(text[tok.start:])
+			return args, true
+		}
+
+		value, ok := parseMessageArgument(arg.typ, tok.value)
+		if !ok {
+			return nil, false
+		}
+		args[arg.name] = value
+	}
+
+	if len(tokens) > len(arguments) {
+		return nil, false
+	}
+
+	return args, true
+}
+
+func parseMessageArgument(typ MessageArgumentType, value string) (interface{}, bool) {
+	switch typ {
+	case MessageArgumentInt:
+		i, err := strconv.Atoi(value)
+		return i, err == nil
+	case MessageArgumentUser:
+		return parseMessageReference(value, "<@")
+	case MessageArgumentChannel:
+		return parseMessageReference(value, "<#")
+	case MessageArgumentDuration:
+		if days := strings.TrimSuffix(value, "d"); days != value {
+			n, err := strconv.Atoi(days)
+			return time.Duration(n) * 24 * time.Hour, err == nil && n >= 0
+		}
+		d, err := time.ParseDuration(value)
+		return d, err == nil
+	default:
+		return value, true
+	}
+}
+
+// parseMessageReference extracts the ID of a <@U123|name> or <#C123|name> reference.
+func parseMessageReference(value, prefix string) (string, bool) {
+	if !strings.HasPrefix(value, prefix) || !strings.HasSuffix(value, ">") {
+		return "", false
+	}
+
+	id := strings.SplitN(value[len(prefix):len(value)-1], "|", 2)[0]
+
+	return id, id != ""
+}
+
+type messageToken struct {
+	value string
+	start int
+}
+
+// tokenizeMessage splits text on spaces, keeping "double quoted" sentences together.
+func tokenizeMessage(text string) []messageToken {
+	var (
+		tokens []messageToken
+		cur    strings.Builder
+		start  = -1
+		quoted bool
+	)
+
+	flush := func() {
+		if start >= 0 {
+			tokens = append(tokens, messageToken{value: cur.String(), start: start})
+		}
+		cur.Reset()
+		start = -1
+	}
+
+	for i, r := range text {
+		switch {
+		case r == '"':
+			if start < 0 {
+				start = i
+			}
+			quoted = !quoted
+		case unicode.IsSpace(r) && !quoted:
+			flush()
+		default:
+			if start < 0 {
+				start = i
+			}
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+
+	return tokens
+}
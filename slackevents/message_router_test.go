@@ -0,0 +1,186 @@
+package slackevents
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack/slackutilsx"
+)
+
+func newMessageEvent(channel, channelType, user, text string) EventsAPIEvent {
+	return EventsAPIEvent{
+		Type: CallbackEvent,
+		InnerEvent: EventsAPIInnerEvent{
+			Type: string(Message),
+			Data: &MessageEvent{Type: "message", Channel: channel, ChannelType: channelType, User: user, Text: text, TimeStamp: "1.2"},
+		},
+	}
+}
+
+func newAppMentionEvent(channel, user, text string) EventsAPIEvent {
+	return EventsAPIEvent{
+		Type: CallbackEvent,
+		InnerEvent: EventsAPIInnerEvent{
+			Type: string(AppMention),
+			Data: &AppMentionEvent{Type: "app_mention", Channel: channel, User: user, Text: text},
+		},
+	}
+}
+
+func TestMessageRouter_HandleCommand(t *testing.T) {
+	r := NewMessageRouter("UBOT")
+
+	var got *MessageRequest
+	r.HandleCommand("deploy <service> to <env> [<delay:duration>]", func(ctx context.Context, msg *MessageRequest) {
+		got = msg
+	})
+	r.HandleCommand("remind <who:user> in <channel:channel> <what...>", func(ctx context.Context, msg *MessageRequest) {
+		got = msg
+	})
+
+	if !r.Dispatch(context.Background(), newAppMentionEvent("C1", "U1", "<@UBOT>: deploy \"api server\" to prod 2d")) {
+		t.Fatal("expected the mention to be handled")
+	}
+	if got.StringArg("service") != "api server" || got.StringArg("env") != "prod" || got.DurationArg("delay") != 48*time.Hour {
+		t.Fatalf("unexpected arguments %v", got.Args)
+	}
+	if !got.IsMention || got.Text != "deploy \"api server\" to prod 2d" {
+		t.Fatalf("expected the mention to be stripped, got %q", got.Text)
+	}
+
+	if !r.Dispatch(context.Background(), newMessageEvent("D1", "im", "U1", "Deploy web to staging")) {
+		t.Fatal("expected the direct message to be handled")
+	}
+	if _, ok := got.Args["delay"]; ok || got.ChannelType != slackutilsx.CTypeDM {
+		t.Fatalf("unexpected request %+v", got)
+	}
+
+	if !r.Dispatch(context.Background(), newMessageEvent("D1", "im", "U1", "remind <@U2|bob> in <#C3|ops> to   review the PR")) {
+		t.Fatal("expected the reminder to be handled")
+	}
+	if got.UserArg("who") != "U2" || got.ChannelArg("channel") != "C3" || got.StringArg("what") != "to   review the PR" {
+		t.Fatalf("unexpected arguments %v", got.Args)
+	}
+
+	for _, text := range []string{"deploy web", "deploy web to prod soon", "deploy web to prod 1h extra"} {
+		if r.Dispatch(context.Background(), newMessageEvent("D1", "im", "U1", text)) {
+			t.Errorf("%q should not have matched", text)
+		}
+	}
+}
+
+func TestMessageRouter_Filters(t *testing.T) {
+	r := NewMessageRouter("UBOT")
+	r.BotID = "BBOT"
+
+	var calls int
+	r.HandlePrefix("ping", func(ctx context.Context, msg *MessageRequest) { calls++ })
+	r.HandleRegexp(regexp.MustCompile(`^dm (?P<what>\w+)$`), func(ctx context.Context, msg *MessageRequest) {
+		calls++
+		if msg.StringArg("what") != "only" {
+			t.Errorf("unexpected arguments %v", msg.Args)
+		}
+	}, MessageRouteDirectOnly())
+
+	edited := newMessageEvent("C1", "channel", "U1", "ping")
+	edited.InnerEvent.Data.(*MessageEvent).SubType = "message_changed"
+
+	self := newMessageEvent("C1", "channel", "", "ping")
+	self.InnerEvent.Data.(*MessageEvent).BotID = "BBOT"
+
+	mentionEdit := newAppMentionEvent("C1", "U1", "<@UBOT> ping")
+	mentionEdit.InnerEvent.Data.(*AppMentionEvent).Edited = &Edited{User: "U1"}
+
+	ignored := []EventsAPIEvent{
+		edited,
+		self,
+		mentionEdit,
+		newMessageEvent("C1", "channel", "UBOT", "ping"),
+		// delivered again as app_mention
+		newMessageEvent("C1", "channel", "U1", "<@UBOT> ping"),
+		// direct messages only
+		newMessageEvent("C1", "channel", "U1", "dm only"),
+	}
+	for _, evt := range ignored {
+		if r.Dispatch(context.Background(), evt) {
+			t.Errorf("%+v should have been ignored", evt.InnerEvent.Data)
+		}
+	}
+	if calls != 0 {
+		t.Fatalf("expected no handler to be called, got %d calls", calls)
+	}
+
+	r.Dispatch(context.Background(), newMessageEvent("C1", "channel", "U1", "ping pong"))
+	r.Dispatch(context.Background(), newMessageEvent("D1", "im", "U1", "dm only"))
+	if calls != 2 {
+		t.Fatalf("expected 2 handler calls, got %d", calls)
+	}
+}
+
+func TestMessageRouter_HandlePrefix(t *testing.T) {
+	r := NewMessageRouter("UBOT")
+
+	var matches []string
+	r.HandlePrefix("!deploy", func(ctx context.Context, msg *MessageRequest) { matches = append(matches, msg.Matches[0]) })
+	r.HandlePrefix("todo:", func(ctx context.Context, msg *MessageRequest) { matches = append(matches, msg.Matches[0]) })
+
+	for _, text := range []string{"!deploy", "!DEPLOY api", "todo:buy milk"} {
+		if !r.Dispatch(context.Background(), newMessageEvent("C1", "channel", "U1", text)) {
+			t.Errorf("expected %q to match", text)
+		}
+	}
+	if r.Dispatch(context.Background(), newMessageEvent("C1", "channel", "U1", "!deployment api")) {
+		t.Error("expected a word prefix not to match a longer word")
+	}
+
+	expected := []string{"", "api", "buy milk"}
+	if !reflect.DeepEqual(matches, expected) {
+		t.Errorf("expected %q, got %q", expected, matches)
+	}
+}
+
+func TestMessageRouter_NotFoundAndHelp(t *testing.T) {
+	r := NewMessageRouter("UBOT")
+	r.HandleCommand("status <service>", func(ctx context.Context, msg *MessageRequest) {}, MessageRouteDescription("Shows the status of a service"))
+	r.HandlePrefix("secret", func(ctx context.Context, msg *MessageRequest) {}, MessageRouteHidden())
+	r.HandlePrefix("!dm", func(ctx context.Context, msg *MessageRequest) {}, MessageRouteDirectOnly())
+
+	var notFound string
+	r.NotFound = func(ctx context.Context, msg *MessageRequest) { notFound = msg.Text }
+
+	if r.Dispatch(context.Background(), newMessageEvent("C1", "channel", "U1", "chatter")) {
+		t.Fatal("unmatched channel chatter should not reach NotFound")
+	}
+	if !r.Dispatch(context.Background(), newAppMentionEvent("C1", "U1", "<@UBOT> help")) || notFound != "help" {
+		t.Fatalf("expected NotFound to handle the mention, got %q", notFound)
+	}
+
+	want := "`status <service>` - Shows the status of a service\n`!dm ...` (direct messages only)\n"
+	if got := r.Help(); got != want {
+		t.Fatalf("unexpected help listing:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMessageRouter_InvalidPatterns(t *testing.T) {
+	for _, pattern := range []string{
+		"",
+		"deploy <service:float>",
+		"deploy [<service>] <env>",
+		"remind <what...> later",
+		"remind <n:int...>",
+		"deploy [now]",
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("pattern %q should have panicked", pattern)
+				}
+			}()
+
+			NewMessageRouter("UBOT").HandleCommand(pattern, func(ctx context.Context, msg *MessageRequest) {})
+		}()
+	}
+}
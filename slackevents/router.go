@@ -0,0 +1,345 @@
+package slackevents
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/slack-go/slack"
+)
+
+// RequestType is the type of a Request served by a Router.
+type RequestType string
+
+const (
+	// RequestTypeEventsAPI is an Events API event. Request.Data is an EventsAPIEvent.
+	RequestTypeEventsAPI RequestType = "events_api"
+	// RequestTypeInteractive is an interactivity payload. Request.Data is a slack.InteractionCallback.
+	RequestTypeInteractive RequestType = "interactive"
+	// RequestTypeSlashCommand is a slash command invocation. Request.Data is a slack.SlashCommand.
+	RequestTypeSlashCommand RequestType = "slash_commands"
+)
+
+// Acknowledger acknowledges a Request over the transport it was received over.
+type Acknowledger interface {
+	Ack(ctx context.Context, payload interface{}) error
+}
+
+// AcknowledgerFunc is a function implementing Acknowledger.
+type AcknowledgerFunc func(ctx context.Context, payload interface{}) error
+
+// Ack implements Acknowledger.
+func (f AcknowledgerFunc) Ack(ctx context.Context, payload interface{}) error {
+	return f(ctx, payload)
+}
+
+// Request is a request from Slack, independent of the transport, Socket Mode or HTTP,
+// it was received over.
+type Request struct {
+	Type RequestType
+	// Data is an EventsAPIEvent, a slack.InteractionCallback or a slack.SlashCommand, depending on Type.
+	Data interface{}
+
+	// EnvelopeID is the Socket Mode envelope ID of the request. It is empty over HTTP.
+	EnvelopeID string
+	// RetryAttempt and RetryReason describe the retried deliveries of a request.
+	RetryAttempt int
+	RetryReason  string
+
+	acknowledger Acknowledger
+	acked        int32
+}
+
+// NewRequest returns a Request acknowledged with ack. It is meant to be used by transports.
+func NewRequest(typ RequestType, data interface{}, ack Acknowledger) *Request {
+	return &Request{Type: typ, Data: data, acknowledger: ack}
+}
+
+// Ack acknowledges the request, optionally with a response payload. Slack expects every
+// request to be acknowledged within 3 seconds. Only the first call has an effect.
+func (r *Request) Ack(ctx context.Context, payload ...interface{}) error {
+	if !atomic.CompareAndSwapInt32(&r.acked, 0, 1) {
+		return nil
+	}
+
+	var pld interface{}
+	if len(payload) > 0 {
+		pld = payload[0]
+	}
+
+	if r.acknowledger == nil {
+		return nil
+	}
+
+	return r.acknowledger.Ack(ctx, pld)
+}
+
+// Acked reports whether the request was acknowledged.
+func (r *Request) Acked() bool {
+	return atomic.LoadInt32(&r.acked) == 1
+}
+
+// EventsAPIEvent returns the event of an events_api request.
+func (r *Request) EventsAPIEvent() (EventsAPIEvent, bool) {
+	evt, ok := r.Data.(EventsAPIEvent)
+	return evt, ok
+}
+
+// InteractionCallback returns the payload of an interactive request.
+func (r *Request) InteractionCallback() (slack.InteractionCallback, bool) {
+	callback, ok := r.Data.(slack.InteractionCallback)
+	return callback, ok
+}
+
+// SlashCommand returns the command of a slash_commands request.
+func (r *Request) SlashCommand() (slack.SlashCommand, bool) {
+	cmd, ok := r.Data.(slack.SlashCommand)
+	return cmd, ok
+}
+
+// RouterHandlerFunc handles a Request routed by a Router.
+type RouterHandlerFunc func(ctx context.Context, req *Request)
+
+// RouterMiddlewareFunc wraps a RouterHandlerFunc.
+type RouterMiddlewareFunc func(RouterHandlerFunc) RouterHandlerFunc
+
+// Router routes the requests from Slack to handlers. The same registrations can be served
+// over Socket Mode, see socketmode.Client.RunRouterContext, or over HTTP, see Router.HTTPHandler.
+// The handlers of this package written for a Router, such as HandleInstallationCleanup, are
+// also served by the Routers of an EventsHandler and an InteractionsHandler.
+//
+// Registrations must happen before the Router starts serving requests.
+type Router struct {
+	// Deduplicator, when set, is consulted before dispatching so that retried deliveries
+	// reach the handlers only once. Suppressed requests are acknowledged on the handlers' behalf.
+	Deduplicator *Deduplicator
+
+	//lvl 1 - the most generic type of request
+	requestMap map[RequestType][]RouterHandlerFunc
+	//lvl 2 - Manage requests by inner type
+	interactionMap map[slack.InteractionType][]RouterHandlerFunc
+	eventMap       map[EventsAPIType][]RouterHandlerFunc
+	//lvl 3 - the most user friendly way of managing requests
+	blockActionMap  map[string]RouterHandlerFunc
+	callbackIDMap   map[string]RouterHandlerFunc
+	slashCommandMap map[string]RouterHandlerFunc
+	//lvl 4 - requests matched by a predicate
+	matchMap map[RequestType][]routerMatcher
+	// shortcuts registered with HandleShortcut, for ManifestRequirements
+	shortcuts []slack.Shortcut
+
+	defaultHandler RouterHandlerFunc
+	middlewares    []RouterMiddlewareFunc
+}
+
+// NewRouter returns an empty Router.
+func NewRouter() *Router {
+	return &Router{
+		requestMap:      make(map[RequestType][]RouterHandlerFunc),
+		interactionMap:  make(map[slack.InteractionType][]RouterHandlerFunc),
+		eventMap:        make(map[EventsAPIType][]RouterHandlerFunc),
+		blockActionMap:  make(map[string]RouterHandlerFunc),
+		callbackIDMap:   make(map[string]RouterHandlerFunc),
+		slashCommandMap: make(map[string]RouterHandlerFunc),
+		matchMap:        make(map[RequestType][]routerMatcher),
+	}
+}
+
+// routerMatcher is a handler registered with HandleMatch.
+type routerMatcher struct {
+	match func(req *Request) bool
+	f     RouterHandlerFunc
+}
+
+// Use adds middlewares wrapping every handler, in the order they are given.
+func (r *Router) Use(middlewares ...RouterMiddlewareFunc) {
+	r.middlewares = append(r.middlewares, middlewares...)
+}
+
+// Handle adds a handler for every request of the given type.
+func (r *Router) Handle(t RequestType, f RouterHandlerFunc) {
+	r.requestMap[t] = append(r.requestMap[t], f)
+}
+
+// HandleEvents adds a handler for an Events API inner event type.
+func (r *Router) HandleEvents(t EventsAPIType, f RouterHandlerFunc) {
+	r.eventMap[t] = append(r.eventMap[t], f)
+}
+
+// HandleInteraction adds a handler for an interaction type.
+func (r *Router) HandleInteraction(t slack.InteractionType, f RouterHandlerFunc) {
+	r.interactionMap[t] = append(r.interactionMap[t], f)
+}
+
+// HandleInteractionBlockAction adds a handler for a block action referenced by its action ID.
+// The block_suggestion requests of the external select with the action ID are routed to it too.
+func (r *Router) HandleInteractionBlockAction(actionID string, f RouterHandlerFunc) {
+	register(r.blockActionMap, "actionID", actionID, f)
+}
+
+// HandleInteractionCallbackID adds a handler for the interactions with the given callback ID:
+// shortcuts, message actions, and submissions or closings of views.
+func (r *Router) HandleInteractionCallbackID(callbackID string, f RouterHandlerFunc) {
+	register(r.callbackIDMap, "callbackID", callbackID, f)
+}
+
+// HandleShortcut adds a handler for a global or message shortcut. Unlike
+// HandleInteractionCallbackID, the shortcut is also part of the ManifestRequirements of r.
+func (r *Router) HandleShortcut(shortcut slack.Shortcut, f RouterHandlerFunc) {
+	register(r.callbackIDMap, "callbackID", shortcut.CallbackID, f)
+	r.shortcuts = append(r.shortcuts, shortcut)
+}
+
+// HandleSlashCommand adds a handler for a slash command, e.g. "/deploy".
+func (r *Router) HandleSlashCommand(command string, f RouterHandlerFunc) {
+	register(r.slashCommandMap, "command", command, f)
+}
+
+// HandleMatch adds a handler for the requests of the given type match accepts. Unlike the
+// handlers of Handle, it leaves the requests match rejects to the default handler.
+func (r *Router) HandleMatch(t RequestType, match func(req *Request) bool, f RouterHandlerFunc) {
+	if match == nil {
+		panic("invalid match cannot be nil")
+	}
+	if f == nil {
+		panic("invalid handler cannot be nil")
+	}
+	r.matchMap[t] = append(r.matchMap[t], routerMatcher{match: match, f: f})
+}
+
+// HandleMessages routes the message and app_mention events with a MessageRouter.
+// The events are acknowledged before being dispatched to the router.
+func (r *Router) HandleMessages(router *MessageRouter) {
+	if router == nil {
+		panic("invalid router cannot be nil")
+	}
+
+	f := func(ctx context.Context, req *Request) {
+		evt, ok := req.EventsAPIEvent()
+		if !ok {
+			return
+		}
+
+		req.Ack(ctx)
+		router.Dispatch(ctx, evt)
+	}
+
+	r.HandleEvents(Message, f)
+	r.HandleEvents(AppMention, f)
+}
+
+// HandleDefault sets the handler of the requests no other handler matched.
+func (r *Router) HandleDefault(f RouterHandlerFunc) {
+	r.defaultHandler = f
+}
+
+func register(m map[string]RouterHandlerFunc, kind, key string, f RouterHandlerFunc) {
+	if key == "" {
+		panic("invalid " + kind + " cannot be empty")
+	}
+	if f == nil {
+		panic("invalid handler cannot be nil")
+	}
+	if _, exist := m[key]; exist {
+		panic("multiple registrations for " + kind + " " + key)
+	}
+	m[key] = f
+}
+
+// Dispatch runs the handlers matching the request concurrently and waits for them to return.
+// A request none of the handlers acknowledged is acknowledged without payload once they
+// returned. Dispatch reports whether any handler, including the default one, matched.
+func (r *Router) Dispatch(ctx context.Context, req *Request) bool {
+	if r.isDuplicate(req) {
+		req.Ack(ctx)
+		return true
+	}
+
+	handlers := r.match(req)
+	isHandled := len(handlers) > 0
+	if !isHandled && r.defaultHandler != nil {
+		handlers = append(handlers, r.defaultHandler)
+	}
+
+	wg := new(sync.WaitGroup)
+	for _, f := range handlers {
+		for i := len(r.middlewares) - 1; i >= 0; i-- {
+			f = r.middlewares[i](f)
+		}
+
+		wg.Add(1)
+		go func(f RouterHandlerFunc) {
+			defer wg.Done()
+
+			f(ctx, req)
+		}(f)
+	}
+	wg.Wait()
+
+	req.Ack(ctx)
+
+	return isHandled || r.defaultHandler != nil
+}
+
+// match returns the handlers matching the request, from the most generic to the most specific.
+func (r *Router) match(req *Request) []RouterHandlerFunc {
+	// Level 1 - request type
+	handlers := append([]RouterHandlerFunc(nil), r.requestMap[req.Type]...)
+
+	switch data := req.Data.(type) {
+	case EventsAPIEvent:
+		// Level 2 - Events API inner event type
+		handlers = append(handlers, r.eventMap[EventsAPIType(data.InnerEvent.Type)]...)
+	case slack.InteractionCallback:
+		// Level 2 - interaction type
+		handlers = append(handlers, r.interactionMap[data.Type]...)
+
+		// Level 3 - callback ID and block action IDs
+		callbackID := data.CallbackID
+		if callbackID == "" {
+			callbackID = data.View.CallbackID
+		}
+		if f, ok := r.callbackIDMap[callbackID]; ok && callbackID != "" {
+			handlers = append(handlers, f)
+		}
+		for _, action := range data.ActionCallback.BlockActions {
+			if f, ok := r.blockActionMap[action.ActionID]; ok {
+				handlers = append(handlers, f)
+			}
+		}
+		if data.Type == slack.InteractionTypeBlockSuggestion {
+			if f, ok := r.blockActionMap[data.ActionID]; ok && data.ActionID != "" {
+				handlers = append(handlers, f)
+			}
+		}
+	case slack.SlashCommand:
+		// Level 2 - slash command by name
+		if f, ok := r.slashCommandMap[data.Command]; ok {
+			handlers = append(handlers, f)
+		}
+	}
+
+	// Level 4 - predicates
+	for _, m := range r.matchMap[req.Type] {
+		if m.match(req) {
+			handlers = append(handlers, m.f)
+		}
+	}
+
+	return handlers
+}
+
+// isDuplicate reports whether the request is a retried delivery that was already dispatched.
+func (r *Router) isDuplicate(req *Request) bool {
+	if r.Deduplicator == nil {
+		return false
+	}
+
+	if evt, ok := req.EventsAPIEvent(); ok {
+		if id := EventID(evt); id != "" {
+			return r.Deduplicator.IsDuplicate(id)
+		}
+	}
+
+	return r.Deduplicator.IsDuplicate(req.EnvelopeID)
+}
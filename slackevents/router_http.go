@@ -0,0 +1,246 @@
+package slackevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	// HeaderRetryNum is the header holding the number of a retried Events API delivery.
+	HeaderRetryNum = "X-Slack-Retry-Num"
+	// HeaderRetryReason is the header holding the reason of a retried Events API delivery.
+	HeaderRetryReason = "X-Slack-Retry-Reason"
+
+	// defaultAckTimeout leaves some margin to the 3 seconds Slack waits for a response.
+	defaultAckTimeout = 2500 * time.Millisecond
+)
+
+var errUnknownRequest = errors.New("unknown request: neither an event, an interaction nor a slash command")
+
+// routerHTTPHandler serves a Router over HTTP.
+type routerHTTPHandler struct {
+	router        *Router
+	signingSecret string
+	maxBodyBytes  int64
+	ackTimeout    time.Duration
+}
+
+// HTTPHandler returns an http.Handler serving the router over HTTP. The same handler can be
+// used as the Events API request URL, the interactivity request URL and the slash commands
+// request URL. Requests are verified with the app's signing secret, and their bodies are
+// limited to 1MiB.
+//
+// Requests are dispatched asynchronously, and the HTTP response is sent as soon as a handler
+// acknowledges the request, or after 2.5 seconds, whichever comes first. The payload given to
+// Request.Ack is sent as the JSON body of the response.
+func (r *Router) HTTPHandler(signingSecret string) http.Handler {
+	return &routerHTTPHandler{
+		router:        r,
+		signingSecret: signingSecret,
+		maxBodyBytes:  defaultMaxBodyBytes,
+		ackTimeout:    defaultAckTimeout,
+	}
+}
+
+func (h *routerHTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := readVerifiedBody(w, r, h.signingSecret, h.maxBodyBytes)
+	if err != nil {
+		w.WriteHeader(err.(*httpError).status)
+		return
+	}
+
+	req, challenge, err := parseHTTPRequest(r.Header, body)
+	switch {
+	case err != nil:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	case challenge != nil:
+		writeChallenge(w, challenge)
+		return
+	case req == nil:
+		// Nothing to dispatch, e.g. app_rate_limited notifications
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	serveRequest(w, req, h.ackTimeout, func(ctx context.Context) {
+		h.router.Dispatch(ctx, req)
+	})
+}
+
+// serveRequest runs dispatch in the background and writes the acknowledgement of req
+// as the HTTP response, or an empty response when req was not acknowledged in time.
+func serveRequest(w http.ResponseWriter, req *Request, timeout time.Duration, dispatch func(ctx context.Context)) {
+	acks := make(chan interface{}, 1)
+	req.acknowledger = AcknowledgerFunc(func(ctx context.Context, payload interface{}) error {
+		acks <- payload
+		return nil
+	})
+
+	// The handlers outlive the HTTP request.
+	go dispatch(context.Background())
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	var payload interface{}
+	select {
+	case payload = <-acks:
+	case <-timer.C:
+		// Claim the acknowledgement, unless a handler is acknowledging right now.
+		if !atomic.CompareAndSwapInt32(&req.acked, 0, 1) {
+			payload = <-acks
+		}
+	}
+
+	writeAck(w, payload)
+}
+
+// writeAck writes an acknowledgement payload as an HTTP response.
+func writeAck(w http.ResponseWriter, payload interface{}) {
+	if payload == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if s, ok := payload.(string); ok {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, s)
+		return
+	}
+
+	js, err := json.Marshal(payload)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(js)
+}
+
+func writeChallenge(w http.ResponseWriter, challenge *EventsAPIURLVerificationEvent) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, challenge.Challenge)
+}
+
+// readVerifiedBody reads the body of r, up to maxBytes, and verifies its signature with secret.
+// The errors it returns are *httpError.
+func readVerifiedBody(w http.ResponseWriter, r *http.Request, secret string, maxBytes int64) ([]byte, error) {
+	sv, err := slack.NewSecretsVerifier(r.Header, secret)
+	if err != nil {
+		return nil, &httpError{status: http.StatusUnauthorized, err: err}
+	}
+
+	body, err := io.ReadAll(io.TeeReader(http.MaxBytesReader(w, r.Body, maxBytes), &sv))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, &httpError{status: http.StatusRequestEntityTooLarge, err: err}
+		}
+		return nil, &httpError{status: http.StatusBadRequest, err: err}
+	}
+
+	if err := sv.Ensure(); err != nil {
+		return nil, &httpError{status: http.StatusUnauthorized, err: err}
+	}
+
+	return body, nil
+}
+
+// parseHTTPRequest parses the body of a request sent by Slack to any of the app's request URLs.
+// It returns the url_verification challenge to answer instead of a Request when applicable,
+// and neither when there is nothing to dispatch.
+func parseHTTPRequest(header http.Header, body []byte) (*Request, *EventsAPIURLVerificationEvent, error) {
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	if mediaType == "application/json" {
+		evt, err := ParseEvent(json.RawMessage(body), OptionNoVerifyToken())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch evt.Type {
+		case URLVerification:
+			return nil, evt.Data.(*EventsAPIURLVerificationEvent), nil
+		case CallbackEvent:
+		default:
+			return nil, nil, nil
+		}
+
+		req := NewRequest(RequestTypeEventsAPI, evt, nil)
+		req.RetryAttempt, _ = strconv.Atoi(header.Get(HeaderRetryNum))
+		req.RetryReason = header.Get(HeaderRetryReason)
+
+		return req, nil, nil
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if payload := values.Get("payload"); payload != "" {
+		callback, err := parseInteractionCallback(payload)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return NewRequest(RequestTypeInteractive, callback, nil), nil, nil
+	}
+
+	cmd, err := parseSlashCommand(body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return NewRequest(RequestTypeSlashCommand, cmd, nil), nil, nil
+}
+
+// parseInteractionCallback decodes the payload form value of an interactivity request.
+func parseInteractionCallback(payload string) (slack.InteractionCallback, error) {
+	var callback slack.InteractionCallback
+	if err := json.Unmarshal([]byte(payload), &callback); err != nil {
+		return slack.InteractionCallback{}, err
+	}
+
+	return callback, nil
+}
+
+// parseSlashCommand decodes the form body of a slash command request.
+func parseSlashCommand(body []byte) (slack.SlashCommand, error) {
+	// SlashCommandParse only needs the form of the request
+	r, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	if err != nil {
+		return slack.SlashCommand{}, err
+	}
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	cmd, err := slack.SlashCommandParse(r)
+	if err != nil {
+		return slack.SlashCommand{}, err
+	}
+	if cmd.Command == "" {
+		return slack.SlashCommand{}, errUnknownRequest
+	}
+
+	return cmd, nil
+}
@@ -0,0 +1,151 @@
+package slackevents
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const testSigningSecret = "e6b19c573432dcc6b075501d51b51bb8"
+
+// newSignedRequest returns a request signed the way Slack signs the requests to an app.
+func newSignedRequest(t *testing.T, contentType, body string) *http.Request {
+	t.Helper()
+
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(testSigningSecret))
+	fmt.Fprintf(mac, "v0:%s:%s", ts, body)
+
+	req := httptest.NewRequest(http.MethodPost, "/slack", strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Slack-Request-Timestamp", ts)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+
+	return req
+}
+
+func TestRouterHTTPHandler(t *testing.T) {
+	r := NewRouter()
+
+	mentions := make(chan *Request, 1)
+	r.HandleEvents(AppMention, func(ctx context.Context, req *Request) {
+		mentions <- req
+	})
+	r.HandleSlashCommand("/echo", func(ctx context.Context, req *Request) {
+		cmd, _ := req.SlashCommand()
+		req.Ack(ctx, map[string]string{"text": cmd.Text})
+	})
+	r.HandleInteractionCallbackID("create_ticket", func(ctx context.Context, req *Request) {
+		req.Ack(ctx, slack.NewClearViewSubmissionResponse())
+	})
+
+	h := r.HTTPHandler(testSigningSecret)
+
+	tests := []struct {
+		name        string
+		req         *http.Request
+		wantStatus  int
+		wantBody    string
+		wantRetries int
+	}{
+		{
+			name:       "url verification",
+			req:        newSignedRequest(t, "application/json", `{"type":"url_verification","token":"x","challenge":"3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P"}`),
+			wantStatus: http.StatusOK,
+			wantBody:   "3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P",
+		},
+		{
+			name: "event callback",
+			req: func() *http.Request {
+				req := newSignedRequest(t, "application/json", `{"type":"event_callback","event_id":"Ev1","event":{"type":"app_mention","text":"hi"}}`)
+				req.Header.Set("X-Slack-Retry-Num", "2")
+				return req
+			}(),
+			wantStatus:  http.StatusOK,
+			wantRetries: 2,
+		},
+		{
+			name:       "slash command",
+			req:        newSignedRequest(t, "application/x-www-form-urlencoded", url.Values{"command": {"/echo"}, "text": {"hello"}}.Encode()),
+			wantStatus: http.StatusOK,
+			wantBody:   `{"text":"hello"}`,
+		},
+		{
+			name:       "interaction",
+			req:        newSignedRequest(t, "application/x-www-form-urlencoded", url.Values{"payload": {`{"type":"view_submission","view":{"callback_id":"create_ticket"}}`}}.Encode()),
+			wantStatus: http.StatusOK,
+			wantBody:   `{"response_action":"clear"}`,
+		},
+		{
+			name: "invalid signature",
+			req: func() *http.Request {
+				req := newSignedRequest(t, "application/json", `{}`)
+				req.Header.Set("X-Slack-Signature", "v0=00")
+				return req
+			}(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "unknown form",
+			req:        newSignedRequest(t, "application/x-www-form-urlencoded", "foo=bar"),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, tt.req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("unexpected status %d", w.Code)
+			}
+			if body, _ := io.ReadAll(w.Body); string(body) != tt.wantBody {
+				t.Fatalf("unexpected body %q, want %q", body, tt.wantBody)
+			}
+
+			if tt.wantRetries > 0 {
+				req := <-mentions
+				if req.RetryAttempt != tt.wantRetries {
+					t.Fatalf("unexpected retry attempt %d", req.RetryAttempt)
+				}
+			}
+		})
+	}
+}
+
+func TestRouterHTTPHandler_ackTimeout(t *testing.T) {
+	r := NewRouter()
+
+	release := make(chan struct{})
+	done := make(chan struct{})
+	r.HandleSlashCommand("/slow", func(ctx context.Context, req *Request) {
+		<-release
+		req.Ack(ctx, "too late")
+		close(done)
+	})
+
+	h := r.HTTPHandler(testSigningSecret).(*routerHTTPHandler)
+	h.ackTimeout = 10 * time.Millisecond
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newSignedRequest(t, "application/x-www-form-urlencoded", url.Values{"command": {"/slow"}}.Encode()))
+	close(release)
+	<-done
+
+	if w.Code != http.StatusOK || w.Body.Len() != 0 {
+		t.Fatalf("expected an empty acknowledgement, got %d %q", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,46 @@
+package slackevents
+
+import (
+	"sort"
+
+	"github.com/slack-go/slack"
+)
+
+// ManifestRequirements returns what the app manifest must have for the handlers of r: the
+// slash commands, the shortcuts registered with HandleShortcut, the bot events and the scopes
+// they require, and interactivity when any interaction is handled.
+//
+// The slash commands only have their name: their description, required by Slack to create
+// the app, is set with ManifestRequirements.AddSlashCommands. The scopes of the Web API
+// methods the app calls are added with ManifestRequirements.AddMethods, and the ones of the
+// bot events ManifestRequirements.AddBotEvents does not know with AddBotScopes: the error of
+// AddBotEvents listing them is returned along with the requirements, which still have the
+// events.
+func (r *Router) ManifestRequirements() (*slack.ManifestRequirements, error) {
+	reqs := &slack.ManifestRequirements{}
+
+	commands := make([]string, 0, len(r.slashCommandMap))
+	for command := range r.slashCommandMap {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+	for _, command := range commands {
+		reqs.AddSlashCommands(slack.ManifestSlashCommand{Command: command})
+	}
+
+	reqs.AddShortcuts(r.shortcuts...)
+
+	events := make([]string, 0, len(r.eventMap))
+	for t := range r.eventMap {
+		events = append(events, string(t))
+	}
+	sort.Strings(events)
+	err := reqs.AddBotEvents(events...)
+
+	if len(r.requestMap[RequestTypeInteractive]) > 0 || len(r.interactionMap) > 0 ||
+		len(r.blockActionMap) > 0 || len(r.callbackIDMap) > 0 || len(r.matchMap[RequestTypeInteractive]) > 0 {
+		reqs.Interactivity = true
+	}
+
+	return reqs, err
+}
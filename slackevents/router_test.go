@@ -0,0 +1,245 @@
+package slackevents
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+type recordingAcknowledger struct {
+	mu       sync.Mutex
+	payloads []interface{}
+}
+
+func (a *recordingAcknowledger) Ack(ctx context.Context, payload interface{}) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.payloads = append(a.payloads, payload)
+	return nil
+}
+
+type handlerRecorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (h *handlerRecorder) handler(name string) RouterHandlerFunc {
+	return func(ctx context.Context, req *Request) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		h.calls = append(h.calls, name)
+	}
+}
+
+func (h *handlerRecorder) sorted() []string {
+	sort.Strings(h.calls)
+	return h.calls
+}
+
+func TestRouter_Dispatch(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *Request
+		want []string
+	}{
+		{
+			name: "events by inner type",
+			req: NewRequest(RequestTypeEventsAPI, EventsAPIEvent{
+				Type:       CallbackEvent,
+				InnerEvent: EventsAPIInnerEvent{Type: string(AppMention)},
+			}, nil),
+			want: []string{"app_mention", "events_api"},
+		},
+		{
+			name: "block actions",
+			req: NewRequest(RequestTypeInteractive, slack.InteractionCallback{
+				Type: slack.InteractionTypeBlockActions,
+				ActionCallback: slack.ActionCallbacks{
+					BlockActions: []*slack.BlockAction{{ActionID: "approve"}, {ActionID: "unknown"}},
+				},
+			}, nil),
+			want: []string{"approve", "block_actions"},
+		},
+		{
+			name: "block suggestion by action ID",
+			req: NewRequest(RequestTypeInteractive, slack.InteractionCallback{
+				Type:     slack.InteractionTypeBlockSuggestion,
+				ActionID: "approve",
+			}, nil),
+			want: []string{"approve"},
+		},
+		{
+			name: "view submission by callback ID",
+			req: NewRequest(RequestTypeInteractive, slack.InteractionCallback{
+				Type: slack.InteractionTypeViewSubmission,
+				View: slack.View{CallbackID: "create_ticket"},
+			}, nil),
+			want: []string{"create_ticket"},
+		},
+		{
+			name: "slash command",
+			req:  NewRequest(RequestTypeSlashCommand, slack.SlashCommand{Command: "/deploy"}, nil),
+			want: []string{"/deploy"},
+		},
+		{
+			name: "default",
+			req:  NewRequest(RequestTypeSlashCommand, slack.SlashCommand{Command: "/unknown"}, nil),
+			want: []string{"default"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &handlerRecorder{}
+
+			r := NewRouter()
+			r.Handle(RequestTypeEventsAPI, rec.handler("events_api"))
+			r.HandleEvents(AppMention, rec.handler("app_mention"))
+			r.HandleEvents(Message, rec.handler("message"))
+			r.HandleInteraction(slack.InteractionTypeBlockActions, rec.handler("block_actions"))
+			r.HandleInteractionBlockAction("approve", rec.handler("approve"))
+			r.HandleInteractionCallbackID("create_ticket", rec.handler("create_ticket"))
+			r.HandleSlashCommand("/deploy", rec.handler("/deploy"))
+			r.HandleDefault(rec.handler("default"))
+
+			ack := &recordingAcknowledger{}
+			tt.req.acknowledger = ack
+
+			if !r.Dispatch(context.Background(), tt.req) {
+				t.Fatal("expected the request to be handled")
+			}
+
+			got := rec.sorted()
+			if len(got) != len(tt.want) {
+				t.Fatalf("unexpected handlers called: want %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("unexpected handlers called: want %v, got %v", tt.want, got)
+				}
+			}
+
+			if len(ack.payloads) != 1 || ack.payloads[0] != nil {
+				t.Fatalf("expected a single empty acknowledgement, got %v", ack.payloads)
+			}
+		})
+	}
+}
+
+func TestRouter_AckPayloadAndMiddleware(t *testing.T) {
+	var order []string
+
+	r := NewRouter()
+	r.Use(func(next RouterHandlerFunc) RouterHandlerFunc {
+		return func(ctx context.Context, req *Request) {
+			order = append(order, "outer")
+			next(ctx, req)
+		}
+	}, func(next RouterHandlerFunc) RouterHandlerFunc {
+		return func(ctx context.Context, req *Request) {
+			order = append(order, "inner")
+			next(ctx, req)
+		}
+	})
+	r.HandleSlashCommand("/echo", func(ctx context.Context, req *Request) {
+		order = append(order, "handler")
+		cmd, _ := req.SlashCommand()
+		req.Ack(ctx, cmd.Text)
+		req.Ack(ctx, "ignored")
+	})
+
+	ack := &recordingAcknowledger{}
+	req := NewRequest(RequestTypeSlashCommand, slack.SlashCommand{Command: "/echo", Text: "hi"}, ack)
+	r.Dispatch(context.Background(), req)
+
+	if len(ack.payloads) != 1 || ack.payloads[0] != "hi" {
+		t.Fatalf("expected a single acknowledgement with the payload, got %v", ack.payloads)
+	}
+	if len(order) != 3 || order[0] != "outer" || order[1] != "inner" || order[2] != "handler" {
+		t.Fatalf("unexpected middleware order %v", order)
+	}
+}
+
+func TestRouter_Deduplicator(t *testing.T) {
+	rec := &handlerRecorder{}
+
+	r := NewRouter()
+	r.Deduplicator = NewDeduplicator(nil)
+	r.HandleEvents(AppMention, rec.handler("app_mention"))
+
+	newReq := func() *Request {
+		return NewRequest(RequestTypeEventsAPI, EventsAPIEvent{
+			Type:       CallbackEvent,
+			Data:       &EventsAPICallbackEvent{EventID: "Ev1"},
+			InnerEvent: EventsAPIInnerEvent{Type: string(AppMention)},
+		}, nil)
+	}
+
+	r.Dispatch(context.Background(), newReq())
+	retry := newReq()
+	if !r.Dispatch(context.Background(), retry) || !retry.Acked() {
+		t.Fatal("expected the retry to be acknowledged")
+	}
+
+	if len(rec.calls) != 1 {
+		t.Fatalf("expected the handler to be called once, got %v", rec.calls)
+	}
+}
+
+func TestRouterManifestRequirements(t *testing.T) {
+	h := &handlerRecorder{}
+	r := NewRouter()
+	r.HandleSlashCommand("/status", h.handler("status"))
+	r.HandleSlashCommand("/deploy", h.handler("deploy"))
+	r.HandleShortcut(slack.Shortcut{Name: "Deploy", CallbackID: "deploy", Description: "Deploy a service", Type: slack.GlobalShortcut}, h.handler("shortcut"))
+	r.HandleEvents(ReactionAdded, h.handler("reaction"))
+	r.HandleMessages(NewMessageRouter("U1"))
+
+	reqs, err := r.ManifestRequirements()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if len(reqs.SlashCommands) != 2 || reqs.SlashCommands[0].Command != "/deploy" || reqs.SlashCommands[1].Command != "/status" {
+		t.Errorf("Unexpected slash commands %+v", reqs.SlashCommands)
+	}
+	if len(reqs.Shortcuts) != 1 || reqs.Shortcuts[0].CallbackID != "deploy" {
+		t.Errorf("Unexpected shortcuts %+v", reqs.Shortcuts)
+	}
+	if !reflect.DeepEqual(reqs.BotEvents, []string{"app_mention", "message", "reaction_added"}) {
+		t.Errorf("Unexpected bot events %v", reqs.BotEvents)
+	}
+	if !reflect.DeepEqual(reqs.BotScopes, []string{"commands", "app_mentions:read", "reactions:read"}) {
+		t.Errorf("Unexpected bot scopes %v", reqs.BotScopes)
+	}
+	if !reqs.Interactivity || reqs.SocketMode {
+		t.Errorf("Unexpected settings %+v", reqs)
+	}
+
+	deployed := &slack.Manifest{}
+	reqs.Apply(deployed)
+	if err := reqs.Check(deployed); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+func TestRouterManifestRequirements_unknownBotEvent(t *testing.T) {
+	h := &handlerRecorder{}
+	r := NewRouter()
+	r.HandleEvents(AppMention, h.handler("mention"))
+	r.HandleEvents("custom_event", h.handler("custom"))
+
+	reqs, err := r.ManifestRequirements()
+	if err == nil || err.Error() != "unknown scopes of the bot events custom_event" {
+		t.Errorf("Expected the unknown bot event to be reported, got %v", err)
+	}
+	if !reflect.DeepEqual(reqs.BotEvents, []string{"app_mention", "custom_event"}) {
+		t.Errorf("Unexpected bot events %v", reqs.BotEvents)
+	}
+}
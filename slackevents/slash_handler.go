@@ -0,0 +1,146 @@
+package slackevents
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/slack-go/slack"
+)
+
+// SlashCommandRequest is a slash command invocation delivered over HTTP.
+type SlashCommandRequest struct {
+	Command slack.SlashCommand
+
+	req *Request
+}
+
+// Respond answers the command synchronously, payload being sent as the body of the HTTP
+// response: as plain text for a string, as JSON otherwise. Only the first response is sent,
+// and only if it happens within 2.5 seconds.
+func (r *SlashCommandRequest) Respond(payload interface{}) error {
+	return r.req.Ack(context.Background(), payload)
+}
+
+// RespondText answers the command synchronously with a message.
+// responseType is slack.ResponseTypeInChannel or slack.ResponseTypeEphemeral.
+func (r *SlashCommandRequest) RespondText(responseType, text string) error {
+	return r.Respond(&slack.Msg{ResponseType: responseType, Text: text})
+}
+
+// FollowUp sends a message to the response URL of the command, after it was answered.
+// responseType is slack.ResponseTypeInChannel or slack.ResponseTypeEphemeral.
+func (r *SlashCommandRequest) FollowUp(ctx context.Context, api *slack.Client, responseType string, options ...slack.MsgOption) error {
+	return followUp(ctx, api, r.Command.ResponseURL, responseType, options...)
+}
+
+// SlashCommandHandlerFunc handles a slash command delivered over HTTP.
+type SlashCommandHandlerFunc func(ctx context.Context, req *SlashCommandRequest)
+
+// SlashCommandsHandlerOption configures a SlashCommandsHandler.
+type SlashCommandsHandlerOption func(*SlashCommandsHandler)
+
+// SlashCommandsHandlerOptionMaxBodyBytes limits the size of the request bodies (default: 1MiB).
+func SlashCommandsHandlerOptionMaxBodyBytes(n int64) SlashCommandsHandlerOption {
+	return func(h *SlashCommandsHandler) {
+		h.maxBodyBytes = n
+	}
+}
+
+// SlashCommandsHandlerOptionErrorHandler sets a callback for the requests that are rejected,
+// because they cannot be verified or parsed.
+func SlashCommandsHandlerOptionErrorHandler(f func(r *http.Request, err error)) SlashCommandsHandlerOption {
+	return func(h *SlashCommandsHandler) {
+		h.onError = f
+	}
+}
+
+// SlashCommandsHandler is an http.Handler serving the request URL of an app's slash commands.
+//
+// It verifies the signature of every request and dispatches the commands asynchronously
+// with its Router. The HTTP response is sent as soon as the handler responds, or after
+// 2.5 seconds, whichever comes first.
+type SlashCommandsHandler struct {
+	signingSecret string
+	maxBodyBytes  int64
+	onError       func(r *http.Request, err error)
+
+	router *Router
+}
+
+// NewSlashCommandsHandler returns a SlashCommandsHandler verifying requests with the app's signing secret.
+func NewSlashCommandsHandler(signingSecret string, options ...SlashCommandsHandlerOption) *SlashCommandsHandler {
+	h := &SlashCommandsHandler{
+		signingSecret: signingSecret,
+		maxBodyBytes:  defaultMaxBodyBytes,
+		router:        NewRouter(),
+	}
+
+	for _, opt := range options {
+		opt(h)
+	}
+
+	return h
+}
+
+// Router returns the router the commands are dispatched with, e.g. to register the handlers
+// written for a Router. Request.Ack answers the command like SlashCommandRequest.Respond does.
+func (h *SlashCommandsHandler) Router() *Router {
+	return h.router
+}
+
+// Handle adds the handler of a slash command, e.g. "/deploy".
+func (h *SlashCommandsHandler) Handle(command string, f SlashCommandHandlerFunc) {
+	h.router.HandleSlashCommand(command, slashCommandRouterHandler(f))
+}
+
+// HandleDefault sets the handler of the commands no other handler matched.
+func (h *SlashCommandsHandler) HandleDefault(f SlashCommandHandlerFunc) {
+	if f == nil {
+		h.router.HandleDefault(nil)
+		return
+	}
+
+	h.router.HandleDefault(slashCommandRouterHandler(f))
+}
+
+// slashCommandRouterHandler adapts f to a Router. It returns nil when f is nil, so that the
+// Router rejects the registration.
+func slashCommandRouterHandler(f SlashCommandHandlerFunc) RouterHandlerFunc {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, req *Request) {
+		cmd, ok := req.SlashCommand()
+		if !ok {
+			return
+		}
+
+		f(ctx, &SlashCommandRequest{Command: cmd, req: req})
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *SlashCommandsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := readVerifiedBody(w, r, h.signingSecret, h.maxBodyBytes)
+	if err != nil {
+		reject(w, r, h.onError, err)
+		return
+	}
+
+	cmd, err := parseSlashCommand(body)
+	if err != nil {
+		reject(w, r, h.onError, &httpError{status: http.StatusBadRequest, err: err})
+		return
+	}
+
+	req := NewRequest(RequestTypeSlashCommand, cmd, nil)
+	serveRequest(w, req, defaultAckTimeout, func(ctx context.Context) {
+		h.router.Dispatch(ctx, req)
+	})
+}
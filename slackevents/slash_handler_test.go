@@ -0,0 +1,93 @@
+package slackevents
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestSlashCommandsHandler(t *testing.T) {
+	h := NewSlashCommandsHandler(testSigningSecret)
+	h.Handle("/echo", func(ctx context.Context, req *SlashCommandRequest) {
+		req.RespondText(slack.ResponseTypeEphemeral, req.Command.Text)
+	})
+	h.Handle("/ping", func(ctx context.Context, req *SlashCommandRequest) {
+		req.Respond("pong")
+	})
+
+	var unknown []string
+	h.HandleDefault(func(ctx context.Context, req *SlashCommandRequest) {
+		unknown = append(unknown, req.Command.Command)
+	})
+
+	tests := []struct {
+		name            string
+		form            url.Values
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "json response",
+			form:            url.Values{"command": {"/echo"}, "text": {"hello"}},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantBody:        `"response_type":"ephemeral"`,
+		},
+		{
+			name:            "text response",
+			form:            url.Values{"command": {"/ping"}},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/plain; charset=utf-8",
+			wantBody:        "pong",
+		},
+		{
+			name:       "default handler",
+			form:       url.Values{"command": {"/unknown"}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "not a command",
+			form:       url.Values{"payload": {"{}"}},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, newSignedRequest(t, "application/x-www-form-urlencoded", test.form.Encode()))
+
+			if rec.Code != test.wantStatus {
+				t.Fatalf("status: expected %d, got %d", test.wantStatus, rec.Code)
+			}
+			if got := rec.Header().Get("Content-Type"); got != test.wantContentType {
+				t.Errorf("content type: expected %q, got %q", test.wantContentType, got)
+			}
+			if !strings.Contains(rec.Body.String(), test.wantBody) {
+				t.Errorf("body: expected %q in %q", test.wantBody, rec.Body.String())
+			}
+		})
+	}
+
+	if len(unknown) != 1 || unknown[0] != "/unknown" {
+		t.Errorf("expected the default handler to run for /unknown, got %v", unknown)
+	}
+}
+
+func TestSlashCommandsHandler_HandleDuplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+
+	h := NewSlashCommandsHandler(testSigningSecret)
+	h.Handle("/echo", func(ctx context.Context, req *SlashCommandRequest) {})
+	h.Handle("/echo", func(ctx context.Context, req *SlashCommandRequest) {})
+}
@@ -0,0 +1,74 @@
+package slackevents
+
+import (
+	"context"
+
+	"github.com/slack-go/slack"
+)
+
+// StatusOverrideDetector detects the users overriding the shifts of a campaign from their
+// user_status_changed and dnd_updated_user events, see slack.StatusCampaign.StatusChanged and
+// slack.StatusCampaign.DNDChanged.
+type StatusOverrideDetector struct {
+	Campaign *slack.StatusCampaign
+	// OnOverride, when set, is called with the overrides detected.
+	OnOverride func(ctx context.Context, evt EventsAPIEvent, override slack.StatusOverride)
+	// OnError, when set, is called when the DND of a user cannot be checked.
+	OnError func(ctx context.Context, evt EventsAPIEvent, err error)
+}
+
+// NewStatusOverrideDetector returns a StatusOverrideDetector of the overrides of campaign.
+func NewStatusOverrideDetector(campaign *slack.StatusCampaign) *StatusOverrideDetector {
+	return &StatusOverrideDetector{Campaign: campaign}
+}
+
+// HandleEvent records the change of a user_status_changed or dnd_updated_user event. It returns
+// false when evt is another event, or when the user did not override their shift.
+func (d *StatusOverrideDetector) HandleEvent(ctx context.Context, evt EventsAPIEvent) (slack.StatusOverride, bool, error) {
+	var (
+		override slack.StatusOverride
+		ok       bool
+		err      error
+	)
+
+	switch data := evt.InnerEvent.Data.(type) {
+	case *UserStatusChangedEvent:
+		override, ok = d.Campaign.StatusChanged(data.User.ID, slack.UserStatus{
+			Text:       data.User.Profile.StatusText,
+			Emoji:      data.User.Profile.StatusEmoji,
+			Expiration: int64(data.User.Profile.StatusExpiration),
+		})
+	case *DndUpdatedUserEvent:
+		override, ok, err = d.Campaign.DNDChanged(ctx, data.User)
+	default:
+		return slack.StatusOverride{}, false, nil
+	}
+
+	if err != nil && d.OnError != nil {
+		d.OnError(ctx, evt, err)
+	}
+	if ok && d.OnOverride != nil {
+		d.OnOverride(ctx, evt, override)
+	}
+
+	return override, ok, err
+}
+
+// RouterHandler returns a handler detecting the overrides of the events it receives.
+func (d *StatusOverrideDetector) RouterHandler() RouterHandlerFunc {
+	return func(ctx context.Context, req *Request) {
+		evt, ok := req.EventsAPIEvent()
+		if !ok {
+			return
+		}
+
+		req.Ack(ctx)
+		d.HandleEvent(ctx, evt)
+	}
+}
+
+// HandleStatusOverrides routes the user_status_changed and dnd_updated_user events to d.
+func (r *Router) HandleStatusOverrides(d *StatusOverrideDetector) {
+	r.HandleEvents(UserStatusChanged, d.RouterHandler())
+	r.HandleEvents(DndUpdatedUser, d.RouterHandler())
+}
@@ -0,0 +1,55 @@
+package slackevents
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+func TestStatusOverrideDetector(t *testing.T) {
+	now := time.Now()
+	end := now.Add(time.Hour)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users.profile.get", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"ok":true,"profile":{"status_text":"On call","status_emoji":":pager:","status_expiration":%d}}`, end.Unix())
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := slack.New("xoxp-1", slack.OptionAPIURL(ts.URL+"/"))
+	campaign := slack.NewStatusCampaign(api, []slack.StatusShift{
+		{UserID: "U1", Start: now.Add(-time.Minute), End: end, StatusText: "On call", StatusEmoji: ":pager:"},
+	})
+	if _, err := campaign.Reconcile(context.Background(), now); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	d := NewStatusOverrideDetector(campaign)
+	var overrides []slack.StatusOverride
+	d.OnOverride = func(ctx context.Context, evt EventsAPIEvent, override slack.StatusOverride) {
+		overrides = append(overrides, override)
+	}
+
+	r := NewRouter()
+	r.HandleStatusOverrides(d)
+
+	evt := parseTestEvent(t, `{"type":"event_callback","team_id":"T1","event":{"type":"user_status_changed","user":{"id":"U1","profile":{"status_text":"On call","status_emoji":":pager:"}}}}`)
+	if !r.Dispatch(context.Background(), NewRequest(RequestTypeEventsAPI, evt, nil)) {
+		t.Fatal("expected user_status_changed to be handled")
+	}
+	if len(overrides) != 0 {
+		t.Errorf("expected no override, got %+v", overrides)
+	}
+
+	evt = parseTestEvent(t, `{"type":"event_callback","team_id":"T1","event":{"type":"user_status_changed","user":{"id":"U1","profile":{"status_text":"Vacationing","status_emoji":":palm_tree:"}}}}`)
+	r.Dispatch(context.Background(), NewRequest(RequestTypeEventsAPI, evt, nil))
+	if len(overrides) != 1 || overrides[0].Shift.UserID != "U1" || overrides[0].Status.Text != "Vacationing" {
+		t.Errorf("expected an override of U1, got %+v", overrides)
+	}
+}
@@ -2,6 +2,7 @@ package socketmode
 
 import (
 	"encoding/json"
+	"sync"
 	"time"
 
 	"github.com/slack-go/slack"
@@ -12,6 +13,29 @@ import (
 type ConnectedEvent struct {
 	ConnectionCount int // 1 = first time, 2 = second time
 	Info            *slack.SocketModeConnection
+
+	// ConnectionID identifies the WebSocket connection that was just opened among all
+	// the connections opened by the Client.
+	ConnectionID int
+	// Connections is the health of every connection open at the time, including this one.
+	Connections []ConnectionHealth
+}
+
+// ConnectionHealth describes the state of one of the Socket Mode WebSocket connections
+// maintained by the Client.
+type ConnectionHealth struct {
+	ID int
+	// Slot is the index of the connection among the Client's configured connections.
+	// A connection and the replacement opened for it share the same slot.
+	Slot        int
+	ConnectedAt time.Time
+	// LastPingAt is the time of the last WebSocket PING received from Slack.
+	LastPingAt time.Time
+	// Host is the Slack host serving the connection, as reported in the `hello` message.
+	Host string
+	// Rotating is true once Slack warned that it is about to recycle the connection
+	// and a replacement has been opened.
+	Rotating bool
 }
 
 type DebugInfo struct {
@@ -50,10 +74,23 @@ type Client struct {
 	// until Client considers the WebSocket connection is dead and needs to be reopened.
 	maxPingInterval time.Duration
 
+	// numConnections is the number of WebSocket connections kept open concurrently.
+	numConnections int
+
+	// rotationGracePeriod is how long a connection Slack warned about is kept open
+	// alongside its replacement, unless Slack closes it first.
+	rotationGracePeriod time.Duration
+
 	// Connection life-cycle
 	Events              chan Event
 	socketModeResponses chan *Response
 
+	// connMu guards connections, envelopes and lastConnectionID
+	connMu           sync.Mutex
+	connections      map[int]*connection
+	envelopes        map[string]*connection
+	lastConnectionID int
+
 	// dialer is a gorilla/websocket Dialer. If nil, use the default
 	// Dialer.
 	dialer *websocket.Dialer
@@ -0,0 +1,140 @@
+package socketmode
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// connection is the bookkeeping of one open Socket Mode WebSocket connection.
+type connection struct {
+	// responses receives the responses to requests delivered over this connection,
+	// so that they are acknowledged over the very same WebSocket.
+	responses chan *Response
+	// mu guards closed, which is set once the responses are no longer read.
+	mu     sync.Mutex
+	closed bool
+
+	health ConnectionHealth
+}
+
+// registerConnection records a newly opened connection and returns it.
+func (smc *Client) registerConnection(slot int) *connection {
+	smc.connMu.Lock()
+	defer smc.connMu.Unlock()
+
+	smc.lastConnectionID++
+	conn := &connection{
+		responses: make(chan *Response, 20),
+		health: ConnectionHealth{
+			ID:          smc.lastConnectionID,
+			Slot:        slot,
+			ConnectedAt: time.Now(),
+		},
+	}
+	smc.connections[conn.health.ID] = conn
+
+	return conn
+}
+
+// unregisterConnection forgets a connection along with the envelopes that were
+// still awaiting a response over it. The responses queued for the connection but not
+// sent, e.g. when it was rotated, are moved to the queue shared by all the connections,
+// waiting for room in it until ctx is done.
+func (smc *Client) unregisterConnection(ctx context.Context, conn *connection) {
+	smc.connMu.Lock()
+	delete(smc.connections, conn.health.ID)
+	for envelopeID, c := range smc.envelopes {
+		if c == conn {
+			delete(smc.envelopes, envelopeID)
+		}
+	}
+	smc.connMu.Unlock()
+
+	conn.mu.Lock()
+	conn.closed = true
+	conn.mu.Unlock()
+
+	for {
+		select {
+		case res := <-conn.responses:
+			select {
+			case smc.socketModeResponses <- res:
+			case <-ctx.Done():
+				smc.Debugf("Dropped the Socket Mode response with envelope ID %q: %v", res.EnvelopeID, ctx.Err())
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// enqueueResponse queues res to be sent over conn, and reports whether it did. It does not
+// when conn is gone, or when its queue is full.
+func (conn *connection) enqueueResponse(res *Response) bool {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if conn.closed {
+		return false
+	}
+
+	select {
+	case conn.responses <- res:
+		return true
+	default:
+		return false
+	}
+}
+
+// updateConnection applies f to the health of conn.
+func (smc *Client) updateConnection(conn *connection, f func(*ConnectionHealth)) {
+	smc.connMu.Lock()
+	defer smc.connMu.Unlock()
+
+	f(&conn.health)
+}
+
+// trackEnvelope remembers that the request with the given envelope ID arrived over conn.
+func (smc *Client) trackEnvelope(envelopeID string, conn *connection) {
+	if envelopeID == "" {
+		return
+	}
+
+	smc.connMu.Lock()
+	defer smc.connMu.Unlock()
+
+	smc.envelopes[envelopeID] = conn
+}
+
+// envelopeConnection returns, and stops tracking, the connection a request arrived over.
+// It returns nil when the connection is unknown or already gone.
+func (smc *Client) envelopeConnection(envelopeID string) *connection {
+	smc.connMu.Lock()
+	defer smc.connMu.Unlock()
+
+	conn, ok := smc.envelopes[envelopeID]
+	if !ok {
+		return nil
+	}
+	delete(smc.envelopes, envelopeID)
+
+	return conn
+}
+
+// Connections returns the health of every Socket Mode connection currently open,
+// ordered by connection ID.
+func (smc *Client) Connections() []ConnectionHealth {
+	smc.connMu.Lock()
+	defer smc.connMu.Unlock()
+
+	health := make([]ConnectionHealth, 0, len(smc.connections))
+	for _, conn := range smc.connections {
+		health = append(health, conn.health)
+	}
+	sort.Slice(health, func(i, j int) bool { return health[i].ID < health[j].ID })
+
+	return health
+}
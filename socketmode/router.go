@@ -0,0 +1,69 @@
+package socketmode
+
+import (
+	"context"
+
+	"github.com/slack-go/slack/slackevents"
+)
+
+// NewRouterRequest converts an event received over Socket Mode into a transport-neutral
+// slackevents.Request acknowledged over the client's connection. It returns false for the
+// events that do not wrap a request from Slack, such as connection life-cycle events.
+func NewRouterRequest(smc *Client, evt Event) (*slackevents.Request, bool) {
+	var typ slackevents.RequestType
+
+	switch evt.Type {
+	case EventTypeEventsAPI:
+		typ = slackevents.RequestTypeEventsAPI
+	case EventTypeInteractive:
+		typ = slackevents.RequestTypeInteractive
+	case EventTypeSlashCommand:
+		typ = slackevents.RequestTypeSlashCommand
+	default:
+		return nil, false
+	}
+
+	if evt.Request == nil {
+		return nil, false
+	}
+
+	envelopeID := evt.Request.EnvelopeID
+	req := slackevents.NewRequest(typ, evt.Data, slackevents.AcknowledgerFunc(func(ctx context.Context, payload interface{}) error {
+		return smc.AckCtx(ctx, envelopeID, payload)
+	}))
+	req.EnvelopeID = envelopeID
+	req.RetryAttempt = evt.Request.RetryAttempt
+	req.RetryReason = evt.Request.RetryReason
+
+	return req, true
+}
+
+// RunRouterContext runs the client like RunContext does, dispatching every request received
+// from Slack to router. The other events are only logged when debugging is enabled.
+func (smc *Client) RunRouterContext(ctx context.Context, router *slackevents.Router) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		for {
+			select {
+			case evt, ok := <-smc.Events:
+				if !ok {
+					return
+				}
+
+				req, ok := NewRouterRequest(smc, evt)
+				if !ok {
+					smc.Debugf("Ignored event of type %q", evt.Type)
+					continue
+				}
+
+				go router.Dispatch(ctx, req)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return smc.RunContext(ctx)
+}
@@ -0,0 +1,45 @@
+package socketmode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+func TestNewRouterRequest(t *testing.T) {
+	smc := New(slack.New("ABCDEFG"))
+
+	if _, ok := NewRouterRequest(smc, Event{Type: EventTypeConnected}); ok {
+		t.Fatal("life-cycle events should not be converted")
+	}
+
+	evt := Event{
+		Type:    EventTypeSlashCommand,
+		Data:    slack.SlashCommand{Command: "/echo", Text: "hi"},
+		Request: &Request{EnvelopeID: "envelope-1", RetryAttempt: 1, RetryReason: "timeout"},
+	}
+	req, ok := NewRouterRequest(smc, evt)
+	if !ok {
+		t.Fatal("expected the slash command to be converted")
+	}
+	if req.Type != slackevents.RequestTypeSlashCommand || req.EnvelopeID != "envelope-1" || req.RetryAttempt != 1 || req.RetryReason != "timeout" {
+		t.Fatalf("unexpected request %+v", req)
+	}
+
+	router := slackevents.NewRouter()
+	router.HandleSlashCommand("/echo", func(ctx context.Context, req *slackevents.Request) {
+		cmd, _ := req.SlashCommand()
+		req.Ack(ctx, map[string]string{"text": cmd.Text})
+	})
+	router.Dispatch(context.Background(), req)
+
+	res := <-smc.socketModeResponses
+	if res.EnvelopeID != "envelope-1" {
+		t.Fatalf("unexpected envelope ID %q", res.EnvelopeID)
+	}
+	if payload, _ := res.Payload.(map[string]string); payload["text"] != "hi" {
+		t.Fatalf("unexpected payload %v", res.Payload)
+	}
+}
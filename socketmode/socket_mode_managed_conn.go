@@ -41,20 +41,74 @@ func (smc *Client) Run() error {
 // `socketmode.Event`s that includes the client-specific events that may or may not wrap Socket Mode requests.
 //
 // Note that this function automatically reconnect on requested by Slack through a `disconnect` message.
+// When Slack warns that it is about to recycle a connection, a replacement is opened right away
+// so that no request is lost while the old connection is being closed.
 // This function exists with an error only when a reconnection is failued due to some reason.
 // If you want to retry even on reconnection failure, you'd need to write your own wrapper for this function
 // to do so.
 func (smc *Client) RunContext(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	numConnections := smc.numConnections
+	if numConnections < 1 {
+		numConnections = 1
+	}
+
+	wg := new(sync.WaitGroup)
+	errc := make(chan error, numConnections)
+	for slot := 0; slot < numConnections; slot++ {
+		wg.Add(1)
+		go func(slot int) {
+			defer wg.Done()
+
+			errc <- smc.runSlot(ctx, wg, slot)
+		}(slot)
+	}
+
+	// Slots only return on unrecoverable errors, in which case we tear all the
+	// other connections down as well.
+	err := <-errc
+	cancel()
+	wg.Wait()
+
+	return err
+}
+
+// runSlot keeps one of the Client's connections open, reconnecting whenever it is lost.
+// It returns only when a connection could not be established.
+func (smc *Client) runSlot(ctx context.Context, wg *sync.WaitGroup, slot int) error {
 	for connectionCount := 0; ; connectionCount++ {
-		if err := smc.run(ctx, connectionCount); err != nil {
-			return err
+		rotate := make(chan struct{})
+		done := make(chan error, 1)
+
+		wg.Add(1)
+		go func(connectionCount int) {
+			defer wg.Done()
+
+			done <- smc.run(ctx, slot, connectionCount, rotate)
+		}(connectionCount)
+
+		select {
+		case err := <-done:
+			if err != nil {
+				return err
+			}
+		case <-rotate:
+			// Slack warned that it is about to recycle the connection. We open the
+			// replacement right away and let the old connection wind down on its own,
+			// its outcome being of no interest anymore.
+			smc.Debugf("Opening a replacement for connection slot %d", slot)
 		}
 
 		// Continue and run the loop again to reconnect
 	}
 }
 
-func (smc *Client) run(ctx context.Context, connectionCount int) error {
+// run opens a connection and serves it until it dies. rotate is closed when Slack warns
+// that the connection is about to be recycled, at which point the connection keeps being
+// served until Slack closes it or Client.rotationGracePeriod elapses.
+func (smc *Client) run(ctx context.Context, slot, connectionCount int, rotate chan struct{}) error {
 	messages := make(chan json.RawMessage, 1)
 
 	pingChan := make(chan time.Time, 1)
@@ -81,15 +135,37 @@ func (smc *Client) run(ctx context.Context, connectionCount int) error {
 		return err
 	}
 
+	c := smc.registerConnection(slot)
+	// The pending responses outlive the connection, but not the client.
+	defer smc.unregisterConnection(ctx, c)
+
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	smc.sendEvent(ctx, newEvent(EventTypeConnected, &ConnectedEvent{
 		ConnectionCount: connectionCount,
 		Info:            info,
+		ConnectionID:    c.health.ID,
+		Connections:     smc.Connections(),
 	}))
 
-	smc.Debugf("WebSocket connection succeeded on try %d", connectionCount)
+	smc.Debugf("WebSocket connection %d succeeded on try %d", c.health.ID, connectionCount)
+
+	var rotateOnce sync.Once
+	onWarning := func() {
+		rotateOnce.Do(func() {
+			smc.Debugf("Slack warned that connection %d is about to be recycled", c.health.ID)
+			smc.updateConnection(c, func(h *ConnectionHealth) { h.Rotating = true })
+
+			close(rotate)
+
+			grace := time.AfterFunc(smc.rotationGracePeriod, cancel)
+			go func() {
+				<-ctx.Done()
+				grace.Stop()
+			}()
+		})
+	}
 
 	// We're now connected so we can set up listeners
 
@@ -109,7 +185,7 @@ func (smc *Client) run(ctx context.Context, connectionCount int) error {
 		defer cancel()
 
 		// The response sender sends Socket Mode responses over the WebSocket conn
-		if err := smc.runResponseSender(ctx, conn); err != nil {
+		if err := smc.runResponseSender(ctx, conn, c); err != nil {
 			sendErr(err)
 		}
 	}()
@@ -120,7 +196,7 @@ func (smc *Client) run(ctx context.Context, connectionCount int) error {
 		defer cancel()
 
 		// The handler reads Socket Mode requests, and enqueues responses for sending by the response sender
-		if err := smc.runRequestHandler(ctx, messages); err != nil {
+		if err := smc.runRequestHandler(ctx, messages, c, onWarning); err != nil {
 			sendErr(err)
 		}
 	}()
@@ -164,6 +240,7 @@ func (smc *Client) run(ctx context.Context, connectionCount int) error {
 				// If this case never fires then the pingHandler was never called
 				// in which case lastPing is the zero time.Time value, and will 'fail'
 				// the next tick, causing us to exit.
+				smc.updateConnection(c, func(h *ConnectionHealth) { h.LastPingAt = lastPing })
 
 			case now := <-ticker.C:
 				// Our last ping is older than our interval
@@ -337,27 +414,32 @@ func (smc *Client) openAndDial(ctx context.Context, additionalPingHandler func(s
 	return info, conn, err
 }
 
-// runResponseSender runs the handler that reads Socket Mode responses enqueued onto Client.socketModeResponses channel
-// and sends them one by one over the WebSocket connection.
+// runResponseSender runs the handler that reads Socket Mode responses enqueued onto Client.socketModeResponses channel,
+// or onto the channel of the connection the responded request arrived over, and sends them one by one over the
+// WebSocket connection.
 // Gorilla WebSocket is not goroutine safe hence this needs to be the single place you write to the WebSocket connection.
-func (smc *Client) runResponseSender(ctx context.Context, conn *websocket.Conn) error {
+func (smc *Client) runResponseSender(ctx context.Context, conn *websocket.Conn, c *connection) error {
 	for {
+		var res *Response
+
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		// 3. listen for messages that need to be sent
-		case res := <-smc.socketModeResponses:
-			smc.Debugf("Sending Socket Mode response with envelope ID %q: %v", res.EnvelopeID, res)
+		case res = <-c.responses:
+		case res = <-smc.socketModeResponses:
+		}
 
-			if err := unsafeWriteSocketModeResponse(conn, res); err != nil {
-				smc.sendEvent(ctx, newEvent(EventTypeErrorWriteFailed, &ErrorWriteFailed{
-					Cause:    err,
-					Response: res,
-				}))
-			}
+		smc.Debugf("Sending Socket Mode response with envelope ID %q: %v", res.EnvelopeID, res)
 
-			smc.Debugf("Finished sending Socket Mode response with envelope ID %q", res.EnvelopeID)
+		if err := unsafeWriteSocketModeResponse(conn, res); err != nil {
+			smc.sendEvent(ctx, newEvent(EventTypeErrorWriteFailed, &ErrorWriteFailed{
+				Cause:    err,
+				Response: res,
+			}))
 		}
+
+		smc.Debugf("Finished sending Socket Mode response with envelope ID %q", res.EnvelopeID)
 	}
 }
 
@@ -365,7 +447,9 @@ func (smc *Client) runResponseSender(ctx context.Context, conn *websocket.Conn)
 //
 // It reads WebSocket messages sent from Slack's Socket Mode WebSocket connection,
 // parses them as Socket Mode requests, and processes them and optionally emit our own events into Client.Events channel.
-func (smc *Client) runRequestHandler(ctx context.Context, websocket chan json.RawMessage) error {
+//
+// onWarning is called when Slack warns that the connection is about to be recycled.
+func (smc *Client) runRequestHandler(ctx context.Context, websocket chan json.RawMessage, c *connection, onWarning func()) error {
 	for {
 		select {
 		case <-ctx.Done():
@@ -388,12 +472,23 @@ func (smc *Client) runRequestHandler(ctx context.Context, websocket chan json.Ra
 					Message: message,
 				}))
 			} else if evt != nil {
-				if evt.Type == EventTypeDisconnect {
+				switch evt.Type {
+				case EventTypeDisconnect:
+					if evt.Request.Reason == disconnectReasonWarning {
+						// Slack is about to recycle the connection: keep serving it until it
+						// is closed while a replacement gets opened.
+						onWarning()
+						continue
+					}
+
 					// We treat the `disconnect` request from Slack as an error internally,
 					// so that we can tell the consumer of this function to reopen the connection on it.
 					return errorRequestedDisconnect{}
+				case EventTypeHello:
+					smc.updateConnection(c, func(h *ConnectionHealth) { h.Host = evt.Request.DebugInfo.Host })
 				}
 
+				smc.trackEnvelope(evt.Request.EnvelopeID, c)
 				smc.sendEvent(ctx, *evt)
 			}
 		}
@@ -489,6 +584,12 @@ func (smc *Client) SendCtx(ctx context.Context, res Response) error {
 		}
 	}
 
+	// Respond over the connection the request arrived over, if it is still open. Otherwise
+	// any other connection sends the response.
+	if c := smc.envelopeConnection(res.EnvelopeID); c != nil && c.enqueueResponse(&res) {
+		return nil
+	}
+
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -5,10 +5,18 @@ package socketmode
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
+
 	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/slacktest"
 
@@ -44,3 +52,247 @@ func Test_passContext(t *testing.T) {
 		assert.EqualError(t, errors.Unwrap(err), context.DeadlineExceeded.Error())
 	})
 }
+
+// rotatingServer is a minimal Socket Mode server. The first connection it accepts is sent a
+// `disconnect` warning, and every later connection an events_api request.
+type rotatingServer struct {
+	*httptest.Server
+
+	// requestBeforeWarning sends the request over the first connection instead, before the
+	// warning, and closes the first connection right after the warning.
+	requestBeforeWarning bool
+
+	mu    sync.Mutex
+	conns int
+	// acks receives the envelope IDs acknowledged over each connection, by connection number.
+	acks chan [2]string
+}
+
+func newRotatingServer(t *testing.T) *rotatingServer {
+	srv := &rotatingServer{acks: make(chan [2]string, 10)}
+	request := func(n int) map[string]interface{} {
+		return map[string]interface{}{
+			"type":        "events_api",
+			"envelope_id": "envelope-" + strconv.Itoa(n),
+			"payload": map[string]interface{}{
+				"type":     "event_callback",
+				"event_id": "Ev1",
+				"event":    map[string]string{"type": "app_mention"},
+			},
+		}
+	}
+	upgrader := websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/apps.connections.open", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":  true,
+			"url": "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws",
+		})
+	})
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrading connection: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		srv.mu.Lock()
+		srv.conns++
+		n := srv.conns
+		srv.mu.Unlock()
+
+		conn.WriteJSON(map[string]interface{}{"type": "hello", "num_connections": n, "debug_info": map[string]string{"host": "applink-" + strconv.Itoa(n)}})
+		switch {
+		case n == 1 && srv.requestBeforeWarning:
+			conn.WriteJSON(request(n))
+			conn.WriteJSON(map[string]interface{}{"type": "disconnect", "reason": "warning"})
+			return
+		case n == 1:
+			conn.WriteJSON(map[string]interface{}{"type": "disconnect", "reason": "warning"})
+		case !srv.requestBeforeWarning:
+			conn.WriteJSON(request(n))
+		}
+
+		for {
+			var res Response
+			if err := conn.ReadJSON(&res); err != nil {
+				return
+			}
+			srv.acks <- [2]string{strconv.Itoa(n), res.EnvelopeID}
+		}
+	})
+
+	srv.Server = httptest.NewServer(mux)
+
+	return srv
+}
+
+func TestRunContext_rotatesOnWarning(t *testing.T) {
+	srv := newRotatingServer(t)
+	defer srv.Close()
+
+	api := slack.New("ABCDEFG", slack.OptionAPIURL(srv.URL+"/"), slack.OptionAppLevelToken("xapp-1"))
+	cli := New(api, OptionPingInterval(time.Minute))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	runErr := make(chan error, 1)
+	go func() { runErr <- cli.RunContext(ctx) }()
+
+	var connected []*ConnectedEvent
+	for len(connected) < 2 {
+		select {
+		case evt := <-cli.Events:
+			switch evt.Type {
+			case EventTypeConnected:
+				connected = append(connected, evt.Data.(*ConnectedEvent))
+			case EventTypeEventsAPI:
+				cli.Ack(*evt.Request)
+			}
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for the replacement connection, got %d connections", len(connected))
+		}
+	}
+
+	// The replacement must be opened while the warned connection is still up.
+	assert.Equal(t, 2, len(connected[1].Connections))
+	assert.True(t, connected[1].Connections[0].Rotating)
+	assert.NotEqual(t, connected[0].ConnectionID, connected[1].ConnectionID)
+
+	for {
+		select {
+		case evt := <-cli.Events:
+			if evt.Type == EventTypeEventsAPI {
+				cli.Ack(*evt.Request)
+			}
+			continue
+		case ack := <-srv.acks:
+			// The request was acknowledged over the connection it arrived over.
+			assert.Equal(t, [2]string{"2", "envelope-2"}, ack)
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for the acknowledgement")
+		}
+		break
+	}
+
+	cancel()
+	assert.True(t, errors.Is(<-runErr, context.Canceled))
+	assert.Empty(t, cli.Connections())
+}
+
+func TestRunContext_acksMidRotation(t *testing.T) {
+	srv := newRotatingServer(t)
+	srv.requestBeforeWarning = true
+	defer srv.Close()
+
+	api := slack.New("ABCDEFG", slack.OptionAPIURL(srv.URL+"/"), slack.OptionAppLevelToken("xapp-1"))
+	cli := New(api, OptionPingInterval(time.Minute))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	runErr := make(chan error, 1)
+	go func() { runErr <- cli.RunContext(ctx) }()
+
+	// The request arrives over the warned connection, and is only acknowledged once the
+	// replacement is up and the warned connection is gone.
+	var req *Request
+	var replaced bool
+	for req == nil || !replaced {
+		select {
+		case evt := <-cli.Events:
+			switch evt.Type {
+			case EventTypeEventsAPI:
+				req = evt.Request
+			case EventTypeConnected:
+				replaced = evt.Data.(*ConnectedEvent).ConnectionID > 1
+			}
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for the request and the replacement connection")
+		}
+	}
+	for len(cli.Connections()) != 1 {
+		select {
+		case <-cli.Events:
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	cli.Ack(*req)
+
+	for {
+		select {
+		case <-cli.Events:
+			continue
+		case ack := <-srv.acks:
+			assert.Equal(t, [2]string{"2", "envelope-1"}, ack)
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for the acknowledgement")
+		}
+		break
+	}
+
+	cancel()
+	assert.True(t, errors.Is(<-runErr, context.Canceled))
+}
+
+func TestUnregisterConnection_movesPendingResponses(t *testing.T) {
+	cli := New(slack.New("ABCDEFG"))
+	conn := cli.registerConnection(0)
+	cli.trackEnvelope("envelope-1", conn)
+
+	// The response is queued for the connection, whose sender already stopped.
+	assert.NoError(t, cli.SendCtx(context.Background(), Response{EnvelopeID: "envelope-1"}))
+	cli.unregisterConnection(context.Background(), conn)
+
+	select {
+	case res := <-cli.socketModeResponses:
+		assert.Equal(t, "envelope-1", res.EnvelopeID)
+	default:
+		t.Fatal("expected the pending response to be moved to the shared queue")
+	}
+
+	// The responses to the requests of a connection that is gone are sent by any other.
+	cli.trackEnvelope("envelope-2", conn)
+	assert.NoError(t, cli.SendCtx(context.Background(), Response{EnvelopeID: "envelope-2"}))
+	assert.Equal(t, "envelope-2", (<-cli.socketModeResponses).EnvelopeID)
+}
+
+func TestUnregisterConnection_waitsForTheSharedQueue(t *testing.T) {
+	cli := New(slack.New("ABCDEFG"))
+	cli.socketModeResponses = make(chan *Response)
+	conn := cli.registerConnection(0)
+	cli.trackEnvelope("envelope-1", conn)
+	assert.NoError(t, cli.SendCtx(context.Background(), Response{EnvelopeID: "envelope-1"}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cli.unregisterConnection(context.Background(), conn)
+	}()
+
+	// The shared queue is full until another connection reads it.
+	assert.Equal(t, "envelope-1", (<-cli.socketModeResponses).EnvelopeID)
+	<-done
+
+	conn = cli.registerConnection(0)
+	cli.trackEnvelope("envelope-2", conn)
+	assert.NoError(t, cli.SendCtx(context.Background(), Response{EnvelopeID: "envelope-2"}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cli.unregisterConnection(ctx, conn)
+}
+
+func TestOptionConnections(t *testing.T) {
+	api := slack.New("ABCDEFG")
+
+	assert.Equal(t, 1, New(api).numConnections)
+	assert.Equal(t, 4, New(api, OptionConnections(4)).numConnections)
+	assert.Equal(t, 1, New(api, OptionConnections(0)).numConnections)
+	assert.Equal(t, maxConnections, New(api, OptionConnections(42)).numConnections)
+}
@@ -47,8 +47,16 @@ const (
 	EventTypeInteractive  = EventType("interactive")
 	EventTypeSlashCommand = EventType("slash_commands")
 
-	websocketDefaultTimeout = 10 * time.Second
-	defaultMaxPingInterval  = 30 * time.Second
+	// disconnectReasonWarning is the reason of the `disconnect` request Slack sends shortly
+	// before it recycles a connection.
+	disconnectReasonWarning = "warning"
+
+	websocketDefaultTimeout    = 10 * time.Second
+	defaultMaxPingInterval     = 30 * time.Second
+	defaultRotationGracePeriod = time.Minute
+
+	// maxConnections is the maximum number of concurrent Socket Mode connections Slack allows per app.
+	maxConnections = 10
 )
 
 // Open calls the "apps.connections.open" endpoint and returns the provided URL and the full Info block.
@@ -88,6 +96,21 @@ func OptionPingInterval(d time.Duration) Option {
 	}
 }
 
+// OptionConnections sets the number of WebSocket connections the client keeps open concurrently.
+// Slack delivers each request over one of them, and all of them feed the Client.Events channel.
+// The value is clamped between 1 (the default) and 10, the maximum Slack allows per app.
+func OptionConnections(n int) Option {
+	return func(smc *Client) {
+		switch {
+		case n < 1:
+			n = 1
+		case n > maxConnections:
+			n = maxConnections
+		}
+		smc.numConnections = n
+	}
+}
+
 // OptionDebug enable debugging for the client
 func OptionDebug(b bool) func(*Client) {
 	return func(c *Client) {
@@ -110,6 +133,10 @@ func New(api *slack.Client, options ...Option) *Client {
 		Events:              make(chan Event, 50),
 		socketModeResponses: make(chan *Response, 20),
 		maxPingInterval:     defaultMaxPingInterval,
+		numConnections:      1,
+		rotationGracePeriod: defaultRotationGracePeriod,
+		connections:         make(map[int]*connection),
+		envelopes:           make(map[string]*connection),
 		log:                 log.New(os.Stderr, "slack-go/slack/socketmode", log.LstdFlags|log.Lshortfile),
 	}
 
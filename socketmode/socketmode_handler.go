@@ -2,11 +2,14 @@ package socketmode
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackevents"
 )
 
+// SocketmodeHandler routes the events received over Socket Mode to handlers, with the routing
+// rules of slackevents.Router. The handlers must be registered before the event loop starts.
 type SocketmodeHandler struct {
 	Client *Client
 
@@ -20,6 +23,12 @@ type SocketmodeHandler struct {
 	SlashCommandMap                map[string]SocketmodeHandlerFunc
 
 	Default SocketmodeHandlerFunc
+
+	// Deduplicator, when set, is consulted before dispatching so that retried Events API
+	// deliveries (keyed by event ID) and retried envelopes (keyed by envelope ID) reach the
+	// handlers only once, see slackevents.Router. Suppressed requests are acknowledged on the
+	// handlers' behalf.
+	Deduplicator *slackevents.Deduplicator
 }
 
 // Handler have access to the event and socketmode client
@@ -85,6 +94,29 @@ func (r *SocketmodeHandler) HandleEvents(et slackevents.EventsAPIType, f Socketm
 	r.EventApiMap[et] = append(r.EventApiMap[et], f)
 }
 
+// HandleMessages routes the message and app_mention events with a MessageRouter.
+// The events are acknowledged before being dispatched to the router.
+func (r *SocketmodeHandler) HandleMessages(router *slackevents.MessageRouter) {
+	if router == nil {
+		panic("invalid router cannot be nil")
+	}
+
+	f := func(evt *Event, c *Client) {
+		eventsAPIEvent, ok := evt.Data.(slackevents.EventsAPIEvent)
+		if !ok {
+			return
+		}
+		if evt.Request != nil {
+			c.Ack(*evt.Request)
+		}
+
+		router.Dispatch(context.Background(), eventsAPIEvent)
+	}
+
+	r.HandleEvents(slackevents.Message, f)
+	r.HandleEvents(slackevents.AppMention, f)
+}
+
 // HandleSlashCommand adds a middleware or handler for a Slash Command
 func (r *SocketmodeHandler) HandleSlashCommand(command string, f SocketmodeHandlerFunc) {
 	if command == "" {
@@ -121,6 +153,8 @@ func (r *SocketmodeHandler) RunEventLoopContext(ctx context.Context) error {
 
 // Call the dispatcher for each incoming event
 func (r *SocketmodeHandler) runEventLoop(ctx context.Context) {
+	router := r.newRouter()
+
 	for {
 		select {
 		case evt, ok := <-r.Client.Events:
@@ -128,7 +162,7 @@ func (r *SocketmodeHandler) runEventLoop(ctx context.Context) {
 				return
 			}
 
-			r.dispatcher(evt)
+			r.dispatch(ctx, router, evt)
 
 		case <-ctx.Done():
 			return
@@ -136,127 +170,99 @@ func (r *SocketmodeHandler) runEventLoop(ctx context.Context) {
 	}
 }
 
-// Dispatch events to the specialized dispatcher
-func (r *SocketmodeHandler) dispatcher(evt Event) {
-	var isHandled bool
-
-	// Some eventType can be further decomposed
-	switch evt.Type {
-	case EventTypeInteractive:
-		isHandled = r.interactionDispatcher(&evt)
-	case EventTypeEventsAPI:
-		isHandled = r.eventAPIDispatcher(&evt)
-	case EventTypeSlashCommand:
-		isHandled = r.slashCommandDispatcher(&evt)
-	default:
-		isHandled = r.socketmodeDispatcher(&evt)
-	}
+// eventContextKey is the key of the dispatchedEvent in the context of the handlers of the
+// router built by newRouter.
+type eventContextKey struct{}
 
-	if !isHandled {
-		go r.Default(&evt, r.Client)
-	}
+// dispatchedEvent is an Event being dispatched by the router built by newRouter.
+type dispatchedEvent struct {
+	evt *Event
+	// handled is set once the event reaches a handler of r, which acknowledges it itself.
+	handled int32
 }
 
-// Dispatch socketmode events to the registered middleware
-func (r *SocketmodeHandler) socketmodeDispatcher(evt *Event) bool {
-	if handlers, ok := r.EventMap[evt.Type]; ok {
-		// If we registered an event
-		for _, f := range handlers {
-			go f(evt, r.Client)
-		}
-
-		return true
-	}
-
-	return false
+// handle marks the event as handled, and returns it.
+func (d *dispatchedEvent) handle() *Event {
+	atomic.StoreInt32(&d.handled, 1)
+	return d.evt
 }
 
-// Dispatch interactions to the registered middleware
-func (r *SocketmodeHandler) interactionDispatcher(evt *Event) bool {
-	var isHandled bool
+// newRouter returns a slackevents.Router routing the requests to the handlers registered in
+// the maps of r, so that the socketmode and the HTTP handlers share the same routing rules.
+func (r *SocketmodeHandler) newRouter() *slackevents.Router {
+	router := slackevents.NewRouter()
+	router.Deduplicator = r.Deduplicator
 
-	interaction, ok := evt.Data.(slack.InteractionCallback)
-	if !ok {
-		r.Client.log.Printf("Ignored %+v\n", evt)
-		return false
+	for et, handlers := range r.EventMap {
+		for _, f := range handlers {
+			router.Handle(slackevents.RequestType(et), r.routerHandler(f))
+		}
 	}
-
-	// Level 1 - socketmode EventType
-	isHandled = r.socketmodeDispatcher(evt)
-
-	// Level 2 - interaction EventType
-	if handlers, ok := r.InteractionEventMap[interaction.Type]; ok {
-		// If we registered an event
+	for it, handlers := range r.InteractionEventMap {
 		for _, f := range handlers {
-			go f(evt, r.Client)
+			router.HandleInteraction(it, r.routerHandler(f))
 		}
-
-		isHandled = true
 	}
-
-	// Level 3 - interaction with actionID
-	blockActions := interaction.ActionCallback.BlockActions
-	// outmoded approach won`t be implemented
-	// attachments_actions := interaction.ActionCallback.AttachmentActions
-
-	for _, action := range blockActions {
-		if handler, ok := r.InteractionBlockActionEventMap[action.ActionID]; ok {
-
-			go handler(evt, r.Client)
-
-			isHandled = true
+	for et, handlers := range r.EventApiMap {
+		for _, f := range handlers {
+			router.HandleEvents(et, r.routerHandler(f))
 		}
 	}
-	return isHandled
-}
-
-// Dispatch eventAPI events to the registered middleware
-func (r *SocketmodeHandler) eventAPIDispatcher(evt *Event) bool {
-	var isHandled bool
-	eventsAPIEvent, ok := evt.Data.(slackevents.EventsAPIEvent)
-	if !ok {
-		r.Client.log.Printf("Ignored %+v\n", evt)
-		return false
+	for actionID, f := range r.InteractionBlockActionEventMap {
+		router.HandleInteractionBlockAction(actionID, r.routerHandler(f))
+	}
+	for command, f := range r.SlashCommandMap {
+		router.HandleSlashCommand(command, r.routerHandler(f))
 	}
 
-	innerEventType := slackevents.EventsAPIType(eventsAPIEvent.InnerEvent.Type)
-
-	// Level 1 - socketmode EventType
-	isHandled = r.socketmodeDispatcher(evt)
-
-	// Level 2 - EventAPI EventType
-	if handlers, ok := r.EventApiMap[innerEventType]; ok {
-		// If we registered an event
-		for _, f := range handlers {
-			go f(evt, r.Client)
+	router.HandleDefault(func(ctx context.Context, req *slackevents.Request) {
+		if r.Default != nil {
+			r.Default(ctx.Value(eventContextKey{}).(*dispatchedEvent).handle(), r.Client)
 		}
+	})
 
-		isHandled = true
-	}
-
-	return isHandled
+	return router
 }
 
-// Dispatch SlashCommands events to the registered middleware
-func (r *SocketmodeHandler) slashCommandDispatcher(evt *Event) bool {
-	var isHandled bool
-	slashCommandEvent, ok := evt.Data.(slack.SlashCommand)
-	if !ok {
-		r.Client.log.Printf("Ignored %+v\n", evt)
-		return false
+// routerHandler adapts f to the router built by newRouter.
+func (r *SocketmodeHandler) routerHandler(f SocketmodeHandlerFunc) slackevents.RouterHandlerFunc {
+	return func(ctx context.Context, req *slackevents.Request) {
+		f(ctx.Value(eventContextKey{}).(*dispatchedEvent).handle(), r.Client)
 	}
+}
 
-	// Level 1 - socketmode EventType
-	isHandled = r.socketmodeDispatcher(evt)
-
-	// Level 2 - SlackCommand by name
-	if handler, ok := r.SlashCommandMap[slashCommandEvent.Command]; ok {
+// Dispatch events to the handlers registered so far
+func (r *SocketmodeHandler) dispatcher(evt Event) {
+	r.dispatch(context.Background(), r.newRouter(), evt)
+}
 
-		go handler(evt, r.Client)
+// dispatch routes the event with router. The handlers acknowledge the requests themselves, so
+// the request given to router is only acknowledged when no handler ran, e.g. when the router
+// suppressed it as a duplicate.
+func (r *SocketmodeHandler) dispatch(ctx context.Context, router *slackevents.Router, evt Event) {
+	d := &dispatchedEvent{evt: &evt}
+	req := slackevents.NewRequest(slackevents.RequestType(evt.Type), evt.Data, slackevents.AcknowledgerFunc(func(ctx context.Context, payload interface{}) error {
+		if evt.Request == nil || atomic.LoadInt32(&d.handled) == 1 {
+			return nil
+		}
 
-		isHandled = true
+		return r.Client.AckCtx(ctx, evt.Request.EnvelopeID, payload)
+	}))
+	if evt.Request != nil {
+		req.EnvelopeID = evt.Request.EnvelopeID
+		req.RetryAttempt = evt.Request.RetryAttempt
+		req.RetryReason = evt.Request.RetryReason
 	}
 
-	return isHandled
+	go router.Dispatch(context.WithValue(ctx, eventContextKey{}, d), req)
+}
+
+// ManifestRequirements returns what the app manifest must have for the handlers of r: Socket
+// Mode, the slash commands, the bot events and the scopes they require, and interactivity
+// when any interaction is handled. See slackevents.Router.ManifestRequirements.
+func (r *SocketmodeHandler) ManifestRequirements() (*slack.ManifestRequirements, error) {
+	reqs, err := r.newRouter().ManifestRequirements()
+	reqs.SocketMode = true
 
+	return reqs, err
 }
@@ -1,6 +1,7 @@
 package socketmode
 
 import (
+	"context"
 	"log"
 	"os"
 	"reflect"
@@ -346,6 +347,21 @@ func TestSocketmodeHandler_HandleInteractionBlockAction(t *testing.T) {
 				},
 			},
 			want: "github.com/slack-go/slack/socketmode.middleware_interaction_block_action",
+		}, {
+			name: "Block suggestion Match registered function",
+			args: args{
+				evt: Event{
+					Type: EventTypeInteractive,
+					Data: slack.InteractionCallback{
+						Type:     slack.InteractionTypeBlockSuggestion,
+						ActionID: "add_note",
+					},
+				},
+				register: func(r *SocketmodeHandler, c chan<- string) {
+					r.HandleInteractionBlockAction("add_note", testing_wrapper(c, middleware_interaction_block_action))
+				},
+			},
+			want: "github.com/slack-go/slack/socketmode.middleware_interaction_block_action",
 		}, {
 			name: "Event do not Match any registered function",
 			args: args{
@@ -513,3 +529,104 @@ func TestSocketmodeHandler_Handle_errors(t *testing.T) {
 		})
 	}
 }
+
+func TestSocketmodeHandler_Deduplicator(t *testing.T) {
+	r := init_SocketmodeHandler()
+	r.Client.socketModeResponses = make(chan *Response, 1)
+	r.Deduplicator = slackevents.NewDeduplicator(nil)
+
+	c := make(chan string, 2)
+	r.HandleEvents(slackevents.AppMention, testing_wrapper(c, middleware_eventapi))
+	r.HandleDefault(testing_wrapper(c, defaultmiddleware))
+
+	newDelivery := func(envelopeID string, retry int) Event {
+		return Event{
+			Type: EventTypeEventsAPI,
+			Data: slackevents.EventsAPIEvent{
+				Type: slackevents.CallbackEvent,
+				Data: &slackevents.EventsAPICallbackEvent{EventID: "Ev123"},
+				InnerEvent: slackevents.EventsAPIInnerEvent{
+					Type: string(slackevents.AppMention),
+				},
+			},
+			Request: &Request{EnvelopeID: envelopeID, RetryAttempt: retry},
+		}
+	}
+
+	r.dispatcher(newDelivery("envelope-1", 0))
+	if got := <-c; got != "github.com/slack-go/slack/socketmode.middleware_eventapi" {
+		t.Fatalf("first delivery was not dispatched, got %v", got)
+	}
+
+	r.dispatcher(newDelivery("envelope-2", 1))
+	res := <-r.Client.socketModeResponses
+	if res.EnvelopeID != "envelope-2" {
+		t.Fatalf("expected the duplicate envelope to be acknowledged, got %q", res.EnvelopeID)
+	}
+
+	select {
+	case got := <-c:
+		t.Fatalf("duplicate delivery was dispatched to %v", got)
+	default:
+	}
+
+	if stats := r.Deduplicator.Stats(); stats.Checked != 2 || stats.Suppressed != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestSocketmodeHandler_HandleMessages(t *testing.T) {
+	r := init_SocketmodeHandler()
+	r.Client.socketModeResponses = make(chan *Response, 1)
+
+	matched := make(chan string, 1)
+	router := slackevents.NewMessageRouter("UBOT")
+	router.HandleCommand("echo <what...>", func(ctx context.Context, msg *slackevents.MessageRequest) {
+		matched <- msg.StringArg("what")
+	})
+	r.HandleMessages(router)
+
+	r.dispatcher(Event{
+		Type: EventTypeEventsAPI,
+		Data: slackevents.EventsAPIEvent{
+			Type: slackevents.CallbackEvent,
+			InnerEvent: slackevents.EventsAPIInnerEvent{
+				Type: string(slackevents.AppMention),
+				Data: &slackevents.AppMentionEvent{Channel: "C1", User: "U1", Text: "<@UBOT> echo hello world"},
+			},
+		},
+		Request: &Request{EnvelopeID: "envelope-1"},
+	})
+
+	if res := <-r.Client.socketModeResponses; res.EnvelopeID != "envelope-1" {
+		t.Fatalf("expected the event to be acknowledged, got %q", res.EnvelopeID)
+	}
+	if got := <-matched; got != "hello world" {
+		t.Fatalf("unexpected argument %q", got)
+	}
+}
+
+func TestSocketmodeHandler_ManifestRequirements(t *testing.T) {
+	socketmodeHandler := init_SocketmodeHandler()
+	f := func(evt *Event, c *Client) {}
+
+	socketmodeHandler.HandleSlashCommand("/deploy", f)
+	socketmodeHandler.HandleEvents(slackevents.AppMention, f)
+	socketmodeHandler.HandleInteractionBlockAction("approve", f)
+
+	reqs, err := socketmodeHandler.ManifestRequirements()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := &slack.ManifestRequirements{
+		SlashCommands: []slack.ManifestSlashCommand{{Command: "/deploy"}},
+		BotEvents:     []string{"app_mention"},
+		BotScopes:     []string{"commands", "app_mentions:read"},
+		Interactivity: true,
+		SocketMode:    true,
+	}
+	if !reflect.DeepEqual(reqs, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, reqs)
+	}
+}
@@ -0,0 +1,377 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultStatusCampaignInterval = time.Minute
+
+// UserStatus is the custom status of a user.
+type UserStatus struct {
+	Text  string
+	Emoji string
+	// Expiration is the unix timestamp the status is cleared at, 0 when it does not expire.
+	Expiration int64
+}
+
+// StatusShift is a period, e.g. an on-call shift, during which a user has a custom status and
+// optionally snoozes their notifications.
+type StatusShift struct {
+	UserID string
+	Start  time.Time
+	End    time.Time
+	// StatusText and StatusEmoji are the status of the user during the shift, which expires at
+	// its end. The status is left untouched when both are empty.
+	StatusText  string
+	StatusEmoji string
+	// DND snoozes the notifications of the user until the end of the shift.
+	DND bool
+}
+
+func (s StatusShift) hasStatus() bool {
+	return s.StatusText != "" || s.StatusEmoji != ""
+}
+
+// sameAs reports whether s and o are the same shift of a user, whatever the location and the
+// monotonic clock reading of their times, e.g. once the shifts are synced again.
+func (s StatusShift) sameAs(o StatusShift) bool {
+	return s.UserID == o.UserID && s.Start.Equal(o.Start) && s.End.Equal(o.End)
+}
+
+func (s StatusShift) activeAt(t time.Time) bool {
+	return !t.Before(s.Start) && t.Before(s.End)
+}
+
+// StatusOverride is a change a user made to the status or the DND a campaign set during a shift.
+type StatusOverride struct {
+	Shift StatusShift
+	// Status is the status the user set, when they changed it.
+	Status *UserStatus
+	// DND is the DND status of the user, when they ended the snooze.
+	DND *DNDStatus
+}
+
+// StatusCampaignPlan is the list of the changes a StatusCampaign carried out.
+type StatusCampaignPlan struct {
+	// Set are the shifts whose status was set.
+	Set []StatusShift
+	// Snooze are the shifts whose notifications were snoozed.
+	Snooze []StatusShift
+	// Clear are the IDs of the users whose status of an ended shift was reverted: the status
+	// they had before the shift is restored, and the status is cleared when it is unknown or
+	// expired.
+	Clear []string
+	// Overridden are the IDs of the users skipped since they overrode their shift.
+	Overridden []string
+}
+
+// Empty reports whether the plan has no change.
+func (p *StatusCampaignPlan) Empty() bool {
+	return len(p.Set) == 0 && len(p.Snooze) == 0 && len(p.Clear) == 0
+}
+
+// String returns the changes of the plan, one per line.
+func (p *StatusCampaignPlan) String() string {
+	var lines []string
+	for _, s := range p.Set {
+		lines = append(lines, fmt.Sprintf("+ %s: %s %s until %s", s.UserID, s.StatusEmoji, s.StatusText, s.End.UTC().Format(time.RFC3339)))
+	}
+	for _, s := range p.Snooze {
+		lines = append(lines, fmt.Sprintf("+ %s: dnd until %s", s.UserID, s.End.UTC().Format(time.RFC3339)))
+	}
+	for _, user := range p.Clear {
+		lines = append(lines, fmt.Sprintf("- %s", user))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// StatusCampaign sets the custom status and the DND of users according to a schedule of shifts,
+// and reverts them afterwards. The statuses set expire at the end of the shifts, and the
+// notifications are snoozed until then, so that they are reverted even if the campaign stops.
+//
+// A user who changes the status or ends the snooze of a shift overrides it, see StatusChanged
+// and DNDChanged: the campaign leaves them alone until the end of the shift.
+type StatusCampaign struct {
+	// Client reads and sets the statuses. Setting the status of other users requires the token
+	// of an admin on a paid team.
+	Client *Client
+	// DNDClient, when set, returns the client snoozing the notifications of a user. It must be
+	// authenticated as the user, since the DND of other users cannot be changed. The DND of
+	// the shifts is ignored when it is nil.
+	DNDClient func(userID string) (*Client, error)
+	// Interval is the time between two reconciliations of Run, a minute by default.
+	Interval time.Duration
+
+	mu         sync.Mutex
+	shifts     map[string][]StatusShift
+	applied    map[string]StatusShift
+	overridden map[string]StatusShift
+	// previous are the statuses the users had before the campaign set the status of a shift.
+	previous map[string]UserStatus
+}
+
+// NewStatusCampaign returns a StatusCampaign applying shifts with client.
+func NewStatusCampaign(client *Client, shifts []StatusShift) *StatusCampaign {
+	c := &StatusCampaign{
+		Client:   client,
+		Interval: defaultStatusCampaignInterval,
+	}
+	c.SetShifts(shifts)
+	return c
+}
+
+// SetShifts replaces the schedule of the campaign, e.g. once the shifts are synced from an
+// on-call scheduler. The shifts of a user must not overlap.
+func (c *StatusCampaign) SetShifts(shifts []StatusShift) {
+	byUser := make(map[string][]StatusShift)
+	for _, s := range shifts {
+		byUser[s.UserID] = append(byUser[s.UserID], s)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.shifts = byUser
+}
+
+// initState allocates the state of the campaign, so that a StatusCampaign literal is usable.
+// c.mu must be held.
+func (c *StatusCampaign) initState() {
+	if c.applied == nil {
+		c.applied = make(map[string]StatusShift)
+	}
+	if c.overridden == nil {
+		c.overridden = make(map[string]StatusShift)
+	}
+	if c.previous == nil {
+		c.previous = make(map[string]UserStatus)
+	}
+}
+
+// activeShift returns the shift of user at t, if any. c.mu must be held.
+func (c *StatusCampaign) activeShift(user string, t time.Time) (StatusShift, bool) {
+	for _, s := range c.shifts[user] {
+		if s.activeAt(t) {
+			return s, true
+		}
+	}
+	return StatusShift{}, false
+}
+
+// endedShiftWithStatus returns whether status is the status of an ended shift of user at t.
+// c.mu must be held.
+func (c *StatusCampaign) endedShiftWithStatus(user string, status UserStatus, t time.Time) bool {
+	for _, s := range c.shifts[user] {
+		if s.hasStatus() && !t.Before(s.End) && s.StatusText == status.Text && s.StatusEmoji == status.Emoji {
+			return true
+		}
+	}
+	return false
+}
+
+// Run reconciles the statuses every Interval until ctx is done, or an error occurs. It returns
+// the error, and the campaign can then be run again.
+func (c *StatusCampaign) Run(ctx context.Context) error {
+	interval := c.Interval
+	if interval <= 0 {
+		interval = defaultStatusCampaignInterval
+	}
+
+	for {
+		if _, err := c.Reconcile(ctx, time.Now()); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Reconcile compares the actual status and DND of the users of the campaign with the shifts
+// active at now, and returns the plan it carried out. The status of a user is set when it differs
+// from the status of their shift, and reverted to the status they had before when it is still the
+// status of an ended shift. Their notifications are snoozed when they are not.
+func (c *StatusCampaign) Reconcile(ctx context.Context, now time.Time) (*StatusCampaignPlan, error) {
+	c.mu.Lock()
+	users := make([]string, 0, len(c.shifts))
+	for user := range c.shifts {
+		users = append(users, user)
+	}
+	c.mu.Unlock()
+	sort.Strings(users)
+
+	plan := &StatusCampaignPlan{}
+	for _, user := range users {
+		if err := c.reconcileUser(ctx, user, now, plan); err != nil {
+			return plan, err
+		}
+	}
+	return plan, nil
+}
+
+func (c *StatusCampaign) reconcileUser(ctx context.Context, user string, now time.Time, plan *StatusCampaignPlan) error {
+	c.mu.Lock()
+	shift, active := c.activeShift(user, now)
+	if o, ok := c.overridden[user]; ok && !now.Before(o.End) {
+		delete(c.overridden, user)
+	}
+	_, overridden := c.overridden[user]
+	if !active {
+		delete(c.applied, user)
+	}
+	c.mu.Unlock()
+
+	if overridden {
+		plan.Overridden = append(plan.Overridden, user)
+		return nil
+	}
+
+	if !active || shift.hasStatus() {
+		profile, err := c.Client.GetUserProfileContext(ctx, &GetUserProfileParameters{UserID: user})
+		if err != nil {
+			return fmt.Errorf("failed to get the status of %s: %w", user, err)
+		}
+		actual := UserStatus{Text: profile.StatusText, Emoji: profile.StatusEmoji, Expiration: int64(profile.StatusExpiration)}
+
+		if active {
+			desired := UserStatus{Text: shift.StatusText, Emoji: shift.StatusEmoji, Expiration: shift.End.Unix()}
+			if actual != desired {
+				c.mu.Lock()
+				c.initState()
+				if _, ok := c.previous[user]; !ok && !c.endedShiftWithStatus(user, actual, now) {
+					c.previous[user] = actual
+				}
+				c.mu.Unlock()
+
+				if err := c.Client.SetUserCustomStatusContextWithUser(ctx, user, desired.Text, desired.Emoji, desired.Expiration); err != nil {
+					return fmt.Errorf("failed to set the status of %s: %w", user, err)
+				}
+				plan.Set = append(plan.Set, shift)
+			}
+		} else {
+			c.mu.Lock()
+			ended := c.endedShiftWithStatus(user, actual, now)
+			previous := c.previous[user]
+			c.mu.Unlock()
+
+			if ended {
+				if previous.Expiration != 0 && previous.Expiration <= now.Unix() {
+					previous = UserStatus{}
+				}
+				if err := c.Client.SetUserCustomStatusContextWithUser(ctx, user, previous.Text, previous.Emoji, previous.Expiration); err != nil {
+					return fmt.Errorf("failed to revert the status of %s: %w", user, err)
+				}
+				plan.Clear = append(plan.Clear, user)
+			}
+
+			c.mu.Lock()
+			delete(c.previous, user)
+			c.mu.Unlock()
+		}
+	}
+
+	if active && shift.DND && c.DNDClient != nil {
+		client, err := c.DNDClient(user)
+		if err != nil {
+			return fmt.Errorf("failed to get the DND client of %s: %w", user, err)
+		}
+
+		dnd, err := client.GetDNDInfoContext(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get the DND of %s: %w", user, err)
+		}
+
+		if !dnd.SnoozeEnabled {
+			minutes := int(math.Ceil(shift.End.Sub(now).Minutes()))
+			if _, err := client.SetSnoozeContext(ctx, minutes); err != nil {
+				return fmt.Errorf("failed to snooze the notifications of %s: %w", user, err)
+			}
+			plan.Snooze = append(plan.Snooze, shift)
+		}
+	}
+
+	if active {
+		c.mu.Lock()
+		c.initState()
+		c.applied[user] = shift
+		c.mu.Unlock()
+	}
+
+	return nil
+}
+
+// override records that user overrode shift, unless the campaign has not applied it yet.
+func (c *StatusCampaign) override(user string, shift StatusShift) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if applied, ok := c.applied[user]; !ok || !applied.sameAs(shift) {
+		return false
+	}
+	c.initState()
+	c.overridden[user] = shift
+	return true
+}
+
+// StatusChanged records a change of the status of a user, e.g. from a user_status_changed event.
+// It returns true when the user overrode the status of their active shift, which is then left
+// alone until its end.
+func (c *StatusCampaign) StatusChanged(userID string, status UserStatus) (StatusOverride, bool) {
+	c.mu.Lock()
+	shift, active := c.activeShift(userID, time.Now())
+	c.mu.Unlock()
+
+	if !active || !shift.hasStatus() {
+		return StatusOverride{}, false
+	}
+	if status.Text == shift.StatusText && status.Emoji == shift.StatusEmoji {
+		return StatusOverride{}, false
+	}
+
+	if !c.override(userID, shift) {
+		return StatusOverride{}, false
+	}
+	return StatusOverride{Shift: shift, Status: &status}, true
+}
+
+// DNDChanged checks the DND of a user after it changed, e.g. from a dnd_updated_user event, which
+// does not tell whether the notifications of the user are snoozed. It returns true when the user
+// ended the snooze of their active shift, which is then left alone until its end.
+func (c *StatusCampaign) DNDChanged(ctx context.Context, userID string) (StatusOverride, bool, error) {
+	c.mu.Lock()
+	shift, active := c.activeShift(userID, time.Now())
+	c.mu.Unlock()
+
+	if !active || !shift.DND || c.DNDClient == nil {
+		return StatusOverride{}, false, nil
+	}
+
+	client, err := c.DNDClient(userID)
+	if err != nil {
+		return StatusOverride{}, false, err
+	}
+
+	dnd, err := client.GetDNDInfoContext(ctx, nil)
+	if err != nil {
+		return StatusOverride{}, false, err
+	}
+	if dnd.SnoozeEnabled {
+		return StatusOverride{}, false, nil
+	}
+
+	if !c.override(userID, shift) {
+		return StatusOverride{}, false, nil
+	}
+	return StatusOverride{Shift: shift, DND: dnd}, true, nil
+}
@@ -0,0 +1,208 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStatusCampaign(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	end := now.Add(time.Hour)
+
+	profiles := map[string]string{
+		"U1": `{"status_text":"Lunch","status_emoji":":taco:"}`,
+		"U2": `{"status_text":"On call","status_emoji":":pager:","status_expiration":0}`,
+		"U3": fmt.Sprintf(`{"status_text":"On call","status_emoji":":pager:","status_expiration":%d}`, end.Unix()),
+	}
+	var calls []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users.profile.get", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"ok":true,"profile":%s}`, profiles[r.FormValue("user")])
+	})
+	mux.HandleFunc("/users.profile.set", func(w http.ResponseWriter, r *http.Request) {
+		var status struct {
+			StatusText       string `json:"status_text"`
+			StatusEmoji      string `json:"status_emoji"`
+			StatusExpiration int64  `json:"status_expiration"`
+		}
+		json.Unmarshal([]byte(r.FormValue("profile")), &status)
+		calls = append(calls, fmt.Sprintf("set %s %q %s %d", r.FormValue("user"), status.StatusText, status.StatusEmoji, status.StatusExpiration))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	})
+	mux.HandleFunc("/dnd.info", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"dnd_enabled":true,"snooze_enabled":false}`))
+	})
+	mux.HandleFunc("/dnd.setSnooze", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "snooze "+r.FormValue("num_minutes"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"snooze_enabled":true}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+	oncall := StatusShift{UserID: "U1", Start: now.Add(-time.Minute), End: end, StatusText: "On call", StatusEmoji: ":pager:", DND: true}
+	c := NewStatusCampaign(api, []StatusShift{
+		oncall,
+		{UserID: "U2", Start: now.Add(-2 * time.Hour), End: now.Add(-time.Hour), StatusText: "On call", StatusEmoji: ":pager:"},
+		{UserID: "U3", Start: now.Add(-time.Minute), End: end, StatusText: "On call", StatusEmoji: ":pager:"},
+	})
+	c.DNDClient = func(userID string) (*Client, error) {
+		return api, nil
+	}
+
+	plan, err := c.Reconcile(context.Background(), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{
+		fmt.Sprintf(`set U1 "On call" :pager: %d`, end.Unix()),
+		"snooze 60",
+		`set U2 ""  0`,
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected %v, got %v", expected, calls)
+	}
+	if len(plan.Set) != 1 || len(plan.Snooze) != 1 || !reflect.DeepEqual(plan.Clear, []string{"U2"}) {
+		t.Errorf("unexpected plan:\n%s", plan)
+	}
+
+	if _, ok := c.StatusChanged("U1", UserStatus{Text: "On call", Emoji: ":pager:", Expiration: end.Unix()}); ok {
+		t.Error("expected the status set by the campaign not to be an override")
+	}
+
+	// The shifts synced again have the same times in another location.
+	resynced := oncall
+	resynced.Start, resynced.End = oncall.Start.In(time.FixedZone("CET", 3600)), oncall.End.In(time.FixedZone("CET", 3600))
+	c.SetShifts([]StatusShift{
+		resynced,
+		{UserID: "U2", Start: now.Add(-2 * time.Hour), End: now.Add(-time.Hour), StatusText: "On call", StatusEmoji: ":pager:"},
+		{UserID: "U3", Start: now.Add(-time.Minute), End: end, StatusText: "On call", StatusEmoji: ":pager:"},
+	})
+
+	override, ok := c.StatusChanged("U1", UserStatus{Text: "Sick", Emoji: ":face_with_thermometer:"})
+	if !ok || !override.Shift.sameAs(oncall) || override.Status.Text != "Sick" {
+		t.Fatalf("expected an override, got %+v, %v", override, ok)
+	}
+
+	calls = nil
+	plan, err = c.Reconcile(context.Background(), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(plan.Overridden, []string{"U1"}) {
+		t.Errorf("expected U1 to be overridden, got %v", plan.Overridden)
+	}
+	for _, call := range calls {
+		if call != `set U2 ""  0` {
+			t.Errorf("unexpected call %q", call)
+		}
+	}
+}
+
+func TestStatusCampaignDNDChanged(t *testing.T) {
+	now := time.Now()
+	snoozed := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/dnd.info", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"ok":true,"dnd_enabled":true,"snooze_enabled":%t}`, snoozed)
+	})
+	mux.HandleFunc("/dnd.setSnooze", func(w http.ResponseWriter, r *http.Request) {
+		snoozed = true
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true,"snooze_enabled":true}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+	c := NewStatusCampaign(api, []StatusShift{{UserID: "U1", Start: now.Add(-time.Minute), End: now.Add(time.Hour), DND: true}})
+	c.DNDClient = func(userID string) (*Client, error) {
+		return api, nil
+	}
+
+	if _, ok, err := c.DNDChanged(context.Background(), "U1"); ok || err != nil {
+		t.Fatalf("expected no override before the shift is applied, got %v, %v", ok, err)
+	}
+
+	if _, err := c.Reconcile(context.Background(), now); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok, err := c.DNDChanged(context.Background(), "U1"); ok || err != nil {
+		t.Fatalf("expected no override while snoozed, got %v, %v", ok, err)
+	}
+
+	snoozed = false
+	if _, ok, err := c.DNDChanged(context.Background(), "U1"); !ok || err != nil {
+		t.Fatalf("expected an override, got %v, %v", ok, err)
+	}
+}
+
+func TestStatusCampaignRevert(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	end := now.Add(time.Hour)
+
+	profile := `{"status_text":"Lunch","status_emoji":":taco:","status_expiration":0}`
+	var calls []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users.profile.get", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"ok":true,"profile":%s}`, profile)
+	})
+	mux.HandleFunc("/users.profile.set", func(w http.ResponseWriter, r *http.Request) {
+		profile = r.FormValue("profile")
+		var status struct {
+			StatusText       string `json:"status_text"`
+			StatusEmoji      string `json:"status_emoji"`
+			StatusExpiration int64  `json:"status_expiration"`
+		}
+		json.Unmarshal([]byte(profile), &status)
+		calls = append(calls, fmt.Sprintf("set %s %q %s %d", r.FormValue("user"), status.StatusText, status.StatusEmoji, status.StatusExpiration))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	// The zero StatusCampaign is usable.
+	c := &StatusCampaign{Client: New("testing-token", OptionAPIURL(ts.URL+"/"))}
+	c.SetShifts([]StatusShift{{UserID: "U1", Start: now.Add(-time.Minute), End: end, StatusText: "On call", StatusEmoji: ":pager:"}})
+
+	if _, err := c.Reconcile(context.Background(), now); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	plan, err := c.Reconcile(context.Background(), end)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{
+		fmt.Sprintf(`set U1 "On call" :pager: %d`, end.Unix()),
+		`set U1 "Lunch" :taco: 0`,
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected %v, got %v", expected, calls)
+	}
+	if !reflect.DeepEqual(plan.Clear, []string{"U1"}) {
+		t.Errorf("unexpected plan:\n%s", plan)
+	}
+
+	calls = nil
+	if _, err := c.Reconcile(context.Background(), end.Add(time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("expected the restored status to be left alone, got %v", calls)
+	}
+}
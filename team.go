@@ -97,14 +97,14 @@ func (api *Client) teamRequest(ctx context.Context, path string, values url.Valu
 	return response, response.Err()
 }
 
-func (api *Client) billableInfoRequest(ctx context.Context, path string, values url.Values) (map[string]BillingActive, error) {
+func (api *Client) billableInfoRequest(ctx context.Context, path string, values url.Values) (map[string]BillingActive, string, error) {
 	response := &BillableInfoResponse{}
 	err := api.postMethod(ctx, path, values, response)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	return response.BillableInfo, response.Err()
+	return response.BillableInfo, response.ResponseMetadata.Cursor, response.Err()
 }
 
 func (api *Client) accessLogsRequest(ctx context.Context, path string, values url.Values) (*LoginResponse, error) {
@@ -220,9 +220,53 @@ func (api *Client) GetAccessLogsContext(ctx context.Context, params AccessLogPar
 	return response.Logins, &response.Paging, nil
 }
 
+// IterateAccessLogs calls f with the logins of all the pages from params.Page.
+// For more information see the IterateAccessLogsContext documentation.
+func (api *Client) IterateAccessLogs(params AccessLogParameters, f func(Login) error) error {
+	return api.IterateAccessLogsContext(context.Background(), params, f)
+}
+
+// IterateAccessLogsContext calls f with the logins of all the pages from params.Page, with a custom
+// context. The rate limited requests are retried once allowed. The iteration stops at the first
+// error of f, which is returned.
+func (api *Client) IterateAccessLogsContext(ctx context.Context, params AccessLogParameters, f func(Login) error) error {
+	if params.Page == 0 {
+		params.Page = DEFAULT_LOGINS_PAGE
+	}
+	if params.Count == 0 {
+		params.Count = DEFAULT_LOGINS_COUNT
+	}
+
+	for {
+		logins, paging, err := api.GetAccessLogsContext(ctx, params)
+		if err != nil {
+			if waitRateLimit(ctx, err) {
+				continue
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		for _, login := range logins {
+			if err := f(login); err != nil {
+				return err
+			}
+		}
+
+		if paging.Page >= paging.Pages {
+			return nil
+		}
+		params.Page = paging.Page + 1
+	}
+}
+
 type GetBillableInfoParams struct {
 	User   string
 	TeamID string
+	Cursor string
+	Limit  int
 }
 
 // GetBillableInfo gets the billable users information of the team.
@@ -234,6 +278,11 @@ func (api *Client) GetBillableInfo(params GetBillableInfoParams) (map[string]Bil
 // GetBillableInfoContext gets the billable users information of the team with a custom context.
 // Slack API docs: https://api.slack.com/methods/team.billableInfo
 func (api *Client) GetBillableInfoContext(ctx context.Context, params GetBillableInfoParams) (map[string]BillingActive, error) {
+	info, _, err := api.getBillableInfoPage(ctx, params)
+	return info, err
+}
+
+func (api *Client) getBillableInfoPage(ctx context.Context, params GetBillableInfoParams) (map[string]BillingActive, string, error) {
 	values := url.Values{
 		"token": {api.token},
 	}
@@ -246,5 +295,166 @@ func (api *Client) GetBillableInfoContext(ctx context.Context, params GetBillabl
 		values.Add("user", params.User)
 	}
 
+	if params.Cursor != "" {
+		values.Add("cursor", params.Cursor)
+	}
+
+	if params.Limit != 0 {
+		values.Add("limit", strconv.Itoa(params.Limit))
+	}
+
 	return api.billableInfoRequest(ctx, "team.billableInfo", values)
 }
+
+// GetAllBillableInfo gets the billable users information of the team from all the pages.
+// For more information see the GetAllBillableInfoContext documentation.
+func (api *Client) GetAllBillableInfo(params GetBillableInfoParams) (map[string]BillingActive, error) {
+	return api.GetAllBillableInfoContext(context.Background(), params)
+}
+
+// GetAllBillableInfoContext gets the billable users information of the team with a custom context,
+// following the cursors from params.Cursor until the last page. The rate limited requests are
+// retried once allowed.
+func (api *Client) GetAllBillableInfoContext(ctx context.Context, params GetBillableInfoParams) (map[string]BillingActive, error) {
+	all := make(map[string]BillingActive)
+	for {
+		info, cursor, err := api.getBillableInfoPage(ctx, params)
+		if err != nil {
+			if waitRateLimit(ctx, err) {
+				continue
+			}
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+
+		for user, billing := range info {
+			all[user] = billing
+		}
+
+		if cursor == "" {
+			return all, nil
+		}
+		params.Cursor = cursor
+	}
+}
+
+// IntegrationLog is a change of the apps or the custom integrations of a team, as returned by
+// GetIntegrationLogs. Only one of AppID and ServiceID is set.
+type IntegrationLog struct {
+	AppID       string   `json:"app_id,omitempty"`
+	AppType     string   `json:"app_type,omitempty"`
+	ServiceID   string   `json:"service_id,omitempty"`
+	ServiceType string   `json:"service_type,omitempty"`
+	UserID      string   `json:"user_id"`
+	UserName    string   `json:"user_name"`
+	Channel     string   `json:"channel,omitempty"`
+	Date        JSONTime `json:"date"`
+	// ChangeType is e.g. "added", "removed", "enabled", "disabled" or "expanded".
+	ChangeType string `json:"change_type"`
+	Reason     string `json:"reason,omitempty"`
+	Scope      string `json:"scope,omitempty"`
+	RSSFeed    bool   `json:"rss_feed,omitempty"`
+}
+
+// IntegrationLogParameters contains the parameters of a GetIntegrationLogs request.
+type IntegrationLogParameters struct {
+	AppID      string
+	ChangeType string
+	ServiceID  string
+	TeamID     string
+	User       string
+	Count      int
+	Page       int
+}
+
+// GetIntegrationLogs retrieves a page of the integration logs according to the parameters given.
+// For more information see the GetIntegrationLogsContext documentation.
+func (api *Client) GetIntegrationLogs(params IntegrationLogParameters) ([]IntegrationLog, *Paging, error) {
+	return api.GetIntegrationLogsContext(context.Background(), params)
+}
+
+// GetIntegrationLogsContext retrieves a page of the integration logs according to the parameters
+// given with a custom context. The logs are returned from the most recent.
+// Slack API docs: https://api.slack.com/methods/team.integrationLogs
+func (api *Client) GetIntegrationLogsContext(ctx context.Context, params IntegrationLogParameters) ([]IntegrationLog, *Paging, error) {
+	values := url.Values{
+		"token": {api.token},
+	}
+	if params.AppID != "" {
+		values.Add("app_id", params.AppID)
+	}
+	if params.ChangeType != "" {
+		values.Add("change_type", params.ChangeType)
+	}
+	if params.ServiceID != "" {
+		values.Add("service_id", params.ServiceID)
+	}
+	if params.TeamID != "" {
+		values.Add("team_id", params.TeamID)
+	}
+	if params.User != "" {
+		values.Add("user", params.User)
+	}
+	if params.Count != 0 {
+		values.Add("count", strconv.Itoa(params.Count))
+	}
+	if params.Page != 0 {
+		values.Add("page", strconv.Itoa(params.Page))
+	}
+
+	response := struct {
+		Logs   []IntegrationLog `json:"logs"`
+		Paging Paging           `json:"paging"`
+		SlackResponse
+	}{}
+
+	err := api.postMethod(ctx, "team.integrationLogs", values, &response)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := response.Err(); err != nil {
+		return nil, nil, err
+	}
+	return response.Logs, &response.Paging, nil
+}
+
+// IterateIntegrationLogs calls f with the integration logs of all the pages from params.Page.
+// For more information see the IterateIntegrationLogsContext documentation.
+func (api *Client) IterateIntegrationLogs(params IntegrationLogParameters, f func(IntegrationLog) error) error {
+	return api.IterateIntegrationLogsContext(context.Background(), params, f)
+}
+
+// IterateIntegrationLogsContext calls f with the integration logs of all the pages from
+// params.Page, with a custom context. The rate limited requests are retried once allowed. The
+// iteration stops at the first error of f, which is returned.
+func (api *Client) IterateIntegrationLogsContext(ctx context.Context, params IntegrationLogParameters, f func(IntegrationLog) error) error {
+	if params.Page == 0 {
+		params.Page = 1
+	}
+
+	for {
+		logs, paging, err := api.GetIntegrationLogsContext(ctx, params)
+		if err != nil {
+			if waitRateLimit(ctx, err) {
+				continue
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		for _, log := range logs {
+			if err := f(log); err != nil {
+				return err
+			}
+		}
+
+		if paging.Page >= paging.Pages {
+			return nil
+		}
+		params.Page = paging.Page + 1
+	}
+}
@@ -0,0 +1,83 @@
+package slack
+
+import (
+	"sort"
+	"time"
+)
+
+// ActiveUsersPerDay counts the active members of entries per day, keyed by the YYYY-MM-DD dates
+// of the entries.
+func ActiveUsersPerDay(entries []AnalyticsMemberEntry) map[string]int {
+	active := make(map[string]int)
+	for _, entry := range entries {
+		if entry.IsActive {
+			active[entry.Date]++
+		}
+	}
+	return active
+}
+
+// StaleAccounts returns the IDs of the users billed in billable who have not logged in since
+// since according to logins, sorted. The users who never logged in are stale as well, but note
+// that team.accessLogs only returns the logins of the last months.
+func StaleAccounts(billable map[string]BillingActive, logins []Login, since time.Time) []string {
+	lastLogin := make(map[string]int, len(logins))
+	for _, login := range logins {
+		if login.DateLast > lastLogin[login.UserID] {
+			lastLogin[login.UserID] = login.DateLast
+		}
+	}
+
+	var stale []string
+	for user, billing := range billable {
+		if !billing.BillingActive {
+			continue
+		}
+		if int64(lastLogin[user]) < since.Unix() {
+			stale = append(stale, user)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
+
+// IntegrationsByUser replays logs, e.g. as returned by IterateIntegrationLogs, and returns the
+// integrations still installed, keyed by the ID of the user who changed them last. Every
+// integration is represented by its most recent log, and they are sorted by date.
+func IntegrationsByUser(logs []IntegrationLog) map[string][]IntegrationLog {
+	sorted := make([]IntegrationLog, len(logs))
+	copy(sorted, logs)
+	// team.integrationLogs returns the logs from the most recent.
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date < sorted[j].Date
+	})
+
+	installed := make(map[string]IntegrationLog)
+	for _, log := range sorted {
+		id := log.AppID
+		if id == "" {
+			id = log.ServiceID
+		}
+
+		switch log.ChangeType {
+		case "removed", "disabled":
+			delete(installed, id)
+		default:
+			installed[id] = log
+		}
+	}
+
+	byUser := make(map[string][]IntegrationLog)
+	for _, log := range installed {
+		byUser[log.UserID] = append(byUser[log.UserID], log)
+	}
+	for _, userLogs := range byUser {
+		sort.Slice(userLogs, func(i, j int) bool {
+			if userLogs[i].Date != userLogs[j].Date {
+				return userLogs[i].Date < userLogs[j].Date
+			}
+			return userLogs[i].AppID+userLogs[i].ServiceID < userLogs[j].AppID+userLogs[j].ServiceID
+		})
+	}
+	return byUser
+}
@@ -0,0 +1,61 @@
+package slack
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestActiveUsersPerDay(t *testing.T) {
+	entries := []AnalyticsMemberEntry{
+		{Date: "2024-01-01", UserID: "W1", IsActive: true},
+		{Date: "2024-01-01", UserID: "W2"},
+		{Date: "2024-01-02", UserID: "W1", IsActive: true},
+		{Date: "2024-01-02", UserID: "W2", IsActive: true},
+	}
+
+	expected := map[string]int{"2024-01-01": 1, "2024-01-02": 2}
+	if active := ActiveUsersPerDay(entries); !reflect.DeepEqual(active, expected) {
+		t.Errorf("expected %v, got %v", expected, active)
+	}
+}
+
+func TestStaleAccounts(t *testing.T) {
+	billable := map[string]BillingActive{
+		"U1": {BillingActive: true},
+		"U2": {BillingActive: true},
+		"U3": {BillingActive: true},
+		"U4": {BillingActive: false},
+	}
+	logins := []Login{
+		{UserID: "U1", DateLast: 500},
+		{UserID: "U1", DateLast: 2000},
+		{UserID: "U2", DateLast: 900},
+		{UserID: "U4", DateLast: 100},
+	}
+
+	expected := []string{"U2", "U3"}
+	if stale := StaleAccounts(billable, logins, time.Unix(1000, 0)); !reflect.DeepEqual(stale, expected) {
+		t.Errorf("expected %v, got %v", expected, stale)
+	}
+}
+
+func TestIntegrationsByUser(t *testing.T) {
+	logs := []IntegrationLog{
+		{AppID: "A2", UserID: "U2", Date: 400, ChangeType: "removed"},
+		{ServiceID: "S1", UserID: "U1", Date: 300, ChangeType: "added"},
+		{AppID: "A1", UserID: "U1", Date: 250, ChangeType: "expanded"},
+		{AppID: "A2", UserID: "U2", Date: 200, ChangeType: "added"},
+		{AppID: "A1", UserID: "U2", Date: 100, ChangeType: "added"},
+	}
+
+	expected := map[string][]IntegrationLog{
+		"U1": {
+			{AppID: "A1", UserID: "U1", Date: 250, ChangeType: "expanded"},
+			{ServiceID: "S1", UserID: "U1", Date: 300, ChangeType: "added"},
+		},
+	}
+	if byUser := IntegrationsByUser(logs); !reflect.DeepEqual(byUser, expected) {
+		t.Errorf("expected %+v, got %+v", expected, byUser)
+	}
+}
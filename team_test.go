@@ -1,10 +1,14 @@
 package slack
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 var (
@@ -236,3 +240,123 @@ func TestGetAccessLogs(t *testing.T) {
 		t.Fatal(ErrIncorrectResponse)
 	}
 }
+
+func TestIterateAccessLogs(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/team.accessLogs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.FormValue("page") {
+		case "":
+			w.Write([]byte(`{"ok":true,"logins":[{"user_id":"U1","date_last":100}],"paging":{"count":1,"total":2,"page":1,"pages":2}}`))
+		case "2":
+			w.Write([]byte(`{"ok":true,"logins":[{"user_id":"U2","date_last":200}],"paging":{"count":1,"total":2,"page":2,"pages":2}}`))
+		default:
+			t.Errorf("unexpected page %q", r.FormValue("page"))
+		}
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	var users []string
+	err := api.IterateAccessLogs(NewAccessLogParameters(), func(login Login) error {
+		users = append(users, login.UserID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(users, []string{"U1", "U2"}) {
+		t.Errorf("unexpected users %v", users)
+	}
+}
+
+func TestIterateAccessLogsContext_canceledWhileRateLimited(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/team.accessLogs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := api.IterateAccessLogsContext(ctx, NewAccessLogParameters(), func(login Login) error {
+		return nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the error of the context, got %v", err)
+	}
+}
+
+func TestGetAllBillableInfo(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/team.billableInfo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.FormValue("cursor") {
+		case "":
+			w.Write([]byte(`{"ok":true,"billable_info":{"U1":{"billing_active":true}},"response_metadata":{"next_cursor":"page2"}}`))
+		case "page2":
+			w.Write([]byte(`{"ok":true,"billable_info":{"U2":{"billing_active":false}},"response_metadata":{"next_cursor":""}}`))
+		default:
+			t.Errorf("unexpected cursor %q", r.FormValue("cursor"))
+		}
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	info, err := api.GetAllBillableInfo(GetBillableInfoParams{Limit: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]BillingActive{"U1": {BillingActive: true}, "U2": {BillingActive: false}}
+	if !reflect.DeepEqual(info, expected) {
+		t.Errorf("expected %v, got %v", expected, info)
+	}
+}
+
+func TestIterateIntegrationLogs(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/team.integrationLogs", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("change_type") != "added" {
+			t.Errorf("unexpected change_type %q", r.FormValue("change_type"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.FormValue("page") {
+		case "1":
+			w.Write([]byte(`{"ok":true,"logs":[{"app_id":"A1","app_type":"app","user_id":"U1","user_name":"alice","date":"1392163200","change_type":"added","scope":"chat:write"}],"paging":{"count":1,"total":2,"page":1,"pages":2}}`))
+		case "2":
+			w.Write([]byte(`{"ok":true,"logs":[{"service_id":"1234","service_type":"Google Calendar","user_id":"U2","user_name":"bob","date":"1392163100","change_type":"added","channel":"C1"}],"paging":{"count":1,"total":2,"page":2,"pages":2}}`))
+		default:
+			t.Errorf("unexpected page %q", r.FormValue("page"))
+		}
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+
+	var logs []IntegrationLog
+	err := api.IterateIntegrationLogs(IntegrationLogParameters{ChangeType: "added"}, func(log IntegrationLog) error {
+		logs = append(logs, log)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []IntegrationLog{
+		{AppID: "A1", AppType: "app", UserID: "U1", UserName: "alice", Date: JSONTime(1392163200), ChangeType: "added", Scope: "chat:write"},
+		{ServiceID: "1234", ServiceType: "Google Calendar", UserID: "U2", UserName: "bob", Date: JSONTime(1392163100), ChangeType: "added", Channel: "C1"},
+	}
+	if !reflect.DeepEqual(logs, expected) {
+		t.Errorf("expected %+v, got %+v", expected, logs)
+	}
+}
@@ -2,9 +2,17 @@ package slack
 
 import (
 	"context"
+	"errors"
 	"net/url"
+	"reflect"
+	"sync"
+	"time"
 )
 
+// configTokenRotationMargin is how long before their expiration the configuration tokens
+// are rotated.
+const configTokenRotationMargin = 5 * time.Minute
+
 // RotateTokens exchanges a refresh token for a new app configuration token.
 // For more information see the RotateTokensContext documentation.
 func (api *Client) RotateTokens(configToken string, refreshToken string) (*TokenResponse, error) {
@@ -14,12 +22,13 @@ func (api *Client) RotateTokens(configToken string, refreshToken string) (*Token
 // RotateTokensContext exchanges a refresh token for a new app configuration token with a custom context.
 // Slack API docs: https://api.slack.com/methods/tooling.tokens.rotate
 func (api *Client) RotateTokensContext(ctx context.Context, configToken string, refreshToken string) (*TokenResponse, error) {
+	token, refresh, _ := api.configTokens()
 	if configToken == "" {
-		configToken = api.configToken
+		configToken = token
 	}
 
 	if refreshToken == "" {
-		refreshToken = api.configRefreshToken
+		refreshToken = refresh
 	}
 
 	values := url.Values{
@@ -35,10 +44,131 @@ func (api *Client) RotateTokensContext(ctx context.Context, configToken string,
 	return response, response.Err()
 }
 
-// UpdateConfigTokens replaces the configuration tokens in the client with those returned by the API
+// configMutexes guards the configuration tokens of a Client, and serializes their rotations.
+// The Client holds them by pointer since it is copied by value, e.g. into an RTM.
+type configMutexes struct {
+	tokens   sync.Mutex
+	rotation sync.Mutex
+}
+
+// configMutexesMu guards the allocation of the configMutexes of the clients.
+var configMutexesMu sync.Mutex
+
+// configMutexes returns the configMutexes of the client, allocating them on first use so that
+// a Client built without New can be used.
+func (api *Client) configMutexes() *configMutexes {
+	configMutexesMu.Lock()
+	defer configMutexesMu.Unlock()
+
+	if api.configMus == nil {
+		api.configMus = &configMutexes{}
+	}
+	return api.configMus
+}
+
+// UpdateConfigTokens replaces the configuration tokens in the client with those returned by the API,
+// and their expiration time.
 func (api *Client) UpdateConfigTokens(response *TokenResponse) {
+	mus := api.configMutexes()
+	mus.tokens.Lock()
+	defer mus.tokens.Unlock()
+
 	api.configToken = response.Token
 	api.configRefreshToken = response.RefreshToken
+	api.configTokenExpiresAt = time.Time{}
+	if response.ExpiresAt > 0 {
+		api.configTokenExpiresAt = time.Unix(int64(response.ExpiresAt), 0)
+	}
+}
+
+// ConfigTokenExpiresAt returns the expiration time of the configuration token of the client,
+// or the zero time when it is unknown.
+func (api *Client) ConfigTokenExpiresAt() time.Time {
+	_, _, expiresAt := api.configTokens()
+	return expiresAt
+}
+
+func (api *Client) configTokens() (token, refreshToken string, expiresAt time.Time) {
+	mus := api.configMutexes()
+	mus.tokens.Lock()
+	defer mus.tokens.Unlock()
+
+	return api.configToken, api.configRefreshToken, api.configTokenExpiresAt
+}
+
+// rotateConfigToken rotates the configuration token of the client when it is still token, and
+// when force is true or it is about to expire. It returns the token to use.
+func (api *Client) rotateConfigToken(ctx context.Context, token string, force bool) (string, error) {
+	mus := api.configMutexes()
+	mus.rotation.Lock()
+	defer mus.rotation.Unlock()
+
+	current, refresh, expiresAt := api.configTokens()
+	if current != token {
+		// Rotated concurrently.
+		return current, nil
+	}
+	if !force && (expiresAt.IsZero() || time.Until(expiresAt) > configTokenRotationMargin) {
+		return current, nil
+	}
+	if refresh == "" {
+		return current, nil
+	}
+
+	resp, err := api.RotateTokensContext(ctx, current, refresh)
+	if err != nil {
+		return "", err
+	}
+	api.UpdateConfigTokens(resp)
+
+	if api.onConfigTokenRotated != nil {
+		if err := api.onConfigTokenRotated(ctx, resp); err != nil {
+			return "", err
+		}
+	}
+
+	return resp.Token, nil
+}
+
+// postConfigMethod posts a method authenticated with a configuration token, which is the one
+// of the client when token is empty. When its rotation is enabled, see OptionConfigTokenRotation,
+// the token of the client is rotated before the request when it is about to expire, and the
+// request is retried once with a rotated token when the Web API reports that it expired.
+func (api *Client) postConfigMethod(ctx context.Context, path string, token string, values url.Values, intf interface{ Err() error }) error {
+	current, _, _ := api.configTokens()
+	managed := api.configTokenRotation && (token == "" || token == current)
+
+	if token == "" {
+		token = current
+	}
+	if managed {
+		var err error
+		if token, err = api.rotateConfigToken(ctx, token, false); err != nil {
+			return err
+		}
+	}
+
+	values.Set("token", token)
+	if err := api.postMethod(ctx, path, values, intf); err != nil {
+		return err
+	}
+
+	var serr SlackErrorResponse
+	if !managed || !errors.As(intf.Err(), &serr) || serr.Err != "token_expired" {
+		return nil
+	}
+
+	token, err := api.rotateConfigToken(ctx, token, true)
+	if err != nil {
+		return err
+	}
+
+	// Reset the response, as the fields of the failed one would remain otherwise.
+	v := reflect.ValueOf(intf).Elem()
+	v.Set(reflect.Zero(v.Type()))
+
+	values.Set("token", token)
+	return api.postMethod(ctx, path, values, intf)
 }
 
 type TokenResponse struct {
@@ -1,10 +1,15 @@
 package slack
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestRotateTokens(t *testing.T) {
@@ -43,3 +48,128 @@ func handleRotateToken(rw http.ResponseWriter, r *http.Request) {
 	response, _ := json.Marshal(getTestTokenResponse())
 	rw.Write(response)
 }
+
+type configTokenServer struct {
+	mu       sync.Mutex
+	valid    string
+	rotated  int
+	exported []string
+}
+
+func (s *configTokenServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tooling.tokens.rotate", func(rw http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.rotated++
+		s.valid = fmt.Sprintf("xoxe.xoxp-%d", s.rotated)
+
+		rw.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(rw).Encode(TokenResponse{
+			Token:         s.valid,
+			RefreshToken:  fmt.Sprintf("xoxe-%d", s.rotated),
+			ExpiresAt:     uint64(time.Now().Add(12 * time.Hour).Unix()),
+			SlackResponse: SlackResponse{Ok: true},
+		})
+	})
+	mux.HandleFunc("/apps.manifest.export", func(rw http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		token := r.FormValue("token")
+		s.exported = append(s.exported, token)
+
+		rw.Header().Set("Content-Type", "application/json")
+		if token != s.valid {
+			rw.Write([]byte(`{"ok":false,"error":"token_expired"}`))
+			return
+		}
+		rw.Write([]byte(`{"ok":true,"manifest":{"display_information":{"name":"Deploy Bot"}}}`))
+	})
+	return mux
+}
+
+func TestConfigTokenRotation(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		valid     string
+		exported  []string
+	}{
+		{"about to expire", time.Now().Add(time.Minute), "xoxe.xoxp-0", []string{"xoxe.xoxp-1"}},
+		{"expired unexpectedly", time.Now().Add(time.Hour), "", []string{"xoxe.xoxp-0", "xoxe.xoxp-1"}},
+		{"unknown expiration", time.Time{}, "xoxe.xoxp-0", []string{"xoxe.xoxp-0"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &configTokenServer{valid: test.valid}
+			ts := httptest.NewServer(s.handler())
+			defer ts.Close()
+
+			var persisted []*TokenResponse
+			api := New("",
+				OptionAPIURL(ts.URL+"/"),
+				OptionConfigToken("xoxe.xoxp-0"),
+				OptionConfigRefreshToken("xoxe-0"),
+				OptionConfigTokenExpiresAt(test.expiresAt),
+				OptionConfigTokenRotation(func(ctx context.Context, resp *TokenResponse) error {
+					persisted = append(persisted, resp)
+					return nil
+				}),
+			)
+
+			manifest, err := api.ExportManifestContext(context.Background(), "", "A1")
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if manifest.Display.Name != "Deploy Bot" {
+				t.Errorf("Unexpected manifest %+v", manifest)
+			}
+			if !reflect.DeepEqual(s.exported, test.exported) {
+				t.Errorf("Expected the tokens %v to be used, got %v", test.exported, s.exported)
+			}
+
+			if len(persisted) != s.rotated {
+				t.Fatalf("Expected %d persisted rotations, got %d", s.rotated, len(persisted))
+			}
+			if s.rotated > 0 {
+				token, refresh, expiresAt := api.configTokens()
+				if token != "xoxe.xoxp-1" || refresh != "xoxe-1" || time.Until(expiresAt) < 11*time.Hour {
+					t.Errorf("Unexpected tokens %s, %s, %s", token, refresh, expiresAt)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigTokenRotationExplicitToken(t *testing.T) {
+	s := &configTokenServer{valid: "xoxe.xoxp-0"}
+	ts := httptest.NewServer(s.handler())
+	defer ts.Close()
+
+	api := New("",
+		OptionAPIURL(ts.URL+"/"),
+		OptionConfigToken("xoxe.xoxp-0"),
+		OptionConfigRefreshToken("xoxe-0"),
+		OptionConfigTokenRotation(nil),
+	)
+
+	_, err := api.ExportManifestContext(context.Background(), "xoxe.xoxp-other", "A1")
+	if err == nil || err.Error() != "token_expired" {
+		t.Errorf("Expected a token_expired error, got %v", err)
+	}
+	if s.rotated != 0 {
+		t.Errorf("Expected a token not managed by the client not to be rotated")
+	}
+}
+
+func TestUpdateConfigTokensZeroClient(t *testing.T) {
+	api := &Client{}
+	api.UpdateConfigTokens(&TokenResponse{Token: "xoxe.xoxp-1", RefreshToken: "xoxe-1", ExpiresAt: 1700000000})
+
+	if token, refresh, expiresAt := api.configTokens(); token != "xoxe.xoxp-1" || refresh != "xoxe-1" || expiresAt.Unix() != 1700000000 {
+		t.Errorf("Unexpected config tokens %q %q %v", token, refresh, expiresAt)
+	}
+}
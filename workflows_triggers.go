@@ -175,3 +175,296 @@ func (api *Client) WorkflowsTriggersPermissionsSet(ctx context.Context, input *W
 
 	return response.WorkflowsTriggersPermissionsSetOutput, nil
 }
+
+// WorkflowTriggerType is the type of a workflow trigger.
+type WorkflowTriggerType string
+
+const (
+	// WorkflowTriggerTypeLink is the type of the link triggers, also known as shortcut triggers.
+	WorkflowTriggerTypeLink      WorkflowTriggerType = "shortcut"
+	WorkflowTriggerTypeScheduled WorkflowTriggerType = "scheduled"
+	WorkflowTriggerTypeEvent     WorkflowTriggerType = "event"
+	WorkflowTriggerTypeWebhook   WorkflowTriggerType = "webhook"
+)
+
+// WorkflowTriggerFrequencyType is how often a scheduled trigger runs.
+type WorkflowTriggerFrequencyType string
+
+const (
+	WorkflowTriggerFrequencyOnce    WorkflowTriggerFrequencyType = "once"
+	WorkflowTriggerFrequencyHourly  WorkflowTriggerFrequencyType = "hourly"
+	WorkflowTriggerFrequencyDaily   WorkflowTriggerFrequencyType = "daily"
+	WorkflowTriggerFrequencyWeekly  WorkflowTriggerFrequencyType = "weekly"
+	WorkflowTriggerFrequencyMonthly WorkflowTriggerFrequencyType = "monthly"
+	WorkflowTriggerFrequencyYearly  WorkflowTriggerFrequencyType = "yearly"
+)
+
+type (
+	// WorkflowTriggerInput is the value of an input of the workflow, e.g. "{{data.channel_id}}".
+	WorkflowTriggerInput struct {
+		Value        interface{} `json:"value,omitempty"`
+		Customizable bool        `json:"customizable,omitempty"`
+	}
+
+	WorkflowTriggerShortcut struct {
+		ButtonText string `json:"button_text,omitempty"`
+	}
+
+	WorkflowTriggerSchedule struct {
+		// StartTime is the time of the first run, in the RFC 3339 format.
+		StartTime string                    `json:"start_time"`
+		Timezone  string                    `json:"timezone,omitempty"`
+		Frequency *WorkflowTriggerFrequency `json:"frequency,omitempty"`
+	}
+
+	WorkflowTriggerFrequency struct {
+		Type         WorkflowTriggerFrequencyType `json:"type"`
+		RepeatsEvery int                          `json:"repeats_every,omitempty"`
+		// OnDays holds the days of the week of the weekly and monthly schedules, e.g. "Monday".
+		OnDays []string `json:"on_days,omitempty"`
+		// OnWeekNum is the week of the month of the monthly schedules, -1 for the last one.
+		OnWeekNum       int    `json:"on_week_num,omitempty"`
+		EndTime         string `json:"end_time,omitempty"`
+		OccurrenceCount int    `json:"occurrence_count,omitempty"`
+	}
+
+	WorkflowTriggerEvent struct {
+		// EventType is the type of the event, e.g. "slack#/events/reaction_added".
+		EventType    string                 `json:"event_type"`
+		ChannelIds   []string               `json:"channel_ids,omitempty"`
+		TeamIds      []string               `json:"team_ids,omitempty"`
+		AllResources bool                   `json:"all_resources,omitempty"`
+		Filter       *WorkflowTriggerFilter `json:"filter,omitempty"`
+	}
+
+	WorkflowTriggerWebhook struct {
+		Filter *WorkflowTriggerFilter `json:"filter,omitempty"`
+	}
+
+	// WorkflowTriggerFilter restricts the events or webhook requests running a trigger.
+	WorkflowTriggerFilter struct {
+		Version int                       `json:"version"`
+		Root    WorkflowTriggerFilterNode `json:"root"`
+	}
+
+	// WorkflowTriggerFilterNode is either a statement, e.g. "{{data.reaction}} == sunglasses",
+	// or an operator ("AND", "OR" or "NOT") applied to its inputs.
+	WorkflowTriggerFilterNode struct {
+		Statement string                      `json:"statement,omitempty"`
+		Operator  string                      `json:"operator,omitempty"`
+		Inputs    []WorkflowTriggerFilterNode `json:"inputs,omitempty"`
+	}
+
+	// WorkflowTriggerDefinition defines a trigger of a workflow. Only the field of its type
+	// among Shortcut, Schedule, Event and Webhook is set.
+	WorkflowTriggerDefinition struct {
+		Type        WorkflowTriggerType `json:"type"`
+		Name        string              `json:"name"`
+		Description string              `json:"description,omitempty"`
+		// Workflow references the workflow of the app, e.g. "#/workflows/my_workflow".
+		Workflow string                          `json:"workflow"`
+		Inputs   map[string]WorkflowTriggerInput `json:"inputs,omitempty"`
+		Shortcut *WorkflowTriggerShortcut        `json:"shortcut,omitempty"`
+		Schedule *WorkflowTriggerSchedule        `json:"schedule,omitempty"`
+		Event    *WorkflowTriggerEvent           `json:"event,omitempty"`
+		Webhook  *WorkflowTriggerWebhook         `json:"webhook,omitempty"`
+	}
+
+	// WorkflowTriggerWorkflow is the workflow a trigger runs.
+	WorkflowTriggerWorkflow struct {
+		ID          string `json:"id"`
+		CallbackID  string `json:"callback_id"`
+		Title       string `json:"title,omitempty"`
+		Description string `json:"description,omitempty"`
+		AppID       string `json:"app_id,omitempty"`
+	}
+
+	// WorkflowTrigger is a trigger as returned by the API.
+	WorkflowTrigger struct {
+		ID          string                          `json:"id"`
+		Type        WorkflowTriggerType             `json:"type"`
+		Name        string                          `json:"name"`
+		Description string                          `json:"description,omitempty"`
+		Workflow    WorkflowTriggerWorkflow         `json:"workflow"`
+		Inputs      map[string]WorkflowTriggerInput `json:"inputs,omitempty"`
+		Shortcut    *WorkflowTriggerShortcut        `json:"shortcut,omitempty"`
+		Schedule    *WorkflowTriggerSchedule        `json:"schedule,omitempty"`
+		Event       *WorkflowTriggerEvent           `json:"event,omitempty"`
+		Webhook     *WorkflowTriggerWebhook         `json:"webhook,omitempty"`
+		// ShortcutURL is the URL of the link triggers, WebhookURL the one of the webhook triggers.
+		ShortcutURL string `json:"shortcut_url,omitempty"`
+		WebhookURL  string `json:"webhook_url,omitempty"`
+		DateCreated int64  `json:"date_created,omitempty"`
+		DateUpdated int64  `json:"date_updated,omitempty"`
+	}
+
+	WorkflowsTriggersCreateInput struct {
+		WorkflowTriggerDefinition
+	}
+
+	WorkflowsTriggersCreateOutput struct {
+		Trigger WorkflowTrigger `json:"trigger"`
+	}
+
+	WorkflowsTriggersUpdateInput struct {
+		TriggerId string `json:"trigger_id"`
+		WorkflowTriggerDefinition
+	}
+
+	WorkflowsTriggersUpdateOutput struct {
+		Trigger WorkflowTrigger `json:"trigger"`
+	}
+
+	WorkflowsTriggersDeleteInput struct {
+		TriggerId string `json:"trigger_id"`
+	}
+
+	WorkflowsTriggersListInput struct {
+		IsOwner     bool     `json:"is_owner,omitempty"`
+		IsPublished bool     `json:"is_published,omitempty"`
+		Types       []string `json:"type,omitempty"`
+		Limit       int      `json:"limit,omitempty"`
+		Cursor      string   `json:"cursor,omitempty"`
+	}
+
+	WorkflowsTriggersListOutput struct {
+		Triggers         []WorkflowTrigger `json:"triggers"`
+		ResponseMetadata ResponseMetadata  `json:"response_metadata"`
+	}
+)
+
+// Definition returns the definition of the trigger.
+func (t WorkflowTrigger) Definition() WorkflowTriggerDefinition {
+	return WorkflowTriggerDefinition{
+		Type:        t.Type,
+		Name:        t.Name,
+		Description: t.Description,
+		Workflow:    "#/workflows/" + t.Workflow.CallbackID,
+		Inputs:      t.Inputs,
+		Shortcut:    t.Shortcut,
+		Schedule:    t.Schedule,
+		Event:       t.Event,
+		Webhook:     t.Webhook,
+	}
+}
+
+// WorkflowsTriggersCreate creates a link, scheduled, event or webhook trigger.
+//
+// Slack API Docs:https://api.slack.com/methods/workflows.triggers.create
+func (api *Client) WorkflowsTriggersCreate(ctx context.Context, input *WorkflowsTriggersCreateInput) (*WorkflowsTriggersCreateOutput, error) {
+	response := struct {
+		SlackResponse
+		*WorkflowsTriggersCreateOutput
+	}{}
+
+	jsonPayload, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal WorkflowsTriggersCreateInput: %w", err)
+	}
+
+	err = postJSON(ctx, api.httpclient, api.endpoint+"workflows.triggers.create", api.token, jsonPayload, &response, api)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := response.Err(); err != nil {
+		return nil, err
+	}
+
+	return response.WorkflowsTriggersCreateOutput, nil
+}
+
+// WorkflowsTriggersUpdate replaces the definition of a trigger.
+//
+// Slack API Docs:https://api.slack.com/methods/workflows.triggers.update
+func (api *Client) WorkflowsTriggersUpdate(ctx context.Context, input *WorkflowsTriggersUpdateInput) (*WorkflowsTriggersUpdateOutput, error) {
+	response := struct {
+		SlackResponse
+		*WorkflowsTriggersUpdateOutput
+	}{}
+
+	jsonPayload, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal WorkflowsTriggersUpdateInput: %w", err)
+	}
+
+	err = postJSON(ctx, api.httpclient, api.endpoint+"workflows.triggers.update", api.token, jsonPayload, &response, api)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := response.Err(); err != nil {
+		return nil, err
+	}
+
+	return response.WorkflowsTriggersUpdateOutput, nil
+}
+
+// WorkflowsTriggersDelete deletes a trigger.
+//
+// Slack API Docs:https://api.slack.com/methods/workflows.triggers.delete
+func (api *Client) WorkflowsTriggersDelete(ctx context.Context, input *WorkflowsTriggersDeleteInput) error {
+	response := SlackResponse{}
+
+	jsonPayload, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("failed to marshal WorkflowsTriggersDeleteInput: %w", err)
+	}
+
+	err = postJSON(ctx, api.httpclient, api.endpoint+"workflows.triggers.delete", api.token, jsonPayload, &response, api)
+	if err != nil {
+		return err
+	}
+
+	return response.Err()
+}
+
+// WorkflowsTriggersList returns a page of the triggers of the app.
+//
+// Slack API Docs:https://api.slack.com/methods/workflows.triggers.list
+func (api *Client) WorkflowsTriggersList(ctx context.Context, input *WorkflowsTriggersListInput) (*WorkflowsTriggersListOutput, error) {
+	// The response metadata of the output is the one of the SlackResponse.
+	response := struct {
+		SlackResponse
+		Triggers []WorkflowTrigger `json:"triggers"`
+	}{}
+
+	jsonPayload, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal WorkflowsTriggersListInput: %w", err)
+	}
+
+	err = postJSON(ctx, api.httpclient, api.endpoint+"workflows.triggers.list", api.token, jsonPayload, &response, api)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := response.Err(); err != nil {
+		return nil, err
+	}
+
+	return &WorkflowsTriggersListOutput{
+		Triggers:         response.Triggers,
+		ResponseMetadata: response.ResponseMetadata,
+	}, nil
+}
+
+// WorkflowsTriggersListAll returns every trigger of the app, following the cursors of
+// WorkflowsTriggersList from input.Cursor.
+func (api *Client) WorkflowsTriggersListAll(ctx context.Context, input *WorkflowsTriggersListInput) ([]WorkflowTrigger, error) {
+	params := *input
+
+	var triggers []WorkflowTrigger
+	for {
+		output, err := api.WorkflowsTriggersList(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		triggers = append(triggers, output.Triggers...)
+		if output.ResponseMetadata.Cursor == "" {
+			return triggers, nil
+		}
+		params.Cursor = output.ResponseMetadata.Cursor
+	}
+}
@@ -0,0 +1,206 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WorkflowTriggerUpdate is an update of an existing trigger planned by DiffWorkflowTriggers.
+type WorkflowTriggerUpdate struct {
+	TriggerId  string
+	Definition WorkflowTriggerDefinition
+	// Changes holds the JSON pointers of the declared values differing from the existing ones,
+	// e.g. "/schedule/frequency/type".
+	Changes []string
+}
+
+// WorkflowTriggerPlan is the list of the changes reconciling the existing triggers of an
+// app with a declared set of triggers.
+type WorkflowTriggerPlan struct {
+	Create []WorkflowTriggerDefinition
+	Update []WorkflowTriggerUpdate
+	// Delete holds the existing triggers that are not declared.
+	Delete []WorkflowTrigger
+}
+
+// Empty reports whether the plan has no change.
+func (p *WorkflowTriggerPlan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Update) == 0 && len(p.Delete) == 0
+}
+
+// String returns the changes of the plan, one per line.
+func (p *WorkflowTriggerPlan) String() string {
+	var lines []string
+	for _, def := range p.Create {
+		lines = append(lines, fmt.Sprintf("+ %s %q", def.Type, def.Name))
+	}
+	for _, u := range p.Update {
+		lines = append(lines, fmt.Sprintf("~ %s %q (%s): %s", u.Definition.Type, u.Definition.Name, u.TriggerId, strings.Join(u.Changes, ", ")))
+	}
+	for _, t := range p.Delete {
+		lines = append(lines, fmt.Sprintf("- %s %q (%s)", t.Type, t.Name, t.ID))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DiffWorkflowTriggers plans the changes turning the existing triggers into the declared ones.
+// Triggers are identified by their name, which must be unique among the declared triggers.
+// An existing trigger is updated when one of the declared values differs: the values the
+// declaration omits are ignored, as Slack fills them in with defaults. When several existing
+// triggers have the same name, the first one is kept and the others are deleted.
+func DiffWorkflowTriggers(declared []WorkflowTriggerDefinition, existing []WorkflowTrigger) (*WorkflowTriggerPlan, error) {
+	names := make(map[string]bool, len(declared))
+	for _, def := range declared {
+		if names[def.Name] {
+			return nil, fmt.Errorf("multiple declarations of the trigger %q", def.Name)
+		}
+		names[def.Name] = true
+	}
+
+	byName := make(map[string]WorkflowTrigger, len(existing))
+	plan := &WorkflowTriggerPlan{}
+	for _, t := range existing {
+		if _, dup := byName[t.Name]; dup || !names[t.Name] {
+			plan.Delete = append(plan.Delete, t)
+			continue
+		}
+		byName[t.Name] = t
+	}
+
+	for _, def := range declared {
+		t, ok := byName[def.Name]
+		if !ok {
+			plan.Create = append(plan.Create, def)
+			continue
+		}
+
+		changes, err := diffTriggerDefinitions(def, t.Definition())
+		if err != nil {
+			return nil, err
+		}
+		if len(changes) > 0 {
+			plan.Update = append(plan.Update, WorkflowTriggerUpdate{TriggerId: t.ID, Definition: def, Changes: changes})
+		}
+	}
+
+	return plan, nil
+}
+
+func diffTriggerDefinitions(declared, existing WorkflowTriggerDefinition) ([]string, error) {
+	var a, b interface{}
+	for _, v := range []struct {
+		def WorkflowTriggerDefinition
+		out *interface{}
+	}{{declared, &a}, {existing, &b}} {
+		js, err := json.Marshal(v.def)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(js, v.out); err != nil {
+			return nil, err
+		}
+	}
+
+	var changes []string
+	diffDeclaredValues(&changes, "", a, b)
+	return changes, nil
+}
+
+// diffDeclaredValues appends the paths of the values of declared differing from existing.
+// The keys missing from declared are ignored, and the lists of scalars are compared as sets.
+func diffDeclaredValues(changes *[]string, path string, declared, existing interface{}) {
+	switch dv := declared.(type) {
+	case map[string]interface{}:
+		ev, _ := existing.(map[string]interface{})
+
+		keys := make([]string, 0, len(dv))
+		for k := range dv {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			diffDeclaredValues(changes, path+"/"+k, dv[k], ev[k])
+		}
+		return
+	case []interface{}:
+		ev, _ := existing.([]interface{})
+		if len(dv) != len(ev) {
+			*changes = append(*changes, path)
+			return
+		}
+
+		if isScalarList(dv) {
+			for _, v := range dv {
+				if !containsValue(ev, v) {
+					*changes = append(*changes, path)
+					return
+				}
+			}
+			return
+		}
+
+		for i := range dv {
+			diffDeclaredValues(changes, path+"/"+strconv.Itoa(i), dv[i], ev[i])
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(declared, existing) {
+		*changes = append(*changes, path)
+	}
+}
+
+// ApplyWorkflowTriggers reconciles the triggers the client's token owns with the declared
+// ones: it plans the changes with DiffWorkflowTriggers, then creates, updates and deletes the
+// triggers. The triggers that are not declared are only deleted when prune is true, while the
+// duplicates of the declared ones are always deleted. It returns the plan it carried out, and
+// stops at the first error.
+func (api *Client) ApplyWorkflowTriggers(ctx context.Context, declared []WorkflowTriggerDefinition, prune bool) (*WorkflowTriggerPlan, error) {
+	existing, err := api.WorkflowsTriggersListAll(ctx, &WorkflowsTriggersListInput{IsOwner: true})
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := DiffWorkflowTriggers(declared, existing)
+	if err != nil {
+		return nil, err
+	}
+	if !prune {
+		names := make(map[string]bool, len(declared))
+		for _, def := range declared {
+			names[def.Name] = true
+		}
+
+		var duplicates []WorkflowTrigger
+		for _, t := range plan.Delete {
+			if names[t.Name] {
+				duplicates = append(duplicates, t)
+			}
+		}
+		plan.Delete = duplicates
+	}
+
+	for _, def := range plan.Create {
+		if _, err := api.WorkflowsTriggersCreate(ctx, &WorkflowsTriggersCreateInput{def}); err != nil {
+			return plan, fmt.Errorf("failed to create the trigger %q: %w", def.Name, err)
+		}
+	}
+	for _, u := range plan.Update {
+		if _, err := api.WorkflowsTriggersUpdate(ctx, &WorkflowsTriggersUpdateInput{u.TriggerId, u.Definition}); err != nil {
+			return plan, fmt.Errorf("failed to update the trigger %q: %w", u.Definition.Name, err)
+		}
+	}
+	for _, t := range plan.Delete {
+		if err := api.WorkflowsTriggersDelete(ctx, &WorkflowsTriggersDeleteInput{t.ID}); err != nil {
+			return plan, fmt.Errorf("failed to delete the trigger %q: %w", t.Name, err)
+		}
+	}
+
+	return plan, nil
+}
@@ -2,7 +2,10 @@ package slack
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 )
@@ -272,3 +275,139 @@ func TestSlack_WorkflowsTriggersPermissionsSet(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffWorkflowTriggers(t *testing.T) {
+	declared := []WorkflowTriggerDefinition{
+		{
+			Type:     WorkflowTriggerTypeScheduled,
+			Name:     "Standup",
+			Workflow: "#/workflows/standup",
+			Schedule: &WorkflowTriggerSchedule{
+				StartTime: "2024-01-08T09:00:00Z",
+				Timezone:  "Europe/Paris",
+				Frequency: &WorkflowTriggerFrequency{Type: WorkflowTriggerFrequencyWeekly, OnDays: []string{"Monday", "Thursday"}},
+			},
+		},
+		{
+			Type:     WorkflowTriggerTypeEvent,
+			Name:     "Triage",
+			Workflow: "#/workflows/triage",
+			Inputs:   map[string]WorkflowTriggerInput{"channel": {Value: "{{data.channel_id}}"}},
+			Event: &WorkflowTriggerEvent{
+				EventType:  "slack#/events/reaction_added",
+				ChannelIds: []string{"C1"},
+				Filter: &WorkflowTriggerFilter{
+					Version: 1,
+					Root:    WorkflowTriggerFilterNode{Statement: "{{data.reaction}} == eyes"},
+				},
+			},
+		},
+		{Type: WorkflowTriggerTypeLink, Name: "Report", Workflow: "#/workflows/report"},
+	}
+
+	existing := []WorkflowTrigger{
+		{
+			ID:       "Ft1",
+			Type:     WorkflowTriggerTypeScheduled,
+			Name:     "Standup",
+			Workflow: WorkflowTriggerWorkflow{ID: "Wf1", CallbackID: "standup"},
+			Schedule: &WorkflowTriggerSchedule{
+				StartTime: "2024-01-08T09:00:00Z",
+				Timezone:  "Europe/Paris",
+				Frequency: &WorkflowTriggerFrequency{Type: WorkflowTriggerFrequencyWeekly, RepeatsEvery: 1, OnDays: []string{"Thursday", "Monday"}},
+			},
+		},
+		{
+			ID:       "Ft2",
+			Type:     WorkflowTriggerTypeEvent,
+			Name:     "Triage",
+			Workflow: WorkflowTriggerWorkflow{ID: "Wf2", CallbackID: "triage"},
+			Inputs:   map[string]WorkflowTriggerInput{"channel": {Value: "{{data.channel_id}}"}},
+			Event: &WorkflowTriggerEvent{
+				EventType:  "slack#/events/reaction_added",
+				ChannelIds: []string{"C1"},
+				Filter: &WorkflowTriggerFilter{
+					Version: 1,
+					Root:    WorkflowTriggerFilterNode{Statement: "{{data.reaction}} == sunglasses"},
+				},
+			},
+		},
+		{ID: "Ft3", Type: WorkflowTriggerTypeWebhook, Name: "Legacy", Workflow: WorkflowTriggerWorkflow{CallbackID: "legacy"}},
+	}
+
+	plan, err := DiffWorkflowTriggers(declared, existing)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := `+ shortcut "Report"
+~ event "Triage" (Ft2): /event/filter/root/statement
+- webhook "Legacy" (Ft3)`
+	if plan.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, plan)
+	}
+
+	if _, err := DiffWorkflowTriggers(append(declared, declared[0]), existing); err == nil {
+		t.Error("Expected an error for duplicated declarations")
+	}
+}
+
+func TestApplyWorkflowTriggers(t *testing.T) {
+	var calls []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/workflows.triggers.list", func(w http.ResponseWriter, r *http.Request) {
+		var input WorkflowsTriggersListInput
+		json.NewDecoder(r.Body).Decode(&input)
+
+		w.Header().Set("Content-Type", "application/json")
+		if input.Cursor == "" {
+			w.Write([]byte(`{"ok":true,"triggers":[{"id":"Ft1","type":"shortcut","name":"Report","workflow":{"id":"Wf1","callback_id":"report"}}],"response_metadata":{"next_cursor":"next"}}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"triggers":[{"id":"Ft2","type":"webhook","name":"Legacy","workflow":{"id":"Wf2","callback_id":"legacy"}},{"id":"Ft3","type":"shortcut","name":"Report","workflow":{"id":"Wf1","callback_id":"report"}}]}`))
+	})
+	for _, method := range []string{"create", "update", "delete"} {
+		method := method
+		mux.HandleFunc("/workflows.triggers."+method, func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			calls = append(calls, method+" "+string(body))
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"ok":true,"trigger":{"id":"Ft9"}}`))
+		})
+	}
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	api := New("testing-token", OptionAPIURL(ts.URL+"/"))
+	declared := []WorkflowTriggerDefinition{
+		{Type: WorkflowTriggerTypeLink, Name: "Report", Description: "Weekly report", Workflow: "#/workflows/report"},
+		{Type: WorkflowTriggerTypeWebhook, Name: "Deploy", Workflow: "#/workflows/deploy"},
+	}
+
+	plan, err := api.ApplyWorkflowTriggers(context.Background(), declared, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	// The duplicate trigger of Report is deleted even without pruning.
+	if len(plan.Delete) != 1 || plan.Delete[0].ID != "Ft3" {
+		t.Errorf("Expected only the duplicate trigger to be deleted without pruning, got %v", plan.Delete)
+	}
+
+	expected := []string{
+		`create {"type":"webhook","name":"Deploy","workflow":"#/workflows/deploy"}`,
+		`update {"trigger_id":"Ft1","type":"shortcut","name":"Report","description":"Weekly report","workflow":"#/workflows/report"}`,
+		`delete {"trigger_id":"Ft3"}`,
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected %v, got %v", expected, calls)
+	}
+
+	calls = nil
+	if _, err := api.ApplyWorkflowTriggers(context.Background(), declared, true); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(calls) != 4 || calls[2] != `delete {"trigger_id":"Ft2"}` || calls[3] != `delete {"trigger_id":"Ft3"}` {
+		t.Errorf("Expected the undeclared and duplicate triggers to be deleted, got %v", calls)
+	}
+}